@@ -9,18 +9,25 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 
+	"github.com/kjstillabower/weather-alert-service/internal/alerts"
 	"github.com/kjstillabower/weather-alert-service/internal/cache"
+	"github.com/kjstillabower/weather-alert-service/internal/changes"
 	"github.com/kjstillabower/weather-alert-service/internal/circuitbreaker"
-	"github.com/kjstillabower/weather-alert-service/internal/client"
 	"github.com/kjstillabower/weather-alert-service/internal/config"
+	"github.com/kjstillabower/weather-alert-service/internal/groups"
+	"github.com/kjstillabower/weather-alert-service/internal/history"
 	httphandler "github.com/kjstillabower/weather-alert-service/internal/http"
 	"github.com/kjstillabower/weather-alert-service/internal/lifecycle"
+	"github.com/kjstillabower/weather-alert-service/internal/notify"
 	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/reports"
 	"github.com/kjstillabower/weather-alert-service/internal/service"
+	"github.com/kjstillabower/weather-alert-service/internal/stream"
+	"github.com/kjstillabower/weather-alert-service/internal/subscriptions"
+	"github.com/kjstillabower/weather-alert-service/internal/watchlist"
 )
 
 func main() {
@@ -36,17 +43,11 @@ func main() {
 		logger.Fatal("config", zap.Error(err))
 	}
 
-	weatherClient, err := client.NewOpenWeatherClientWithRetry(
-		cfg.WeatherAPIKey,
-		cfg.WeatherAPIURL,
-		cfg.WeatherAPITimeout,
-		cfg.RetryAttempts,
-		cfg.RetryBaseDelay,
-		cfg.RetryMaxDelay,
-	)
+	weatherClient, err := newWeatherClient(cfg)
 	if err != nil {
 		logger.Fatal("weather client", zap.Error(err))
 	}
+	logger.Info("weather provider", zap.String("provider", cfg.WeatherProvider), zap.String("url", cfg.WeatherAPIURL))
 
 	if cfg.CircuitBreakerEnabled {
 		cb := circuitbreaker.New(circuitbreaker.Config{
@@ -80,6 +81,32 @@ func main() {
 		logger.Info("cache backend: in_memory")
 	}
 	weatherService := service.NewWeatherService(weatherClient, cacheSvc, cfg.CacheTTL, cfg.StaleCacheTTL, cfg.CoalesceEnabled, cfg.CoalesceTimeout)
+	weatherService.SetCacheNamespace(cfg.WeatherProvider)
+	weatherService.SetOfficialAlertsTTL(cfg.OfficialAlertsTTL)
+	weatherService.SetForecastTTL(cfg.ForecastTTL)
+	weatherService.SetSearchTTL(cfg.SearchTTL)
+	weatherService.SetAirQualityTTL(cfg.AirQualityTTL)
+
+	alertRules := make([]alerts.RuleConfig, 0, len(cfg.AlertRules))
+	for _, r := range cfg.AlertRules {
+		alertRules = append(alertRules, alerts.RuleConfig{
+			Name:      r.Name,
+			Type:      r.Type,
+			Severity:  r.Severity,
+			Threshold: r.Threshold,
+			Min:       r.Min,
+			Max:       r.Max,
+			Pattern:   r.Pattern,
+			Locations: r.Locations,
+		})
+	}
+	alertEngine, err := alerts.NewEngine(alertRules, cfg.AlertResolvedRetention, logger)
+	if err != nil {
+		logger.Fatal("alert rules", zap.Error(err))
+	}
+	alertEngine.SetStaleAfter(cfg.AlertStaleAfter)
+	weatherService.AddFetchHook(alertEngine.Observe)
+	logger.Info("alert rules loaded", zap.Int("rules", len(alertRules)))
 
 	healthConfig := &httphandler.HealthConfig{
 		OverloadWindow:         cfg.OverloadWindow,
@@ -104,45 +131,196 @@ func main() {
 		limiter = rate.NewLimiter(rate.Limit(cfg.RateLimitRPS), cfg.RateLimitBurst)
 	}
 	handler := httphandler.NewHandler(weatherService, weatherClient, healthConfig, logger, limiter, cfg.LocationMaxLength, cfg.LocationMinLength)
+	handler.SetBatchLimits(cfg.BatchMaxLocations, cfg.BatchConcurrency)
+	handler.SetAlertEngine(alertEngine)
 
-	observability.RegisterRateLimitGauges(cfg.OverloadWindow)
-	if len(cfg.TrackedLocations) > 0 {
-		observability.SetTrackedLocations(cfg.TrackedLocations)
-	}
+	bgCtx, bgCancel := context.WithCancel(context.Background())
+	defer bgCancel()
 
-	if cfg.WarmCache && len(cfg.TrackedLocations) > 0 {
-		warmer := cache.NewCacheWarmer(weatherService, logger)
-		warmCtx, warmCancel := context.WithTimeout(context.Background(), 30*time.Second)
-		if err := warmer.Warm(warmCtx, cfg.TrackedLocations); err != nil {
-			logger.Warn("cache warming failed", zap.Error(err))
+	if cfg.SubscriptionsEnabled {
+		policy, err := subscriptions.NewAddressPolicy(cfg.WebhookAllowedHosts)
+		if err != nil {
+			logger.Fatal("webhook allowed hosts", zap.Error(err))
 		}
-		warmCancel()
-		if cfg.WarmInterval > 0 {
+		dispatcher := subscriptions.NewDispatcher(subscriptions.DeliveryConfig{
+			Timeout:        cfg.WebhookTimeout,
+			MaxAttempts:    cfg.WebhookMaxAttempts,
+			BaseDelay:      cfg.WebhookBaseDelay,
+			MaxDelay:       cfg.WebhookMaxDelay,
+			Workers:        cfg.WebhookWorkers,
+			QueueSize:      cfg.WebhookQueueSize,
+			DeadLetterSize: cfg.WebhookDeadLetterSize,
+			Policy:         policy,
+		}, logger)
+		subStore := subscriptions.NewStore(cfg.SubscriptionMaxCount, policy)
+		poller := subscriptions.NewPoller(subStore, weatherService, dispatcher, cfg.RequestTimeout, logger)
+		go dispatcher.Run(bgCtx)
+		go func() {
+			if err := poller.Run(bgCtx, cfg.SubscriptionPollInterval); err != nil && err != context.Canceled {
+				logger.Error("subscription poller stopped", zap.Error(err))
+			}
+		}()
+		handler.SetSubscriptions(subStore, dispatcher)
+		logger.Info("alert subscriptions enabled", zap.Duration("poll_interval", cfg.SubscriptionPollInterval))
+	}
+
+	var streamHub *stream.Hub
+	if cfg.StreamEnabled {
+		streamHub = stream.NewHub(stream.Config{
+			MaxStreams:   cfg.StreamMaxStreams,
+			ReplayBuffer: cfg.StreamReplayBuffer,
+		})
+		weatherService.AddFetchHook(streamHub.Publish)
+		handler.SetStreamHub(streamHub, httphandler.StreamConfig{
+			KeepAlive:    cfg.StreamKeepAlive,
+			FetchTimeout: cfg.RequestTimeout,
+		})
+		if cfg.StreamRefreshInterval > 0 {
 			go func() {
-				if err := warmer.WarmPeriodic(context.Background(), cfg.TrackedLocations, cfg.WarmInterval); err != nil && err != context.Canceled {
-					logger.Error("periodic cache warming stopped", zap.Error(err))
+				if err := streamHub.RunRefresh(bgCtx, weatherService, cfg.StreamRefreshInterval, cfg.RequestTimeout, logger); err != nil && err != context.Canceled {
+					logger.Error("stream refresh stopped", zap.Error(err))
 				}
 			}()
 		}
+		logger.Info("weather streams enabled", zap.Int("max_streams", cfg.StreamMaxStreams))
 	}
 
-	router := mux.NewRouter()
-	router.Use(httphandler.CorrelationIDMiddleware(logger))
-	router.Use(httphandler.MetricsMiddleware)
-	router.Use(httphandler.SizeMetricsMiddleware)
-	router.HandleFunc("/health", handler.GetHealth).Methods("GET")
-	router.Handle("/metrics", observability.MetricsHandler())
-	weatherRouter := router.PathPrefix("/weather").Subrouter()
-	weatherRouter.Use(httphandler.RateLimitMiddleware(limiter))
-	weatherRouter.Use(httphandler.TimeoutMiddleware(cfg.RequestTimeout))
-	weatherRouter.HandleFunc("/{location}", handler.GetWeather).Methods("GET")
+	if cfg.ChangesEnabled {
+		changeLog := changes.NewLog(changes.Config{
+			TemperatureDelta: cfg.ChangesTemperatureDelta,
+			WindDelta:        cfg.ChangesWindDelta,
+			MaxEvents:        cfg.ChangesMaxEvents,
+			MaxLocations:     cfg.ChangesMaxLocations,
+		})
+		weatherService.AddFetchHook(changeLog.Observe)
+		handler.SetChangeLog(changeLog)
+	}
 
-	if cfg.TestingMode {
-		logger.Warn("Testing mode enabled; /test endpoint exposed")
-		router.HandleFunc("/test", handler.GetTestStatus).Methods("GET")
-		router.HandleFunc("/test/{action}", handler.PostTestAction).Methods("POST")
+	var historyStore *history.Store
+	historyDone := make(chan struct{})
+	if cfg.HistoryEnabled {
+		historyStore, err = history.Open(history.Config{
+			Path:           cfg.HistoryFile,
+			Retention:      cfg.HistoryRetention,
+			MaxPerLocation: cfg.HistoryMaxPerLocation,
+			MaxLocations:   cfg.HistoryMaxLocations,
+		}, logger)
+		if err != nil {
+			logger.Fatal("history store", zap.Error(err))
+		}
+		weatherService.AddFetchHook(historyStore.Record)
+		handler.SetHistory(historyStore, cfg.HistoryTrendWindow)
+		go func() {
+			defer close(historyDone)
+			historyStore.Run(bgCtx, cfg.HistoryPruneInterval)
+		}()
+		logger.Info("observation history enabled", zap.String("file", cfg.HistoryFile), zap.Duration("retention", cfg.HistoryRetention))
 	}
 
+	var healthMonitor *notify.Monitor
+	if cfg.HealthNotifyEnabled {
+		var notifiers []notify.Notifier
+		for _, url := range cfg.HealthNotifyWebhooks {
+			notifiers = append(notifiers, notify.NewWebhookNotifier(url))
+		}
+		if cfg.SMTPAddr != "" {
+			smtpNotifier, err := notify.NewSMTPNotifier(notify.SMTPConfig{
+				Addr:     cfg.SMTPAddr,
+				From:     cfg.SMTPFrom,
+				To:       cfg.SMTPTo,
+				Username: cfg.SMTPUsername,
+				Password: cfg.SMTPPassword,
+			})
+			if err != nil {
+				logger.Fatal("smtp notifier", zap.Error(err))
+			}
+			notifiers = append(notifiers, smtpNotifier)
+		}
+		healthMonitor = notify.NewMonitor(notify.Config{
+			Debounce: cfg.HealthNotifyDebounce,
+			Timeout:  cfg.HealthNotifyTimeout,
+		}, handler.HealthSnapshot, notifiers, logger)
+		go func() {
+			if err := healthMonitor.Run(bgCtx, cfg.HealthNotifyInterval); err != nil && err != context.Canceled {
+				logger.Error("health monitor stopped", zap.Error(err))
+			}
+		}()
+		logger.Info("health notifications enabled", zap.Int("notifiers", len(notifiers)), zap.Duration("interval", cfg.HealthNotifyInterval))
+	}
+
+	if len(cfg.ReportJobs) > 0 {
+		reportJobs := make([]reports.JobConfig, 0, len(cfg.ReportJobs))
+		for _, j := range cfg.ReportJobs {
+			reportJobs = append(reportJobs, reports.JobConfig{
+				Name:         j.Name,
+				Schedule:     j.Schedule,
+				Locations:    j.Locations,
+				Template:     j.Template,
+				TemplateFile: j.TemplateFile,
+				Webhook:      j.Webhook,
+				File:         j.File,
+			})
+		}
+		scheduler, err := reports.NewScheduler(reportJobs, weatherService, reports.Config{
+			Location:    cfg.ReportLocation,
+			Timeout:     cfg.ReportTimeout,
+			MaxAttempts: cfg.ReportMaxAttempts,
+			RetryDelay:  cfg.ReportRetryDelay,
+			Concurrency: cfg.ReportConcurrency,
+		}, logger)
+		if err != nil {
+			logger.Fatal("report jobs", zap.Error(err))
+		}
+		go func() {
+			if err := scheduler.Run(bgCtx); err != nil && err != context.Canceled {
+				logger.Error("report scheduler stopped", zap.Error(err))
+			}
+		}()
+		logger.Info("report jobs scheduled", zap.Int("jobs", len(reportJobs)), zap.String("timezone", cfg.ReportLocation.String()))
+	}
+
+	observability.RegisterRateLimitGauges(cfg.OverloadWindow)
+
+	watch, err := watchlist.Open(cfg.WatchlistFile, cfg.TrackedLocations, cfg.WatchlistMaxLocations)
+	if err != nil {
+		logger.Fatal("watchlist", zap.Error(err))
+	}
+	warmTrigger := make(chan struct{}, 1)
+	watch.OnChange(func(locations []string) {
+		observability.SetTrackedLocations(locations)
+		select {
+		case warmTrigger <- struct{}{}:
+		default:
+		}
+	})
+	handler.SetWatchlist(watch)
+	logger.Info("watchlist loaded", zap.String("file", cfg.WatchlistFile), zap.Int("locations", len(watch.List())))
+
+	locationGroups, err := groups.Open(cfg.GroupsFile, cfg.Groups, cfg.GroupsMaxMembers)
+	if err != nil {
+		logger.Fatal("location groups", zap.Error(err))
+	}
+	handler.SetGroups(locationGroups)
+	logger.Info("location groups loaded", zap.String("file", cfg.GroupsFile), zap.Int("groups", len(locationGroups.List())))
+
+	if cfg.WarmCache {
+		warmer := cache.NewCacheWarmer(weatherService, logger)
+		if locations := watch.List(); len(locations) > 0 {
+			warmCtx, warmCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if err := warmer.Warm(warmCtx, locations); err != nil {
+				logger.Warn("cache warming failed", zap.Error(err))
+			}
+			warmCancel()
+		}
+		go func() {
+			if err := warmer.WarmPeriodicFrom(bgCtx, watch.List, cfg.WarmInterval, warmTrigger); err != nil && err != context.Canceled {
+				logger.Error("periodic cache warming stopped", zap.Error(err))
+			}
+		}()
+	}
+
+	router := newRouter(cfg, handler, limiter, logger)
+
 	srv := &http.Server{
 		Addr:         ":" + cfg.ServerPort,
 		Handler:      router,
@@ -163,12 +341,26 @@ func main() {
 
 	logger.Info("graceful shutdown triggered")
 	lifecycle.SetShuttingDown(true)
+	if streamHub != nil {
+		streamHub.Close()
+	}
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		logger.Error("server shutdown", zap.Error(err))
 	}
 
+	if healthMonitor != nil {
+		healthMonitor.Check(context.Background()) // notify shutting-down before background work stops
+	}
+	bgCancel()
+	if historyStore != nil {
+		<-historyDone
+		if err := historyStore.Close(); err != nil {
+			logger.Warn("history store close", zap.Error(err))
+		}
+	}
+
 	inFlight := httphandler.InFlightCount()
 	logger.Info("waiting for in-flight requests", zap.Int64("count", inFlight))
 	observability.RecordShutdownInFlight(inFlight)
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/kjstillabower/weather-alert-service/internal/circuitbreaker"
+	"github.com/kjstillabower/weather-alert-service/internal/client"
+	"github.com/kjstillabower/weather-alert-service/internal/config"
+)
+
+// providerClient is a weather client that accepts the optional circuit breaker.
+type providerClient interface {
+	client.WeatherClient
+	SetCircuitBreaker(cb *circuitbreaker.CircuitBreaker)
+}
+
+// newWeatherClient creates the client for weather_api.provider with the configured retry
+// settings.
+func newWeatherClient(cfg *config.Config) (providerClient, error) {
+	switch cfg.WeatherProvider {
+	case "open-meteo":
+		return client.NewOpenMeteoClient(cfg.WeatherAPIKey, cfg.WeatherAPIURL, cfg.GeocodingURL, cfg.WeatherAPITimeout, cfg.RetryAttempts, cfg.RetryBaseDelay, cfg.RetryMaxDelay)
+	case "nws":
+		return client.NewNWSClient(cfg.WeatherAPIURL, cfg.GeocodingURL, cfg.WeatherUserAgent, cfg.WeatherAPITimeout, cfg.RetryAttempts, cfg.RetryBaseDelay, cfg.RetryMaxDelay)
+	case "openweathermap":
+		return client.NewOpenWeatherClientWithRetry(cfg.WeatherAPIKey, cfg.WeatherAPIURL, cfg.WeatherAPITimeout, cfg.RetryAttempts, cfg.RetryBaseDelay, cfg.RetryMaxDelay)
+	}
+	return nil, fmt.Errorf("unknown weather provider %q", cfg.WeatherProvider)
+}
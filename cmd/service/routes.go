@@ -0,0 +1,107 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/kjstillabower/weather-alert-service/internal/config"
+	httphandler "github.com/kjstillabower/weather-alert-service/internal/http"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+)
+
+// newRouter registers every HTTP route, honoring the feature flags in cfg. limiter may be nil
+// (rate limiting disabled).
+func newRouter(cfg *config.Config, handler *httphandler.Handler, limiter *rate.Limiter, logger *zap.Logger) *mux.Router {
+	router := mux.NewRouter()
+	router.Use(httphandler.CorrelationIDMiddleware(logger))
+	router.Use(httphandler.MetricsMiddleware)
+	router.Use(httphandler.SizeMetricsMiddleware)
+	router.HandleFunc("/health", handler.GetHealth).Methods("GET")
+	router.Handle("/metrics", observability.MetricsHandler())
+	router.Handle("/openapi.json", httphandler.OpenAPIHandler(router)).Methods("GET")
+	router.HandleFunc("/alerts", handler.GetAlerts).Methods("GET")
+	if cfg.SubscriptionsEnabled {
+		subscriptionsRouter := router.PathPrefix("/alerts/subscriptions").Subrouter()
+		subscriptionsRouter.Use(httphandler.RateLimitMiddleware(limiter))
+		subscriptionsRouter.Use(httphandler.TimeoutMiddleware(cfg.RequestTimeout))
+		subscriptionsRouter.HandleFunc("", handler.CreateSubscription).Methods("POST")
+		subscriptionsRouter.HandleFunc("/{id}", handler.GetSubscription).Methods("GET")
+		subscriptionsRouter.HandleFunc("/{id}", handler.DeleteSubscription).Methods("DELETE")
+	}
+	// registerAPI mounts the /weather, /locations and /groups routes on api, wrapped in mw. Current
+	// weather handlers differ by API version; the other routes are shared.
+	registerAPI := func(api *mux.Router, getWeather, getWeatherByCoordinates http.HandlerFunc, mw ...mux.MiddlewareFunc) {
+		if cfg.StreamEnabled {
+			// Registered ahead of the /weather subrouter so TimeoutMiddleware does not end
+			// long-lived streams; rate limiting still applies to each connection attempt.
+			stream := httphandler.RateLimitMiddleware(limiter)(http.HandlerFunc(handler.StreamWeather))
+			for _, m := range mw {
+				stream = m(stream)
+			}
+			api.Handle("/weather/{location}/stream", stream).Methods("GET")
+		}
+		locationsRouter := api.PathPrefix("/locations").Subrouter()
+		locationsRouter.Use(mw...)
+		locationsRouter.Use(httphandler.RateLimitMiddleware(limiter))
+		locationsRouter.Use(httphandler.TimeoutMiddleware(cfg.RequestTimeout))
+		locationsRouter.HandleFunc("/search", handler.SearchLocations).Methods("GET")
+		groupsRouter := api.PathPrefix("/groups").Subrouter()
+		groupsRouter.Use(mw...)
+		groupsRouter.Use(httphandler.RateLimitMiddleware(limiter))
+		groupsRouter.Use(httphandler.TimeoutMiddleware(cfg.RequestTimeout))
+		groupsRouter.HandleFunc("/{name}/weather", handler.GetGroupWeather).Methods("GET")
+		weatherRouter := api.PathPrefix("/weather").Subrouter()
+		weatherRouter.Use(mw...)
+		weatherRouter.Use(httphandler.RateLimitMiddleware(limiter))
+		weatherRouter.Use(httphandler.TimeoutMiddleware(cfg.RequestTimeout))
+		weatherRouter.HandleFunc("", getWeatherByCoordinates).Methods("GET")
+		weatherRouter.HandleFunc("/batch", handler.GetWeatherBatch).Methods("POST")
+		weatherRouter.HandleFunc("/{location}", getWeather).Methods("GET")
+		weatherRouter.HandleFunc("/{location}/evaluate", handler.EvaluateWeather).Methods("POST")
+		weatherRouter.HandleFunc("/{location}/forecast", handler.GetForecast).Methods("GET")
+		weatherRouter.HandleFunc("/{location}/air-quality", handler.GetAirQuality).Methods("GET")
+		weatherRouter.HandleFunc("/{location}/alerts", handler.GetOfficialAlerts).Methods("GET")
+		weatherRouter.HandleFunc("/{location}/alerts/{id}", handler.GetOfficialAlert).Methods("GET")
+		if cfg.ChangesEnabled {
+			weatherRouter.HandleFunc("/{location}/changes", handler.GetWeatherChanges).Methods("GET")
+		}
+		if cfg.HistoryEnabled {
+			weatherRouter.HandleFunc("/{location}/history", handler.GetWeatherHistory).Methods("GET")
+			weatherRouter.HandleFunc("/{location}/trend", handler.GetWeatherTrend).Methods("GET")
+		}
+	}
+	registerAPI(router.PathPrefix("/v1").Subrouter(), handler.GetWeather, handler.GetWeatherByCoordinates)
+	registerAPI(router.PathPrefix("/v2").Subrouter(), handler.GetWeatherV2, handler.GetWeatherByCoordinatesV2)
+	// Unversioned paths serve the /v1 contract and are deprecated as of the /v1 release.
+	registerAPI(router, handler.GetWeather, handler.GetWeatherByCoordinates, httphandler.DeprecationMiddleware(time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)))
+
+	if cfg.AdminAPIToken != "" {
+		adminRouter := router.PathPrefix("/admin").Subrouter()
+		adminRouter.Use(httphandler.AdminAuthMiddleware(cfg.AdminAPIToken))
+		adminRouter.HandleFunc("/watchlist", handler.ListWatchlist).Methods("GET")
+		adminRouter.HandleFunc("/watchlist/{location}", handler.GetWatchlistLocation).Methods("GET")
+		adminRouter.HandleFunc("/watchlist/{location}", handler.PutWatchlistLocation).Methods("PUT")
+		adminRouter.HandleFunc("/watchlist/{location}", handler.DeleteWatchlistLocation).Methods("DELETE")
+		adminRouter.HandleFunc("/groups", handler.ListGroups).Methods("GET")
+		adminRouter.HandleFunc("/groups/{name}", handler.GetGroup).Methods("GET")
+		adminRouter.HandleFunc("/groups/{name}", handler.PutGroup).Methods("PUT")
+		adminRouter.HandleFunc("/groups/{name}", handler.DeleteGroup).Methods("DELETE")
+		if cfg.SubscriptionsEnabled {
+			adminRouter.HandleFunc("/subscriptions", handler.ListSubscriptions).Methods("GET")
+			adminRouter.HandleFunc("/subscriptions/dead-letters", handler.GetDeadLetters).Methods("GET")
+		}
+	} else {
+		logger.Warn("ADMIN_API_TOKEN not set; /admin endpoints disabled")
+	}
+
+	if cfg.TestingMode {
+		logger.Warn("Testing mode enabled; /test endpoint exposed")
+		router.HandleFunc("/test", handler.GetTestStatus).Methods("GET")
+		router.HandleFunc("/test/{action}", handler.PostTestAction).Methods("POST")
+	}
+	return router
+}
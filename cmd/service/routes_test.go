@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/kjstillabower/weather-alert-service/internal/config"
+	httphandler "github.com/kjstillabower/weather-alert-service/internal/http"
+)
+
+// allFeaturesConfig enables every optional route group.
+func allFeaturesConfig() *config.Config {
+	return &config.Config{
+		RequestTimeout:       time.Second,
+		SubscriptionsEnabled: true,
+		StreamEnabled:        true,
+		ChangesEnabled:       true,
+		HistoryEnabled:       true,
+		AdminAPIToken:        "test-token",
+		TestingMode:          true,
+	}
+}
+
+func newTestRouterSpec(t *testing.T, cfg *config.Config) (paths map[string]map[string]json.RawMessage, undocumented []string) {
+	t.Helper()
+	handler := httphandler.NewHandler(nil, nil, nil, zap.NewNop(), nil, 100, 1)
+	spec, undocumented, err := httphandler.OpenAPISpec(newRouter(cfg, handler, nil, zap.NewNop()))
+	if err != nil {
+		t.Fatalf("OpenAPISpec: %v", err)
+	}
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+	return doc.Paths, undocumented
+}
+
+// TestNewRouter_EveryRouteDocumented fails when a route is registered without an OpenAPI
+// operation. Add the operation to internal/http/openapi.yaml.
+func TestNewRouter_EveryRouteDocumented(t *testing.T) {
+	_, undocumented := newTestRouterSpec(t, allFeaturesConfig())
+
+	for _, route := range undocumented {
+		t.Errorf("route %s has no entry in internal/http/openapi.yaml", route)
+	}
+}
+
+// TestNewRouter_SpecFollowsFeatureFlags verifies disabled features are left out of the spec
+// and versioned copies are present.
+func TestNewRouter_SpecFollowsFeatureFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		path    string
+		method  string
+		present bool
+	}{
+		{name: "testing mode on", cfg: allFeaturesConfig(), path: "/test/{action}", method: "post", present: true},
+		{name: "testing mode off", cfg: &config.Config{}, path: "/test/{action}", method: "post"},
+		{name: "history off", cfg: &config.Config{}, path: "/v1/weather/{location}/history", method: "get"},
+		{name: "v1 weather", cfg: &config.Config{}, path: "/v1/weather/{location}", method: "get", present: true},
+		{name: "v2 forecast", cfg: &config.Config{}, path: "/v2/weather/{location}/forecast", method: "get", present: true},
+		{name: "unversioned batch", cfg: &config.Config{}, path: "/weather/batch", method: "post", present: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			paths, _ := newTestRouterSpec(t, tc.cfg)
+			_, present := paths[tc.path][tc.method]
+			if present != tc.present {
+				t.Errorf("%s %s present = %v, want %v", tc.method, tc.path, present, tc.present)
+			}
+		})
+	}
+}
+
+// TestNewRouter_SubscriptionRoutes verifies the public subscription routes are rate limited
+// and that listing subscriptions and dead letters is admin-only.
+func TestNewRouter_SubscriptionRoutes(t *testing.T) {
+	// Arrange: a bucket with one token that never refills
+	handler := httphandler.NewHandler(nil, nil, nil, zap.NewNop(), nil, 100, 1)
+	router := newRouter(allFeaturesConfig(), handler, rate.NewLimiter(0, 1), zap.NewNop())
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   int
+	}{
+		{name: "first create", method: "POST", path: "/alerts/subscriptions", want: http.StatusServiceUnavailable},
+		{name: "second create", method: "POST", path: "/alerts/subscriptions", want: http.StatusTooManyRequests},
+		{name: "get by id", method: "GET", path: "/alerts/subscriptions/some-id", want: http.StatusTooManyRequests},
+		{name: "public list removed", method: "GET", path: "/alerts/subscriptions", want: http.StatusNotFound},
+		{name: "admin list without token", method: "GET", path: "/admin/subscriptions", want: http.StatusUnauthorized},
+		{name: "dead letters without token", method: "GET", path: "/admin/subscriptions/dead-letters", want: http.StatusUnauthorized},
+	}
+
+	for _, tc := range tests {
+		// Act
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+
+		// Assert
+		if w.Code != tc.want {
+			t.Errorf("%s: %s %s status = %d, want %d", tc.name, tc.method, tc.path, w.Code, tc.want)
+		}
+	}
+}
+
+// TestNewRouter_ServesOpenAPI verifies GET /openapi.json serves the document.
+func TestNewRouter_ServesOpenAPI(t *testing.T) {
+	// Arrange
+	handler := httphandler.NewHandler(nil, nil, nil, zap.NewNop(), nil, 100, 1)
+	router := newRouter(&config.Config{}, handler, nil, zap.NewNop())
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var doc map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if doc["openapi"] != "3.1.0" {
+		t.Errorf("openapi = %v, want 3.1.0", doc["openapi"])
+	}
+}
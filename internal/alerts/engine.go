@@ -0,0 +1,229 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// Status is the lifecycle state of a rule for a location.
+type Status string
+
+// Alert statuses reported by GET /alerts.
+const (
+	StatusFiring   Status = "firing"
+	StatusResolved Status = "resolved"
+)
+
+// State is the current alert state of one rule for one location.
+type State struct {
+	Rule          string    `json:"rule"`
+	Type          RuleType  `json:"type"`
+	Location      string    `json:"location"`
+	Severity      Severity  `json:"severity"`
+	Status        Status    `json:"status"`
+	Value         string    `json:"value"`
+	Message       string    `json:"message"`
+	Since         time.Time `json:"since"`         // when Status last changed
+	LastEvaluated time.Time `json:"lastEvaluated"` // observation time of the last evaluated reading
+}
+
+// Transition describes a status change produced by Evaluate.
+type Transition struct {
+	From  Status // empty when the rule fires for the first time for the location
+	State State
+}
+
+// stateKey identifies alert state by rule name and normalized location.
+type stateKey struct {
+	rule     string
+	location string
+}
+
+// Engine evaluates threshold rules against fresh weather data and tracks firing/resolved state
+// per rule and location. Only rules that have fired at least once have state; resolved state
+// is kept for resolvedRetention so consumers can see recent clears, then pruned. Any state whose
+// location has not been evaluated within staleAfter is pruned too (see SetStaleAfter).
+type Engine struct {
+	rules             []Rule
+	resolvedRetention time.Duration
+	staleAfter        time.Duration
+	logger            *zap.Logger
+
+	mu     sync.RWMutex // protects states
+	states map[stateKey]*State
+}
+
+// NewEngine validates the rule configs and returns an Engine. Rule names must be unique.
+// resolvedRetention <= 0 keeps resolved state until the rule fires again. logger may be nil.
+func NewEngine(configs []RuleConfig, resolvedRetention time.Duration, logger *zap.Logger) (*Engine, error) {
+	rules := make([]Rule, 0, len(configs))
+	seen := make(map[string]struct{}, len(configs))
+	for _, cfg := range configs {
+		r, err := NewRule(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := seen[r.Name]; dup {
+			return nil, fmt.Errorf("alert rule %q: duplicate name", r.Name)
+		}
+		seen[r.Name] = struct{}{}
+		rules = append(rules, r)
+	}
+	return &Engine{
+		rules:             rules,
+		resolvedRetention: resolvedRetention,
+		logger:            logger,
+		states:            make(map[stateKey]*State),
+	}, nil
+}
+
+// SetStaleAfter drops states whose last reading is older than d, whatever their status, so a
+// location that fired once and is no longer fetched does not stay firing forever. d <= 0 keeps
+// states until they resolve. Call during startup before serving traffic.
+func (e *Engine) SetStaleAfter(d time.Duration) {
+	e.staleAfter = d
+}
+
+// Rules returns the configured rules in config order.
+func (e *Engine) Rules() []Rule {
+	return append([]Rule(nil), e.rules...)
+}
+
+// Observe evaluates data for location and logs any transitions. Signature matches
+// service.FetchHook so the engine can be registered with WeatherService.AddFetchHook.
+func (e *Engine) Observe(ctx context.Context, location string, data models.WeatherData) {
+	for _, t := range e.Evaluate(location, data) {
+		if e.logger != nil {
+			e.logger.Info("alert transition",
+				zap.String("rule", t.State.Rule),
+				zap.String("location", t.State.Location),
+				zap.String("severity", string(t.State.Severity)),
+				zap.String("from", string(t.From)),
+				zap.String("to", string(t.State.Status)),
+				zap.String("message", t.State.Message))
+		}
+	}
+}
+
+// Evaluate runs every applicable rule against data for location and returns the resulting
+// transitions (first fire, firing -> resolved, resolved -> firing). Readings that do not
+// change status only refresh Value and LastEvaluated.
+func (e *Engine) Evaluate(location string, data models.WeatherData) []Transition {
+	loc := validation.NormalizeLocation(location)
+	evaluatedAt := data.Timestamp
+	if evaluatedAt.IsZero() {
+		evaluatedAt = time.Now()
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var transitions []Transition
+	for _, r := range e.rules {
+		if !r.AppliesTo(loc) {
+			continue
+		}
+		firing, value, msg := r.Match(data)
+		key := stateKey{rule: r.Name, location: loc}
+		st, exists := e.states[key]
+		if !exists {
+			if !firing {
+				continue
+			}
+			st = &State{Rule: r.Name, Type: r.Type, Location: loc, Severity: r.Severity}
+			e.states[key] = st
+		}
+		st.Value = value
+		st.LastEvaluated = evaluatedAt
+		next := StatusResolved
+		if firing {
+			next = StatusFiring
+			st.Message = msg
+		}
+		if st.Status == next {
+			continue
+		}
+		from := st.Status
+		st.Status = next
+		st.Since = evaluatedAt
+		observability.AlertTransitionsTotal.WithLabelValues(r.Name, string(next)).Inc()
+		transitions = append(transitions, Transition{From: from, State: *st})
+	}
+	e.pruneLocked(time.Now())
+	e.updateFiringGaugeLocked()
+	return transitions
+}
+
+// States returns a snapshot of all tracked alert states, firing first, then by severity
+// (critical first), rule name and location.
+func (e *Engine) States() []State {
+	e.mu.Lock()
+	e.pruneLocked(time.Now())
+	e.updateFiringGaugeLocked()
+	out := make([]State, 0, len(e.states))
+	for _, st := range e.states {
+		out = append(out, *st)
+	}
+	e.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Status != b.Status {
+			return a.Status == StatusFiring
+		}
+		if a.Severity != b.Severity {
+			return severityRank(a.Severity) > severityRank(b.Severity)
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Location < b.Location
+	})
+	return out
+}
+
+// pruneLocked drops resolved states older than resolvedRetention and states of any status not
+// evaluated within staleAfter. Must be called with mu held.
+func (e *Engine) pruneLocked(now time.Time) {
+	for key, st := range e.states {
+		resolvedExpired := e.resolvedRetention > 0 && st.Status == StatusResolved && st.Since.Before(now.Add(-e.resolvedRetention))
+		stale := e.staleAfter > 0 && st.LastEvaluated.Before(now.Add(-e.staleAfter))
+		if resolvedExpired || stale {
+			delete(e.states, key)
+		}
+	}
+}
+
+// updateFiringGaugeLocked refreshes alertsFiring per severity. Must be called with mu held.
+func (e *Engine) updateFiringGaugeLocked() {
+	counts := map[Severity]int{SeverityInfo: 0, SeverityWarning: 0, SeverityCritical: 0}
+	for _, st := range e.states {
+		if st.Status == StatusFiring {
+			counts[st.Severity]++
+		}
+	}
+	for sev, n := range counts {
+		observability.AlertsFiring.WithLabelValues(string(sev)).Set(float64(n))
+	}
+}
+
+// severityRank orders severities for sorting (higher is more urgent).
+func severityRank(s Severity) int {
+	switch s {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,141 @@
+package alerts
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// TestNewEngine_DuplicateRuleName verifies that rule names must be unique.
+func TestNewEngine_DuplicateRuleName(t *testing.T) {
+	_, err := NewEngine([]RuleConfig{
+		{Name: "wind", Type: "wind_above", Threshold: 10},
+		{Name: "wind", Type: "wind_above", Threshold: 20},
+	}, 0, nil)
+	if err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Fatalf("NewEngine() error = %v, want duplicate name error", err)
+	}
+}
+
+// TestEngine_Evaluate_FireAndResolve verifies the firing -> resolved -> firing lifecycle and
+// that readings which do not change status produce no transitions.
+func TestEngine_Evaluate_FireAndResolve(t *testing.T) {
+	// Arrange: one critical heat rule
+	engine, err := NewEngine([]RuleConfig{
+		{Name: "heat", Type: "temperature_above", Threshold: 35, Severity: "critical"},
+	}, 0, nil)
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	t0 := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	// Act + Assert: below threshold creates no state
+	if got := engine.Evaluate("Phoenix", models.WeatherData{Temperature: 30, Timestamp: t0}); len(got) != 0 {
+		t.Fatalf("Evaluate(below) transitions = %d, want 0", len(got))
+	}
+	if got := engine.States(); len(got) != 0 {
+		t.Fatalf("States() = %d entries, want 0 before first fire", len(got))
+	}
+
+	// First fire
+	got := engine.Evaluate("Phoenix", models.WeatherData{Temperature: 40, Timestamp: t0.Add(time.Minute)})
+	if len(got) != 1 || got[0].From != "" || got[0].State.Status != StatusFiring {
+		t.Fatalf("Evaluate(above) = %+v, want single first-fire transition", got)
+	}
+	if got[0].State.Location != "phoenix" || got[0].State.Severity != SeverityCritical {
+		t.Errorf("State = %+v, want normalized location and critical severity", got[0].State)
+	}
+
+	// Still firing: no transition, but value refreshes
+	if got := engine.Evaluate("phoenix", models.WeatherData{Temperature: 41, Timestamp: t0.Add(2 * time.Minute)}); len(got) != 0 {
+		t.Fatalf("Evaluate(still above) transitions = %d, want 0", len(got))
+	}
+	states := engine.States()
+	if len(states) != 1 || states[0].Value != "41" || !states[0].Since.Equal(t0.Add(time.Minute)) {
+		t.Fatalf("States() = %+v, want value 41 and since unchanged", states)
+	}
+
+	// Resolve
+	got = engine.Evaluate("phoenix", models.WeatherData{Temperature: 20, Timestamp: t0.Add(3 * time.Minute)})
+	if len(got) != 1 || got[0].From != StatusFiring || got[0].State.Status != StatusResolved {
+		t.Fatalf("Evaluate(clear) = %+v, want firing -> resolved", got)
+	}
+
+	// Refire
+	got = engine.Evaluate("phoenix", models.WeatherData{Temperature: 36, Timestamp: t0.Add(4 * time.Minute)})
+	if len(got) != 1 || got[0].From != StatusResolved || got[0].State.Status != StatusFiring {
+		t.Fatalf("Evaluate(refire) = %+v, want resolved -> firing", got)
+	}
+}
+
+// TestEngine_Evaluate_ScopedRule verifies rules with a locations list ignore other locations.
+func TestEngine_Evaluate_ScopedRule(t *testing.T) {
+	engine, _ := NewEngine([]RuleConfig{
+		{Name: "chicago-wind", Type: "wind_above", Threshold: 5, Locations: []string{"chicago"}},
+	}, 0, nil)
+
+	if got := engine.Evaluate("boston", models.WeatherData{WindSpeed: 20}); len(got) != 0 {
+		t.Errorf("Evaluate(boston) transitions = %d, want 0 for out-of-scope location", len(got))
+	}
+	if got := engine.Evaluate("chicago", models.WeatherData{WindSpeed: 20}); len(got) != 1 {
+		t.Errorf("Evaluate(chicago) transitions = %d, want 1", len(got))
+	}
+}
+
+// TestEngine_States_OrderAndRetention verifies firing states sort before resolved ones,
+// higher severity first, and that resolved states past retention are pruned.
+func TestEngine_States_OrderAndRetention(t *testing.T) {
+	engine, _ := NewEngine([]RuleConfig{
+		{Name: "wind", Type: "wind_above", Threshold: 5, Severity: "info"},
+		{Name: "heat", Type: "temperature_above", Threshold: 30, Severity: "critical"},
+		{Name: "snow", Type: "conditions_match", Pattern: "snow"},
+	}, time.Hour, nil)
+	now := time.Now()
+
+	engine.Evaluate("a", models.WeatherData{WindSpeed: 10, Temperature: 35, Timestamp: now})
+	engine.Evaluate("b", models.WeatherData{Conditions: "snow", Timestamp: now.Add(-3 * time.Hour)})
+	engine.Evaluate("b", models.WeatherData{Conditions: "clear", Timestamp: now.Add(-2 * time.Hour)})
+	engine.Evaluate("c", models.WeatherData{Conditions: "snow", Timestamp: now.Add(-3 * time.Hour)})
+	engine.Evaluate("c", models.WeatherData{Conditions: "clear", Timestamp: now})
+
+	states := engine.States()
+	var got []string
+	for _, st := range states {
+		got = append(got, st.Rule+"/"+st.Location+"/"+string(st.Status))
+	}
+	want := []string{"heat/a/firing", "wind/a/firing", "snow/c/resolved"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("States() = %v, want %v (b resolved 2h ago should be pruned)", got, want)
+	}
+}
+
+// TestEngine_States_StaleFiringExpires verifies a firing state whose location stops receiving
+// readings is dropped after the staleness window, while a recently evaluated one is kept.
+func TestEngine_States_StaleFiringExpires(t *testing.T) {
+	engine, _ := NewEngine([]RuleConfig{{Name: "heat", Type: "temperature_above", Threshold: 30}}, 0, nil)
+	engine.SetStaleAfter(time.Hour)
+	now := time.Now()
+
+	engine.Evaluate("ad-hoc", models.WeatherData{Temperature: 35, Timestamp: now.Add(-2 * time.Hour)})
+	engine.Evaluate("phoenix", models.WeatherData{Temperature: 35, Timestamp: now.Add(-10 * time.Minute)})
+
+	states := engine.States()
+	if len(states) != 1 || states[0].Location != "phoenix" || states[0].Status != StatusFiring {
+		t.Errorf("States() = %+v, want only phoenix firing (ad-hoc not evaluated for 2h)", states)
+	}
+}
+
+// TestEngine_Observe_MatchesFetchHook verifies Observe evaluates data (used as a service fetch hook).
+func TestEngine_Observe_MatchesFetchHook(t *testing.T) {
+	engine, _ := NewEngine([]RuleConfig{{Name: "cold", Type: "temperature_below", Threshold: 0}}, 0, nil)
+
+	engine.Observe(context.Background(), "oslo", models.WeatherData{Temperature: -5})
+
+	states := engine.States()
+	if len(states) != 1 || states[0].Status != StatusFiring {
+		t.Fatalf("States() after Observe = %+v, want one firing state", states)
+	}
+}
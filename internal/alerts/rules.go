@@ -0,0 +1,145 @@
+package alerts
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// RuleType identifies the comparison a Rule performs against weather data.
+type RuleType string
+
+// Supported rule types. Names match the `type` values accepted in config/[env].yaml.
+const (
+	RuleTemperatureAbove RuleType = "temperature_above"
+	RuleTemperatureBelow RuleType = "temperature_below"
+	RuleWindAbove        RuleType = "wind_above"
+	RuleHumidityRange    RuleType = "humidity_range"
+	RuleConditionsMatch  RuleType = "conditions_match"
+)
+
+// Severity is the operator-facing urgency of a firing rule.
+type Severity string
+
+// Supported severities, lowest to highest.
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// RuleConfig is the raw rule definition loaded from config. NewRule validates it.
+type RuleConfig struct {
+	Name      string
+	Type      string
+	Severity  string
+	Threshold float64  // temperature_above, temperature_below, wind_above
+	Min       float64  // humidity_range lower bound (inclusive)
+	Max       float64  // humidity_range upper bound (inclusive)
+	Pattern   string   // conditions_match regular expression
+	Locations []string // empty applies the rule to every location
+}
+
+// Rule is a validated threshold rule. Match reports whether the rule fires for a reading.
+type Rule struct {
+	Name      string
+	Type      RuleType
+	Severity  Severity
+	Threshold float64
+	Min       float64
+	Max       float64
+	pattern   *regexp.Regexp
+	locations map[string]struct{}
+}
+
+// NewRule validates cfg and returns a Rule. Returns an error for a missing name, unknown type
+// or severity, an inverted humidity range, or an invalid conditions pattern.
+// Severity defaults to warning when empty.
+func NewRule(cfg RuleConfig) (Rule, error) {
+	name := strings.TrimSpace(cfg.Name)
+	if name == "" {
+		return Rule{}, fmt.Errorf("alert rule: name is required")
+	}
+	r := Rule{
+		Name:      name,
+		Type:      RuleType(strings.TrimSpace(strings.ToLower(cfg.Type))),
+		Severity:  Severity(strings.TrimSpace(strings.ToLower(cfg.Severity))),
+		Threshold: cfg.Threshold,
+		Min:       cfg.Min,
+		Max:       cfg.Max,
+	}
+	if r.Severity == "" {
+		r.Severity = SeverityWarning
+	}
+	switch r.Severity {
+	case SeverityInfo, SeverityWarning, SeverityCritical:
+	default:
+		return Rule{}, fmt.Errorf("alert rule %q: severity must be info, warning or critical, got %q", name, cfg.Severity)
+	}
+	switch r.Type {
+	case RuleTemperatureAbove, RuleTemperatureBelow, RuleWindAbove:
+	case RuleHumidityRange:
+		if r.Min > r.Max {
+			return Rule{}, fmt.Errorf("alert rule %q: min %v greater than max %v", name, r.Min, r.Max)
+		}
+	case RuleConditionsMatch:
+		if strings.TrimSpace(cfg.Pattern) == "" {
+			return Rule{}, fmt.Errorf("alert rule %q: pattern is required for %s", name, RuleConditionsMatch)
+		}
+		re, err := regexp.Compile(cfg.Pattern)
+		if err != nil {
+			return Rule{}, fmt.Errorf("alert rule %q: invalid pattern: %w", name, err)
+		}
+		r.pattern = re
+	default:
+		return Rule{}, fmt.Errorf("alert rule %q: unknown type %q", name, cfg.Type)
+	}
+	if len(cfg.Locations) > 0 {
+		r.locations = make(map[string]struct{}, len(cfg.Locations))
+		for _, loc := range cfg.Locations {
+			r.locations[validation.NormalizeLocation(loc)] = struct{}{}
+		}
+	}
+	return r, nil
+}
+
+// AppliesTo reports whether the rule is scoped to the location (normalized).
+// Rules without a location list apply everywhere.
+func (r Rule) AppliesTo(location string) bool {
+	if r.locations == nil {
+		return true
+	}
+	_, ok := r.locations[validation.NormalizeLocation(location)]
+	return ok
+}
+
+// Match reports whether the rule fires for data, along with the observed value and a
+// human-readable message describing the comparison.
+func (r Rule) Match(data models.WeatherData) (bool, string, string) {
+	switch r.Type {
+	case RuleTemperatureAbove:
+		v := formatFloat(data.Temperature)
+		return data.Temperature > r.Threshold, v, fmt.Sprintf("temperature %s above %s", v, formatFloat(r.Threshold))
+	case RuleTemperatureBelow:
+		v := formatFloat(data.Temperature)
+		return data.Temperature < r.Threshold, v, fmt.Sprintf("temperature %s below %s", v, formatFloat(r.Threshold))
+	case RuleWindAbove:
+		v := formatFloat(data.WindSpeed)
+		return data.WindSpeed > r.Threshold, v, fmt.Sprintf("wind speed %s above %s", v, formatFloat(r.Threshold))
+	case RuleHumidityRange:
+		h := float64(data.Humidity)
+		v := fmt.Sprintf("%d", data.Humidity)
+		return h >= r.Min && h <= r.Max, v, fmt.Sprintf("humidity %s within %s-%s", v, formatFloat(r.Min), formatFloat(r.Max))
+	case RuleConditionsMatch:
+		return r.pattern.MatchString(data.Conditions), data.Conditions, fmt.Sprintf("conditions %q match %q", data.Conditions, r.pattern.String())
+	}
+	return false, "", ""
+}
+
+// formatFloat renders a reading without trailing zeros (e.g. 35, 12.5).
+func formatFloat(f float64) string {
+	return fmt.Sprintf("%g", f)
+}
@@ -0,0 +1,101 @@
+package alerts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// TestNewRule_Validation verifies that NewRule rejects malformed configs and applies defaults.
+func TestNewRule_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RuleConfig
+		wantErr string
+	}{
+		{name: "missing name", cfg: RuleConfig{Type: "wind_above"}, wantErr: "name is required"},
+		{name: "unknown type", cfg: RuleConfig{Name: "x", Type: "pressure_below"}, wantErr: "unknown type"},
+		{name: "unknown severity", cfg: RuleConfig{Name: "x", Type: "wind_above", Severity: "page"}, wantErr: "severity"},
+		{name: "inverted humidity range", cfg: RuleConfig{Name: "x", Type: "humidity_range", Min: 80, Max: 20}, wantErr: "greater than max"},
+		{name: "missing pattern", cfg: RuleConfig{Name: "x", Type: "conditions_match"}, wantErr: "pattern is required"},
+		{name: "invalid pattern", cfg: RuleConfig{Name: "x", Type: "conditions_match", Pattern: "(snow"}, wantErr: "invalid pattern"},
+		{name: "valid threshold rule", cfg: RuleConfig{Name: "x", Type: "Temperature_Above", Threshold: 30}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewRule(tc.cfg)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("NewRule() error = %v, want containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewRule() error = %v", err)
+			}
+			if r.Severity != SeverityWarning {
+				t.Errorf("Severity = %q, want default %q", r.Severity, SeverityWarning)
+			}
+			if r.Type != RuleTemperatureAbove {
+				t.Errorf("Type = %q, want %q (case-insensitive)", r.Type, RuleTemperatureAbove)
+			}
+		})
+	}
+}
+
+// TestRule_Match verifies each rule type's comparison, including boundary values.
+func TestRule_Match(t *testing.T) {
+	data := models.WeatherData{Temperature: 30, WindSpeed: 12.5, Humidity: 90, Conditions: "light snow"}
+
+	tests := []struct {
+		name string
+		cfg  RuleConfig
+		want bool
+	}{
+		{name: "temperature above fires", cfg: RuleConfig{Type: "temperature_above", Threshold: 29.9}, want: true},
+		{name: "temperature above is strict", cfg: RuleConfig{Type: "temperature_above", Threshold: 30}, want: false},
+		{name: "temperature below fires", cfg: RuleConfig{Type: "temperature_below", Threshold: 31}, want: true},
+		{name: "temperature below clear", cfg: RuleConfig{Type: "temperature_below", Threshold: 0}, want: false},
+		{name: "wind above fires", cfg: RuleConfig{Type: "wind_above", Threshold: 10}, want: true},
+		{name: "wind above clear", cfg: RuleConfig{Type: "wind_above", Threshold: 20}, want: false},
+		{name: "humidity range inclusive", cfg: RuleConfig{Type: "humidity_range", Min: 90, Max: 100}, want: true},
+		{name: "humidity outside range", cfg: RuleConfig{Type: "humidity_range", Min: 0, Max: 50}, want: false},
+		{name: "conditions match", cfg: RuleConfig{Type: "conditions_match", Pattern: "(?i)SNOW"}, want: true},
+		{name: "conditions no match", cfg: RuleConfig{Type: "conditions_match", Pattern: "rain"}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.Name = tc.name
+			r, err := NewRule(tc.cfg)
+			if err != nil {
+				t.Fatalf("NewRule() error = %v", err)
+			}
+			got, _, msg := r.Match(data)
+			if got != tc.want {
+				t.Errorf("Match() = %v, want %v (%s)", got, tc.want, msg)
+			}
+		})
+	}
+}
+
+// TestRule_AppliesTo verifies location scoping is normalized and that unscoped rules apply everywhere.
+func TestRule_AppliesTo(t *testing.T) {
+	scoped, err := NewRule(RuleConfig{Name: "x", Type: "wind_above", Locations: []string{" New York "}})
+	if err != nil {
+		t.Fatalf("NewRule() error = %v", err)
+	}
+	if !scoped.AppliesTo("new york") {
+		t.Error("AppliesTo(new york) = false, want true")
+	}
+	if scoped.AppliesTo("boston") {
+		t.Error("AppliesTo(boston) = true, want false")
+	}
+
+	global, _ := NewRule(RuleConfig{Name: "y", Type: "wind_above"})
+	if !global.AppliesTo("anywhere") {
+		t.Error("unscoped rule AppliesTo() = false, want true")
+	}
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/kjstillabower/weather-alert-service/internal/models"
@@ -10,16 +11,29 @@ import (
 // Cache defines the interface for weather data caching implementations.
 // Get returns cached data if present and not expired, Set stores data with TTL.
 // GetStale returns cached data if present and within maxStaleAge (even if expired).
+// GetBytes and SetBytes store opaque encoded payloads (e.g. official alerts) that are cached
+// alongside weather data; callers namespace their keys (e.g. "alerts:<location>").
 type Cache interface {
 	Get(ctx context.Context, key string) (models.WeatherData, bool, error)
 	GetStale(ctx context.Context, key string, maxStaleAge time.Duration) (models.WeatherData, bool, error)
 	Set(ctx context.Context, key string, value models.WeatherData, ttl time.Duration) error
+	GetBytes(ctx context.Context, key string) ([]byte, bool, error)
+	SetBytes(ctx context.Context, key string, value []byte, ttl time.Duration) error
 }
 
 // InMemoryCache implements Cache using an in-memory map with TTL-based expiration.
-// Expired entries are removed on access. Not thread-safe; use with single goroutine or external synchronization.
+// Expired entries are removed on access. Safe for concurrent use, as requests and batch
+// fetches reach the cache from many goroutines.
 type InMemoryCache struct {
-	data map[string]cacheEntry
+	mu    sync.RWMutex // guards data and bytes
+	data  map[string]cacheEntry
+	bytes map[string]bytesEntry
+}
+
+// bytesEntry stores an opaque cached payload with expiration timestamp.
+type bytesEntry struct {
+	value     []byte
+	expiresAt time.Time
 }
 
 // cacheEntry stores cached weather data with expiration timestamp.
@@ -31,7 +45,8 @@ type cacheEntry struct {
 // NewInMemoryCache creates a new in-memory cache instance.
 func NewInMemoryCache() *InMemoryCache {
 	return &InMemoryCache{
-		data: make(map[string]cacheEntry),
+		data:  make(map[string]cacheEntry),
+		bytes: make(map[string]bytesEntry),
 	}
 }
 
@@ -39,13 +54,20 @@ func NewInMemoryCache() *InMemoryCache {
 // Returns (data, true, nil) on cache hit, (zero, false, nil) on miss or expiration.
 // Expired entries are automatically removed from cache.
 func (c *InMemoryCache) Get(ctx context.Context, key string) (models.WeatherData, bool, error) {
+	c.mu.RLock()
 	entry, ok := c.data[key]
+	c.mu.RUnlock()
 	if !ok {
 		return models.WeatherData{}, false, nil
 	}
 
 	if time.Now().After(entry.expiresAt) {
-		delete(c.data, key)
+		c.mu.Lock()
+		// Another goroutine may have stored a fresh entry since the read.
+		if current, ok := c.data[key]; ok && current.expiresAt.Equal(entry.expiresAt) {
+			delete(c.data, key)
+		}
+		c.mu.Unlock()
 		return models.WeatherData{}, false, nil
 	}
 
@@ -55,7 +77,9 @@ func (c *InMemoryCache) Get(ctx context.Context, key string) (models.WeatherData
 // GetStale retrieves cached weather data if present and within maxStaleAge, even if expired.
 // Returns (data, true, nil) if stale data available, (zero, false, nil) if not found or too stale.
 func (c *InMemoryCache) GetStale(ctx context.Context, key string, maxStaleAge time.Duration) (models.WeatherData, bool, error) {
+	c.mu.RLock()
 	entry, ok := c.data[key]
+	c.mu.RUnlock()
 	if !ok {
 		return models.WeatherData{}, false, nil
 	}
@@ -71,9 +95,44 @@ func (c *InMemoryCache) GetStale(ctx context.Context, key string, maxStaleAge ti
 // Set stores weather data in cache with the specified TTL duration.
 // Entry expires after TTL elapses and will be removed on next Get access.
 func (c *InMemoryCache) Set(ctx context.Context, key string, value models.WeatherData, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.data[key] = cacheEntry{
 		value:     value,
 		expiresAt: time.Now().Add(ttl),
 	}
 	return nil
 }
+
+// GetBytes retrieves a cached payload for the key if present and not expired.
+// Expired entries are removed on access.
+func (c *InMemoryCache) GetBytes(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.RLock()
+	entry, ok := c.bytes[key]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false, nil
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		c.mu.Lock()
+		if current, ok := c.bytes[key]; ok && current.expiresAt.Equal(entry.expiresAt) {
+			delete(c.bytes, key)
+		}
+		c.mu.Unlock()
+		return nil, false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+// SetBytes stores a payload in cache with the specified TTL duration.
+func (c *InMemoryCache) SetBytes(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bytes[key] = bytesEntry{
+		value:     value,
+		expiresAt: time.Now().Add(ttl),
+	}
+	return nil
+}
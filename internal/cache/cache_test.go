@@ -2,6 +2,8 @@ package cache
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -122,3 +124,32 @@ func TestInMemoryCache_GetStale_Miss(t *testing.T) {
 		t.Error("GetStale() ok = true, want false for miss")
 	}
 }
+
+// TestInMemoryCache_Concurrent verifies weather and byte entries can be read and written from
+// many goroutines at once; run with -race to check the locking.
+func TestInMemoryCache_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	c := NewInMemoryCache()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("key-%d", j%10)
+				_ = c.Set(ctx, key, models.WeatherData{Location: key}, time.Minute)
+				_, _, _ = c.Get(ctx, key)
+				_, _, _ = c.GetStale(ctx, key, time.Hour)
+				_ = c.SetBytes(ctx, key, []byte(key), time.Minute)
+				_, _, _ = c.GetBytes(ctx, key)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, ok, err := c.GetBytes(ctx, "key-3")
+	if err != nil || !ok || string(got) != "key-3" {
+		t.Errorf("GetBytes() = %q, %v, %v, want \"key-3\", true, nil", got, ok, err)
+	}
+}
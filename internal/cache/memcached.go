@@ -132,16 +132,50 @@ func (c *MemcachedCache) Set(ctx context.Context, key string, value models.Weath
 	if err != nil {
 		return err
 	}
+	return c.client.Set(&memcache.Item{
+		Key:        c.key(key),
+		Value:      raw,
+		Expiration: expirationSeconds(ttl),
+	})
+}
+
+// GetBytes implements Cache.GetBytes. Returns false, nil on cache miss; false, err on error.
+// Expiry is left to memcached.
+func (c *MemcachedCache) GetBytes(ctx context.Context, key string) ([]byte, bool, error) {
+	if ctx.Err() != nil {
+		return nil, false, ctx.Err()
+	}
+	item, err := c.client.Get(c.key(key))
+	if err != nil {
+		if err == memcache.ErrCacheMiss {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return item.Value, true, nil
+}
+
+// SetBytes implements Cache.SetBytes. TTL is capped and defaulted as in Set.
+func (c *MemcachedCache) SetBytes(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return c.client.Set(&memcache.Item{
+		Key:        c.key(key),
+		Value:      value,
+		Expiration: expirationSeconds(ttl),
+	})
+}
+
+// expirationSeconds converts ttl to a memcached relative expiration. TTL is capped at 30 days
+// (memcached limit) and defaults to 1 hour if invalid.
+func expirationSeconds(ttl time.Duration) int32 {
 	expSec := int32(ttl.Seconds())
 	const maxRelativeExp = 30 * 24 * 60 * 60 // 30 days
 	if expSec <= 0 || expSec > maxRelativeExp {
 		expSec = 3600 // fallback 1h if invalid
 	}
-	return c.client.Set(&memcache.Item{
-		Key:        c.key(key),
-		Value:      raw,
-		Expiration: expSec,
-	})
+	return expSec
 }
 
 // Ping checks if memcached is reachable. Used for health checks.
@@ -70,19 +70,34 @@ func (w *CacheWarmer) Warm(ctx context.Context, locations []string) error {
 
 // WarmPeriodic runs an initial Warm, then refreshes at the given interval until ctx is done.
 func (w *CacheWarmer) WarmPeriodic(ctx context.Context, locations []string, interval time.Duration) error {
-	if err := w.Warm(ctx, locations); err != nil && w.logger != nil {
+	return w.WarmPeriodicFrom(ctx, func() []string { return locations }, interval, nil)
+}
+
+// WarmPeriodicFrom is WarmPeriodic with a location source read on every pass, so runtime
+// changes (e.g. the admin watchlist) apply without a restart. A receive on trigger runs an
+// extra pass immediately; nil disables triggering. interval <= 0 disables the ticker.
+func (w *CacheWarmer) WarmPeriodicFrom(ctx context.Context, locations func() []string, interval time.Duration, trigger <-chan struct{}) error {
+	if err := w.Warm(ctx, locations()); err != nil && w.logger != nil {
 		w.logger.Warn("initial cache warm failed", zap.Error(err))
 	}
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-ticker.C:
-			if err := w.Warm(ctx, locations); err != nil && w.logger != nil {
+		case <-tick:
+			if err := w.Warm(ctx, locations()); err != nil && w.logger != nil {
 				w.logger.Warn("periodic cache warm failed", zap.Error(err))
 			}
+		case <-trigger:
+			if err := w.Warm(ctx, locations()); err != nil && w.logger != nil {
+				w.logger.Warn("triggered cache warm failed", zap.Error(err))
+			}
 		}
 	}
 }
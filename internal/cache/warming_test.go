@@ -3,7 +3,9 @@ package cache
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/kjstillabower/weather-alert-service/internal/models"
 )
@@ -61,3 +63,63 @@ func TestCacheWarmer_Warm_FetcherError(t *testing.T) {
 		t.Errorf("Warm() error = %q, want non-empty message containing failure", msg)
 	}
 }
+
+type recordingFetcher struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recordingFetcher) GetWeather(ctx context.Context, location string) (models.WeatherData, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, location)
+	return models.WeatherData{Location: location}, nil
+}
+
+func (r *recordingFetcher) count(location string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, c := range r.calls {
+		if c == location {
+			n++
+		}
+	}
+	return n
+}
+
+func TestCacheWarmer_WarmPeriodicFrom_TriggerReadsSource(t *testing.T) {
+	fetcher := &recordingFetcher{}
+	warmer := NewCacheWarmer(fetcher, nil)
+	var mu sync.Mutex
+	locations := []string{"seattle"}
+	source := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), locations...)
+	}
+	trigger := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- warmer.WarmPeriodicFrom(ctx, source, 0, trigger) }()
+	deadline := time.Now().Add(time.Second)
+	for fetcher.count("seattle") == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	mu.Lock()
+	locations = append(locations, "boston")
+	mu.Unlock()
+	trigger <- struct{}{}
+	for fetcher.count("boston") == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("WarmPeriodicFrom() error = %v, want context.Canceled", err)
+	}
+	if fetcher.count("seattle") != 2 || fetcher.count("boston") != 1 {
+		t.Errorf("calls = %v, want seattle initially and boston after trigger", fetcher.calls)
+	}
+}
@@ -0,0 +1,165 @@
+package changes
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// Reasons a change event was recorded. One event can carry several.
+const (
+	ReasonConditions  = "conditions"
+	ReasonTemperature = "temperature"
+	ReasonWind        = "wind"
+)
+
+// Event is a material change between two consecutive upstream readings for a location.
+// IDs increase monotonically across all locations for the life of the process.
+type Event struct {
+	ID         uint64             `json:"id"`
+	Location   string             `json:"location"`
+	Reasons    []string           `json:"reasons"`
+	DetectedAt time.Time          `json:"detectedAt"`
+	Before     models.WeatherData `json:"before"`
+	After      models.WeatherData `json:"after"`
+}
+
+// Config configures a Log.
+type Config struct {
+	TemperatureDelta float64 // absolute temperature change that counts as material (must be > 0)
+	WindDelta        float64 // absolute wind speed change that counts as material (must be > 0)
+	MaxEvents        int     // events retained per location; oldest are dropped first
+	MaxLocations     int     // locations tracked at once; least recently fetched are evicted
+}
+
+// Log records change events from fresh upstream fetches. Each location keeps the last reading
+// it saw and a bounded event history; the number of locations is capped so arbitrary request
+// traffic cannot grow memory without bound.
+type Log struct {
+	cfg Config
+	now func() time.Time
+
+	mu        sync.RWMutex
+	locations map[string]*history
+	seq       uint64
+}
+
+type history struct {
+	last     models.WeatherData
+	lastSeen time.Time
+	events   []Event // oldest first, at most cfg.MaxEvents
+}
+
+// NewLog creates a Log, applying defaults for zero config values.
+func NewLog(cfg Config) *Log {
+	if cfg.TemperatureDelta <= 0 {
+		cfg.TemperatureDelta = 3
+	}
+	if cfg.WindDelta <= 0 {
+		cfg.WindDelta = 5
+	}
+	if cfg.MaxEvents <= 0 {
+		cfg.MaxEvents = 100
+	}
+	if cfg.MaxLocations <= 0 {
+		cfg.MaxLocations = 1000
+	}
+	return &Log{cfg: cfg, now: time.Now, locations: make(map[string]*history)}
+}
+
+// Observe compares data with the previous reading for key and records an event when the
+// change is material. It matches service.FetchHook and is registered with
+// WeatherService.AddFetchHook, so cache hits and stale fallbacks never produce events.
+// The first reading for a location only establishes the baseline.
+func (l *Log) Observe(ctx context.Context, key string, data models.WeatherData) {
+	key = validation.NormalizeLocation(key)
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	h, ok := l.locations[key]
+	if !ok {
+		l.evictLocked()
+		l.locations[key] = &history{last: data, lastSeen: now}
+		return
+	}
+	before := h.last
+	h.last = data
+	h.lastSeen = now
+	reasons := l.compare(before, data)
+	if len(reasons) == 0 {
+		return
+	}
+	l.seq++
+	if len(h.events) >= l.cfg.MaxEvents {
+		h.events = h.events[1:]
+	}
+	h.events = append(h.events, Event{
+		ID:         l.seq,
+		Location:   key,
+		Reasons:    reasons,
+		DetectedAt: now,
+		Before:     before,
+		After:      data,
+	})
+	for _, r := range reasons {
+		observability.WeatherChangeEventsTotal.WithLabelValues(r).Inc()
+	}
+}
+
+// Since returns events for location detected after since, oldest first. A zero since returns
+// all retained events. Unknown locations return an empty slice.
+func (l *Log) Since(location string, since time.Time) []Event {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	out := []Event{}
+	h, ok := l.locations[validation.NormalizeLocation(location)]
+	if !ok {
+		return out
+	}
+	for _, ev := range h.events {
+		if ev.DetectedAt.After(since) {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// compare returns the reasons before -> after is a material change, in a fixed order.
+func (l *Log) compare(before, after models.WeatherData) []string {
+	var reasons []string
+	if !strings.EqualFold(strings.TrimSpace(before.Conditions), strings.TrimSpace(after.Conditions)) {
+		reasons = append(reasons, ReasonConditions)
+	}
+	if math.Abs(after.Temperature-before.Temperature) >= l.cfg.TemperatureDelta {
+		reasons = append(reasons, ReasonTemperature)
+	}
+	if math.Abs(after.WindSpeed-before.WindSpeed) >= l.cfg.WindDelta {
+		reasons = append(reasons, ReasonWind)
+	}
+	return reasons
+}
+
+// evictLocked drops the least recently fetched locations until there is room for one more.
+func (l *Log) evictLocked() {
+	excess := len(l.locations) - l.cfg.MaxLocations + 1
+	if excess <= 0 {
+		return
+	}
+	keys := make([]string, 0, len(l.locations))
+	for k := range l.locations {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return l.locations[keys[i]].lastSeen.Before(l.locations[keys[j]].lastSeen)
+	})
+	for _, k := range keys[:excess] {
+		delete(l.locations, k)
+	}
+}
@@ -0,0 +1,127 @@
+package changes
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// fixedClock returns a clock that advances by one minute per call, starting at base.
+func fixedClock(base time.Time) func() time.Time {
+	n := 0
+	return func() time.Time {
+		n++
+		return base.Add(time.Duration(n) * time.Minute)
+	}
+}
+
+// TestLog_Observe_Reasons verifies which differences count as material.
+func TestLog_Observe_Reasons(t *testing.T) {
+	baseline := models.WeatherData{Temperature: 10, Conditions: "Clouds", WindSpeed: 4}
+	tests := []struct {
+		name  string
+		after models.WeatherData
+		want  string
+	}{
+		{name: "no change", after: baseline, want: ""},
+		{name: "conditions case-insensitive", after: models.WeatherData{Temperature: 10, Conditions: "clouds", WindSpeed: 4}, want: ""},
+		{name: "small drift", after: models.WeatherData{Temperature: 12.9, Conditions: "Clouds", WindSpeed: 8.9}, want: ""},
+		{name: "conditions", after: models.WeatherData{Temperature: 10, Conditions: "Snow", WindSpeed: 4}, want: "conditions"},
+		{name: "temperature drop", after: models.WeatherData{Temperature: 7, Conditions: "Clouds", WindSpeed: 4}, want: "temperature"},
+		{name: "wind jump", after: models.WeatherData{Temperature: 10, Conditions: "Clouds", WindSpeed: 9}, want: "wind"},
+		{name: "all", after: models.WeatherData{Temperature: -1, Conditions: "Snow", WindSpeed: 15}, want: "conditions,temperature,wind"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			log := NewLog(Config{TemperatureDelta: 3, WindDelta: 5})
+			log.Observe(context.Background(), "chicago", baseline)
+
+			// Act
+			log.Observe(context.Background(), "chicago", tc.after)
+
+			// Assert
+			events := log.Since("chicago", time.Time{})
+			got := ""
+			if len(events) > 0 {
+				got = strings.Join(events[0].Reasons, ",")
+			}
+			if len(events) > 1 || got != tc.want {
+				t.Errorf("events = %+v, want one event with reasons %q", events, tc.want)
+			}
+		})
+	}
+}
+
+// TestLog_Observe_BeforeAfter verifies events compare against the previous fetch, not the
+// first one, and carry both readings.
+func TestLog_Observe_BeforeAfter(t *testing.T) {
+	log := NewLog(Config{})
+	ctx := context.Background()
+
+	log.Observe(ctx, "Chicago", models.WeatherData{Conditions: "Clouds"})
+	log.Observe(ctx, "chicago", models.WeatherData{Conditions: "Snow"})
+	log.Observe(ctx, "chicago", models.WeatherData{Conditions: "Snow"})
+	log.Observe(ctx, "chicago", models.WeatherData{Conditions: "Rain"})
+
+	events := log.Since(" CHICAGO ", time.Time{})
+	if len(events) != 2 {
+		t.Fatalf("len(events) = %d, want 2", len(events))
+	}
+	if events[0].Before.Conditions != "Clouds" || events[0].After.Conditions != "Snow" {
+		t.Errorf("first event = %s -> %s, want Clouds -> Snow", events[0].Before.Conditions, events[0].After.Conditions)
+	}
+	if events[1].Before.Conditions != "Snow" || events[1].After.Conditions != "Rain" {
+		t.Errorf("second event = %s -> %s, want Snow -> Rain", events[1].Before.Conditions, events[1].After.Conditions)
+	}
+	if events[1].ID <= events[0].ID || events[0].Location != "chicago" {
+		t.Errorf("events = %+v, want increasing IDs for chicago", events)
+	}
+}
+
+// TestLog_Since verifies filtering by detection time.
+func TestLog_Since(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	log := NewLog(Config{})
+	log.now = fixedClock(base)
+	ctx := context.Background()
+	for _, c := range []string{"Clear", "Clouds", "Rain", "Snow"} {
+		log.Observe(ctx, "oslo", models.WeatherData{Conditions: c})
+	}
+
+	events := log.Since("oslo", base.Add(2*time.Minute))
+
+	if len(events) != 2 || events[0].After.Conditions != "Rain" || events[1].After.Conditions != "Snow" {
+		t.Errorf("Since(+2m) = %+v, want Rain and Snow", events)
+	}
+	if got := log.Since("unknown", time.Time{}); got == nil || len(got) != 0 {
+		t.Errorf("Since(unknown) = %#v, want empty non-nil slice", got)
+	}
+}
+
+// TestLog_Bounds verifies per-location event retention and least-recently-fetched eviction.
+func TestLog_Bounds(t *testing.T) {
+	log := NewLog(Config{MaxEvents: 2, MaxLocations: 2})
+	log.now = fixedClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := context.Background()
+	for _, c := range []string{"Clear", "Clouds", "Rain", "Snow"} {
+		log.Observe(ctx, "oslo", models.WeatherData{Conditions: c})
+	}
+	if events := log.Since("oslo", time.Time{}); len(events) != 2 || events[0].After.Conditions != "Rain" {
+		t.Errorf("oslo events = %+v, want last two (Rain, Snow)", events)
+	}
+
+	log.Observe(ctx, "rome", models.WeatherData{Conditions: "Clear"})
+	log.Observe(ctx, "oslo", models.WeatherData{Conditions: "Clear"})
+	log.Observe(ctx, "lima", models.WeatherData{Conditions: "Clear"})
+
+	if _, ok := log.locations["rome"]; ok {
+		t.Error("rome still tracked, want evicted as least recently fetched")
+	}
+	if _, ok := log.locations["oslo"]; !ok {
+		t.Error("oslo evicted, want kept")
+	}
+}
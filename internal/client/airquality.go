@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// AirQualityClient is optionally implemented by a WeatherClient whose provider reports air
+// quality.
+type AirQualityClient interface {
+	GetAirQuality(ctx context.Context, location string) (models.AirQuality, error)
+}
+
+var (
+	// ErrAirQualityUnsupported indicates the weather client's provider has no air quality data.
+	ErrAirQualityUnsupported = errors.New("air quality not supported by provider")
+	// ErrAirQualityUnavailable indicates the provider returned no air quality reading for the
+	// location.
+	ErrAirQualityUnavailable = errors.New("air quality unavailable")
+)
+
+// aqiCategories names the OpenWeatherMap AQI levels 1 to 5.
+var aqiCategories = []string{"good", "fair", "moderate", "poor", "very poor"}
+
+// airPollutionResponse is the JSON shape returned by the OpenWeatherMap air pollution API. dt
+// is Unix seconds; components are μg/m³.
+type airPollutionResponse struct {
+	Coord struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"coord"`
+	List []struct {
+		Dt   int64 `json:"dt"`
+		Main struct {
+			AQI int `json:"aqi"`
+		} `json:"main"`
+		Components struct {
+			PM25 float64 `json:"pm2_5"`
+			PM10 float64 `json:"pm10"`
+			O3   float64 `json:"o3"`
+			NO2  float64 `json:"no2"`
+		} `json:"components"`
+	} `json:"list"`
+}
+
+// GetAirQuality retrieves current air quality for the location. The location is resolved to
+// coordinates with the geocoding API, then read from the air pollution API. Returns
+// ErrAirQualityUnavailable when the response has no reading. Uses the same retry, circuit
+// breaker and timeout propagation as GetCurrentWeather.
+func (c *OpenWeatherClient) GetAirQuality(ctx context.Context, location string) (models.AirQuality, error) {
+	upstreamTimeout := c.upstreamTimeoutFromContext(ctx)
+	var aq models.AirQuality
+	fetch := func() error {
+		return c.withRetry(ctx, func() error {
+			var err error
+			aq, err = c.fetchAirQuality(ctx, location, upstreamTimeout)
+			return err
+		})
+	}
+	if c.circuitBreaker != nil {
+		if cbErr := c.circuitBreaker.Call(ctx, fetch); cbErr != nil {
+			return models.AirQuality{}, fmt.Errorf("circuit breaker: %w", cbErr)
+		}
+		return aq, nil
+	}
+	if err := fetch(); err != nil {
+		return models.AirQuality{}, err
+	}
+	return aq, nil
+}
+
+// fetchAirQuality performs one geocode and air pollution round trip and maps the response.
+func (c *OpenWeatherClient) fetchAirQuality(ctx context.Context, location string, timeout time.Duration) (models.AirQuality, error) {
+	point, err := c.geocode(ctx, location, timeout)
+	if err != nil {
+		return models.AirQuality{}, err
+	}
+	params := url.Values{}
+	params.Set("lat", strconv.FormatFloat(point.Lat, 'f', -1, 64))
+	params.Set("lon", strconv.FormatFloat(point.Lon, 'f', -1, 64))
+	var apiResp airPollutionResponse
+	if err := c.getJSON(ctx, "/data/2.5/air_pollution", params, timeout, &apiResp); err != nil {
+		return models.AirQuality{}, err
+	}
+	if len(apiResp.List) == 0 || apiResp.List[0].Main.AQI < 1 || apiResp.List[0].Main.AQI > len(aqiCategories) {
+		return models.AirQuality{}, fmt.Errorf("%w for %s", ErrAirQualityUnavailable, location)
+	}
+
+	reading := apiResp.List[0]
+	displayName := point.Name
+	if displayName == "" {
+		displayName = location
+	}
+	return models.AirQuality{
+		Location:    strings.ToLower(displayName),
+		Coordinates: models.Coordinates{Lat: point.Lat, Lon: point.Lon},
+		AQI:         reading.Main.AQI,
+		Category:    aqiCategories[reading.Main.AQI-1],
+		Pollutants: models.Pollutants{
+			PM25: reading.Components.PM25,
+			PM10: reading.Components.PM10,
+			O3:   reading.Components.O3,
+			NO2:  reading.Components.NO2,
+		},
+		Timestamp: time.Unix(reading.Dt, 0).UTC(),
+	}, nil
+}
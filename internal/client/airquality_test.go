@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// TestOpenWeatherClient_GetAirQuality verifies the geocode and air pollution requests and the
+// mapping of AQI and pollutants.
+func TestOpenWeatherClient_GetAirQuality(t *testing.T) {
+	// Arrange: API URL has a path; the air pollution endpoint must use only its host
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("appid") == "" {
+			t.Errorf("%s: expected API key in query", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/geo/1.0/direct":
+			fmt.Fprint(w, `[{"name":"Beijing","lat":39.9042,"lon":116.4074}]`)
+		case "/data/2.5/air_pollution":
+			if r.URL.Query().Get("lat") != "39.9042" || r.URL.Query().Get("lon") != "116.4074" {
+				t.Errorf("air pollution query = %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"coord":{"lat":39.9042,"lon":116.4074},"list":[{"dt":1704110400,"main":{"aqi":4},
+				"components":{"co":700.9,"no":0.5,"no2":35.6,"o3":40.1,"so2":8.2,"pm2_5":61.2,"pm10":88,"nh3":4.1}}]}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := NewOpenWeatherClient("test-api-key-12345", server.URL+"/data/2.5/weather", 2*time.Second)
+	if err != nil {
+		t.Fatalf("NewOpenWeatherClient() error = %v", err)
+	}
+
+	// Act
+	got, err := client.GetAirQuality(context.Background(), "beijing")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetAirQuality() error = %v", err)
+	}
+	want := models.Pollutants{PM25: 61.2, PM10: 88, O3: 40.1, NO2: 35.6}
+	if got.Location != "beijing" || got.AQI != 4 || got.Category != "poor" || got.Pollutants != want || !got.Timestamp.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("GetAirQuality() = %+v, want beijing AQI 4 (poor) with %+v", got, want)
+	}
+}
+
+// TestOpenWeatherClient_GetAirQuality_Errors verifies an unknown location, a missing or invalid
+// reading, and an upstream error.
+func TestOpenWeatherClient_GetAirQuality_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		geocode   string
+		status    int
+		pollution string
+		wantErr   error
+	}{
+		{name: "location not geocoded", geocode: `[]`, status: http.StatusOK, pollution: `{}`, wantErr: ErrLocationNotFound},
+		{name: "no reading", geocode: `[{"lat":1,"lon":2}]`, status: http.StatusOK, pollution: `{"list":[]}`, wantErr: ErrAirQualityUnavailable},
+		{name: "aqi out of range", geocode: `[{"lat":1,"lon":2}]`, status: http.StatusOK, pollution: `{"list":[{"main":{"aqi":0}}]}`, wantErr: ErrAirQualityUnavailable},
+		{name: "unauthorized", geocode: `[{"lat":1,"lon":2}]`, status: http.StatusUnauthorized, pollution: `{}`, wantErr: ErrInvalidAPIKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/geo/1.0/direct" {
+					fmt.Fprint(w, tt.geocode)
+					return
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.pollution)
+			}))
+			defer server.Close()
+			client, _ := NewOpenWeatherClient("test-api-key-12345", server.URL, 2*time.Second)
+
+			got, err := client.GetAirQuality(context.Background(), "nowhere")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetAirQuality() = %+v, %v; want error %v", got, err, tt.wantErr)
+			}
+		})
+	}
+}
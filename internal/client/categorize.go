@@ -21,6 +21,10 @@ const (
 	ErrorCategoryValidation       ErrorCategory = "validation"
 	ErrorCategoryCache            ErrorCategory = "cache"
 	ErrorCategoryUnknown          ErrorCategory = "unknown"
+
+	ErrorCategoryAirQualityUnsupported ErrorCategory = "air_quality_unsupported"
+	ErrorCategoryAirQualityUnavailable ErrorCategory = "air_quality_unavailable"
+	ErrorCategoryAlertsUnsupported     ErrorCategory = "alerts_unsupported"
 )
 
 // CategorizeError maps an error to a stable ErrorCategory for metrics.
@@ -55,6 +59,18 @@ func CategorizeError(err error) ErrorCategory {
 		return ErrorCategoryUpstream5xx
 	}
 
+	if errors.Is(err, ErrAirQualityUnsupported) {
+		return ErrorCategoryAirQualityUnsupported
+	}
+
+	if errors.Is(err, ErrAirQualityUnavailable) {
+		return ErrorCategoryAirQualityUnavailable
+	}
+
+	if errors.Is(err, ErrAlertsUnsupported) {
+		return ErrorCategoryAlertsUnsupported
+	}
+
 	if strings.Contains(errStr, "timeout") || strings.Contains(errStr, "context deadline exceeded") {
 		return ErrorCategoryTimeout
 	}
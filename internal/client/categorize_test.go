@@ -24,6 +24,9 @@ func TestCategorizeError(t *testing.T) {
 		{"location not found", ErrLocationNotFound, ErrorCategoryLocationNotFound},
 		{"rate limited", ErrRateLimited, ErrorCategoryRateLimited},
 		{"upstream failure", ErrUpstreamFailure, ErrorCategoryUpstream5xx},
+		{"air quality unsupported", fmt.Errorf("fetch air quality for paris: %w", ErrAirQualityUnsupported), ErrorCategoryAirQualityUnsupported},
+		{"air quality unavailable", fmt.Errorf("%w for paris", ErrAirQualityUnavailable), ErrorCategoryAirQualityUnavailable},
+		{"alerts unsupported", fmt.Errorf("fetch official alerts for paris: %w", ErrAlertsUnsupported), ErrorCategoryAlertsUnsupported},
 		{"timeout in message", fmt.Errorf("request timeout: %w", context.DeadlineExceeded), ErrorCategoryTimeout},
 		{"network in message", errors.New("connection refused"), ErrorCategoryNetwork},
 		{"parse in message", errors.New("parse response: invalid json"), ErrorCategoryParsing},
@@ -17,15 +17,27 @@ import (
 	"github.com/kjstillabower/weather-alert-service/internal/circuitbreaker"
 	"github.com/kjstillabower/weather-alert-service/internal/models"
 	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
 )
 
 // WeatherClient defines the interface for weather data providers.
-// Implementations must provide weather data retrieval and API key validation.
+// Implementations must provide weather data retrieval, forecast retrieval, official alert retrieval,
+// location search, and API key validation. GetOfficialAlerts returns an empty slice when no alerts
+// are in effect for the location; SearchLocations returns an empty slice when nothing matches.
 type WeatherClient interface {
 	GetCurrentWeather(ctx context.Context, location string) (models.WeatherData, error)
+	GetForecast(ctx context.Context, location string) (models.Forecast, error)
+	GetOfficialAlerts(ctx context.Context, location string) ([]models.OfficialAlert, error)
+	SearchLocations(ctx context.Context, query string) ([]models.Place, error)
 	ValidateAPIKey(ctx context.Context) error
 }
 
+// ProviderNamer is optionally implemented by a WeatherClient to name its upstream provider,
+// which is reported in /v2 response metadata.
+type ProviderNamer interface {
+	ProviderName() string
+}
+
 var (
 	// ErrInvalidAPIKey indicates the API key is invalid, missing, or not activated.
 	ErrInvalidAPIKey = errors.New("invalid API key")
@@ -35,6 +47,8 @@ var (
 	ErrUpstreamFailure = errors.New("upstream failure")
 	// ErrRateLimited indicates the upstream API rate limit was exceeded (429).
 	ErrRateLimited = errors.New("rate limited")
+	// ErrAlertsUnsupported indicates the weather client's provider publishes no official alerts.
+	ErrAlertsUnsupported = errors.New("official alerts not supported by provider")
 )
 
 // rateLimitedError wraps ErrRateLimited with retry timing information from headers.
@@ -103,19 +117,50 @@ func NewOpenWeatherClientWithRetry(apiKey, apiURL string, timeout time.Duration,
 	}, nil
 }
 
+// ProviderName returns "openweathermap".
+func (c *OpenWeatherClient) ProviderName() string {
+	return "openweathermap"
+}
+
 // openWeatherResponse is the JSON shape returned by the OpenWeatherMap API for current weather.
 type openWeatherResponse struct {
+	Coord *struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"coord"`
 	Main struct {
-		Temp     float64 `json:"temp"`
-		Humidity int     `json:"humidity"`
+		Temp      float64 `json:"temp"`
+		FeelsLike float64 `json:"feels_like"`
+		TempMin   float64 `json:"temp_min"`
+		TempMax   float64 `json:"temp_max"`
+		Pressure  int     `json:"pressure"`
+		Humidity  int     `json:"humidity"`
 	} `json:"main"`
 	Weather []struct {
 		Main        string `json:"main"`
 		Description string `json:"description"`
 	} `json:"weather"`
-	Wind struct {
+	Visibility int `json:"visibility"`
+	Wind       struct {
 		Speed float64 `json:"speed"`
+		Deg   int     `json:"deg"`
+		Gust  float64 `json:"gust"`
 	} `json:"wind"`
+	Clouds struct {
+		All int `json:"all"`
+	} `json:"clouds"`
+	Rain struct {
+		OneHour float64 `json:"1h"`
+	} `json:"rain"`
+	Snow struct {
+		OneHour float64 `json:"1h"`
+	} `json:"snow"`
+	Dt  int64 `json:"dt"` // observation time, Unix seconds
+	Sys struct {
+		Country string `json:"country"`
+		Sunrise int64  `json:"sunrise"`
+		Sunset  int64  `json:"sunset"`
+	} `json:"sys"`
 	Name string `json:"name"`
 }
 
@@ -143,10 +188,29 @@ func (c *OpenWeatherClient) GetCurrentWeather(ctx context.Context, location stri
 }
 
 // getCurrentWeatherWithRetry runs the retry loop for fetching weather. Used by GetCurrentWeather with or without circuit breaker.
-// Respects Retry-After header from rate limit responses; falls back to exponential backoff otherwise.
 func (c *OpenWeatherClient) getCurrentWeatherWithRetry(ctx context.Context, location string, upstreamTimeout time.Duration) (models.WeatherData, error) {
+	var result models.WeatherData
+	err := c.withRetry(ctx, func() error {
+		var err error
+		result, err = c.callAPI(ctx, location, upstreamTimeout)
+		return err
+	})
+	if err != nil {
+		return models.WeatherData{}, err
+	}
+	return result, nil
+}
+
+// withRetry runs call until it succeeds, returns a non-retryable error, or attempts run out.
+// Respects Retry-After header from rate limit responses; falls back to exponential backoff otherwise.
+func (c *OpenWeatherClient) withRetry(ctx context.Context, call func() error) error {
+	return retry(ctx, c.retryAttempts, c.calculateBackoff, call)
+}
+
+// retry implements withRetry for every provider; backoff returns the delay before an attempt.
+func retry(ctx context.Context, attempts int, backoff func(attempt int) time.Duration, call func() error) error {
 	var lastErr error
-	for attempt := 0; attempt < c.retryAttempts; attempt++ {
+	for attempt := 0; attempt < attempts; attempt++ {
 		if attempt > 0 {
 			observability.WeatherAPIRetriesTotal.Inc()
 
@@ -155,27 +219,288 @@ func (c *OpenWeatherClient) getCurrentWeatherWithRetry(ctx context.Context, loca
 			if rle, ok := lastErr.(*rateLimitedError); ok && rle.retryAfter > 0 {
 				delay = rle.retryAfter
 			} else {
-				delay = c.calculateBackoff(attempt)
+				delay = backoff(attempt)
 			}
 
 			select {
 			case <-ctx.Done():
-				return models.WeatherData{}, ctx.Err()
+				return ctx.Err()
 			case <-time.After(delay):
 			}
 		}
 
-		result, err := c.callAPI(ctx, location, upstreamTimeout)
+		err := call()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 
 		lastErr = err
-		if !c.isRetryable(err) {
-			return models.WeatherData{}, err
+		if !isRetryableError(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("exhausted retries: %w", lastErr)
+}
+
+// forecastResponse is the JSON shape returned by the OpenWeatherMap 5-day/3-hour forecast API.
+// dt is Unix seconds; pop is the probability of precipitation (0 to 1).
+type forecastResponse struct {
+	List []struct {
+		Dt   int64 `json:"dt"`
+		Main struct {
+			Temp     float64 `json:"temp"`
+			Humidity int     `json:"humidity"`
+		} `json:"main"`
+		Weather []struct {
+			Main        string `json:"main"`
+			Description string `json:"description"`
+		} `json:"weather"`
+		Wind struct {
+			Speed float64 `json:"speed"`
+		} `json:"wind"`
+		Pop float64 `json:"pop"`
+	} `json:"list"`
+	City struct {
+		Name string `json:"name"`
+	} `json:"city"`
+}
+
+// GetForecast retrieves the 5-day/3-hour forecast for the location. Uses the same retry,
+// circuit breaker and timeout propagation as GetCurrentWeather.
+func (c *OpenWeatherClient) GetForecast(ctx context.Context, location string) (models.Forecast, error) {
+	upstreamTimeout := c.upstreamTimeoutFromContext(ctx)
+	var forecast models.Forecast
+	fetch := func() error {
+		return c.withRetry(ctx, func() error {
+			var err error
+			forecast, err = c.fetchForecast(ctx, location, upstreamTimeout)
+			return err
+		})
+	}
+	if c.circuitBreaker != nil {
+		if cbErr := c.circuitBreaker.Call(ctx, fetch); cbErr != nil {
+			return models.Forecast{}, fmt.Errorf("circuit breaker: %w", cbErr)
+		}
+		return forecast, nil
+	}
+	if err := fetch(); err != nil {
+		return models.Forecast{}, err
+	}
+	return forecast, nil
+}
+
+// fetchForecast performs one forecast round trip and maps the response. Conditions and
+// location naming follow mapResponse.
+func (c *OpenWeatherClient) fetchForecast(ctx context.Context, location string, timeout time.Duration) (models.Forecast, error) {
+	params := url.Values{}
+	setLocationParams(params, location)
+	params.Set("units", "metric")
+	var apiResp forecastResponse
+	if err := c.getJSON(ctx, "/data/2.5/forecast", params, timeout, &apiResp); err != nil {
+		return models.Forecast{}, err
+	}
+
+	displayName := apiResp.City.Name
+	if displayName == "" {
+		displayName = location
+	}
+	periods := make([]models.ForecastPeriod, 0, len(apiResp.List))
+	for _, item := range apiResp.List {
+		conditions := ""
+		if len(item.Weather) > 0 {
+			conditions = item.Weather[0].Main
+			if item.Weather[0].Description != "" {
+				conditions = item.Weather[0].Description
+			}
+		}
+		periods = append(periods, models.ForecastPeriod{
+			Time:                     time.Unix(item.Dt, 0).UTC(),
+			Temperature:              item.Main.Temp,
+			Conditions:               conditions,
+			Humidity:                 item.Main.Humidity,
+			WindSpeed:                item.Wind.Speed,
+			PrecipitationProbability: item.Pop,
+		})
+	}
+	return models.Forecast{
+		Location:  strings.ToLower(displayName),
+		Periods:   periods,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// GetOfficialAlerts retrieves government-issued alerts for the location. The location is
+// resolved to coordinates with the geocoding API, then alerts are read from One Call 3.0
+// (which needs a One Call subscription on the API key). Uses the same retry, circuit breaker
+// and timeout propagation as GetCurrentWeather. Returns ErrAlertsUnsupported when the key has
+// no One Call subscription; that answer does not count as a circuit breaker failure, so alert
+// requests on such keys cannot open the breaker for current weather.
+func (c *OpenWeatherClient) GetOfficialAlerts(ctx context.Context, location string) ([]models.OfficialAlert, error) {
+	upstreamTimeout := c.upstreamTimeoutFromContext(ctx)
+	var alerts []models.OfficialAlert
+	var unsupported error
+	fetch := func() error {
+		err := c.withRetry(ctx, func() error {
+			var err error
+			alerts, err = c.fetchOfficialAlerts(ctx, location, upstreamTimeout)
+			return err
+		})
+		if errors.Is(err, ErrAlertsUnsupported) {
+			unsupported = err
+			return nil
+		}
+		return err
+	}
+	if c.circuitBreaker != nil {
+		if cbErr := c.circuitBreaker.Call(ctx, fetch); cbErr != nil {
+			return nil, fmt.Errorf("circuit breaker: %w", cbErr)
+		}
+	} else if err := fetch(); err != nil {
+		return nil, err
+	}
+	if unsupported != nil {
+		return nil, unsupported
+	}
+	return alerts, nil
+}
+
+// searchLimit is the number of candidates requested from the geocoding API for SearchLocations
+// (the API maximum).
+const searchLimit = 5
+
+// geocodeResponse is the JSON shape of one match from the OpenWeatherMap direct geocoding API.
+type geocodeResponse struct {
+	Name    string  `json:"name"`
+	State   string  `json:"state"`
+	Country string  `json:"country"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+}
+
+// SearchLocations returns up to five places matching query from the direct geocoding API, each
+// with an ID (its coordinate key) that GetCurrentWeather and the other lookups accept in place of
+// a name. Places that share an ID are returned once. Uses the same retry, circuit breaker and
+// timeout propagation as GetCurrentWeather.
+func (c *OpenWeatherClient) SearchLocations(ctx context.Context, query string) ([]models.Place, error) {
+	upstreamTimeout := c.upstreamTimeoutFromContext(ctx)
+	var places []models.Place
+	fetch := func() error {
+		return c.withRetry(ctx, func() error {
+			matches, err := c.directGeocode(ctx, query, searchLimit, upstreamTimeout)
+			if err != nil {
+				return err
+			}
+			places = mapPlaces(matches)
+			return nil
+		})
+	}
+	if c.circuitBreaker != nil {
+		if cbErr := c.circuitBreaker.Call(ctx, fetch); cbErr != nil {
+			return nil, fmt.Errorf("circuit breaker: %w", cbErr)
 		}
+		return places, nil
+	}
+	if err := fetch(); err != nil {
+		return nil, err
 	}
-	return models.WeatherData{}, fmt.Errorf("exhausted retries: %w", lastErr)
+	return places, nil
+}
+
+// mapPlaces converts geocoding matches to places, dropping matches whose ID repeats an earlier
+// one (the API can return the same city under several local names).
+func mapPlaces(matches []geocodeResponse) []models.Place {
+	places := make([]models.Place, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		id := validation.CoordinateKey(m.Lat, m.Lon)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		places = append(places, models.Place{
+			ID:          id,
+			Name:        m.Name,
+			State:       m.State,
+			Country:     m.Country,
+			Coordinates: models.Coordinates{Lat: m.Lat, Lon: m.Lon},
+		})
+	}
+	return places
+}
+
+// directGeocode performs one direct geocoding round trip for up to limit matches.
+func (c *OpenWeatherClient) directGeocode(ctx context.Context, query string, limit int, timeout time.Duration) ([]geocodeResponse, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("limit", strconv.Itoa(limit))
+	var matches []geocodeResponse
+	if err := c.getJSON(ctx, "/geo/1.0/direct", params, timeout, &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// oneCallAlertsResponse is the alerts portion of the One Call 3.0 response. start and end are
+// Unix seconds.
+type oneCallAlertsResponse struct {
+	Alerts []struct {
+		SenderName  string   `json:"sender_name"`
+		Event       string   `json:"event"`
+		Start       int64    `json:"start"`
+		End         int64    `json:"end"`
+		Description string   `json:"description"`
+		Tags        []string `json:"tags"`
+	} `json:"alerts"`
+}
+
+// geocode resolves a location name to coordinates. Returns ErrLocationNotFound when the
+// geocoding API has no match. Coordinate keys are returned as-is without an API call.
+func (c *OpenWeatherClient) geocode(ctx context.Context, location string, timeout time.Duration) (geocodeResponse, error) {
+	if lat, lon, ok := validation.ParseCoordinateKey(location); ok {
+		return geocodeResponse{Name: location, Lat: lat, Lon: lon}, nil
+	}
+	matches, err := c.directGeocode(ctx, location, 1, timeout)
+	if err != nil {
+		return geocodeResponse{}, err
+	}
+	if len(matches) == 0 {
+		return geocodeResponse{}, fmt.Errorf("%w", ErrLocationNotFound)
+	}
+	return matches[0], nil
+}
+
+// fetchOfficialAlerts performs one geocode and One Call round trip. Returns an empty slice when
+// the response has no alerts.
+func (c *OpenWeatherClient) fetchOfficialAlerts(ctx context.Context, location string, timeout time.Duration) ([]models.OfficialAlert, error) {
+	point, err := c.geocode(ctx, location, timeout)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("lat", strconv.FormatFloat(point.Lat, 'f', -1, 64))
+	params.Set("lon", strconv.FormatFloat(point.Lon, 'f', -1, 64))
+	params.Set("exclude", "current,minutely,hourly,daily")
+	var apiResp oneCallAlertsResponse
+	if err := c.getJSON(ctx, "/data/3.0/onecall", params, timeout, &apiResp); err != nil {
+		// The key already passed geocoding, so a rejection here means no One Call subscription.
+		if errors.Is(err, ErrInvalidAPIKey) {
+			return nil, fmt.Errorf("%w: One Call 3.0 subscription required", ErrAlertsUnsupported)
+		}
+		return nil, err
+	}
+
+	alerts := make([]models.OfficialAlert, 0, len(apiResp.Alerts))
+	for _, a := range apiResp.Alerts {
+		alerts = append(alerts, models.OfficialAlert{
+			Sender:      a.SenderName,
+			Event:       a.Event,
+			Start:       time.Unix(a.Start, 0).UTC(),
+			End:         time.Unix(a.End, 0).UTC(),
+			Description: a.Description,
+			Tags:        a.Tags,
+		})
+	}
+	return alerts, nil
 }
 
 // SetCircuitBreaker attaches an optional circuit breaker to the client.
@@ -184,17 +509,38 @@ func (c *OpenWeatherClient) SetCircuitBreaker(cb *circuitbreaker.CircuitBreaker)
 	c.circuitBreaker = cb
 }
 
+// languageKey is the context key for the upstream response language.
+type languageKey struct{}
+
+// WithLanguage returns a context that asks GetCurrentWeather for condition descriptions in lang
+// (an OpenWeatherMap language code such as "fr" or "zh_cn"). Callers that cache the result must
+// key it by language.
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// languageFromContext returns the language set with WithLanguage, or "" for the upstream default.
+func languageFromContext(ctx context.Context) string {
+	lang, _ := ctx.Value(languageKey{}).(string)
+	return lang
+}
+
 // upstreamTimeoutFromContext returns the timeout to use for upstream API calls.
 // If ctx has a deadline, uses 90% of remaining time, capped at c.timeout and min 100ms.
 func (c *OpenWeatherClient) upstreamTimeoutFromContext(ctx context.Context) time.Duration {
+	return upstreamTimeout(ctx, c.timeout)
+}
+
+// upstreamTimeout implements upstreamTimeoutFromContext for every provider.
+func upstreamTimeout(ctx context.Context, timeout time.Duration) time.Duration {
 	deadline, hasDeadline := ctx.Deadline()
 	if !hasDeadline {
-		return c.timeout
+		return timeout
 	}
 	remaining := time.Until(deadline)
 	upstreamTimeout := time.Duration(float64(remaining) * 0.9)
-	if upstreamTimeout > c.timeout {
-		upstreamTimeout = c.timeout
+	if upstreamTimeout > timeout {
+		upstreamTimeout = timeout
 	}
 	if upstreamTimeout < 100*time.Millisecond {
 		upstreamTimeout = 100 * time.Millisecond
@@ -213,8 +559,6 @@ func propagatedLabel(propagated bool) string {
 // Propagates correlation ID from context, records metrics, and handles HTTP errors.
 // timeout is the maximum duration for this single request (may be derived from request context).
 func (c *OpenWeatherClient) callAPI(ctx context.Context, location string, timeout time.Duration) (models.WeatherData, error) {
-	start := time.Now()
-
 	reqCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -225,6 +569,33 @@ func (c *OpenWeatherClient) callAPI(ctx context.Context, location string, timeou
 		return models.WeatherData{}, fmt.Errorf("build request: %w", err)
 	}
 
+	var apiResp openWeatherResponse
+	if err := c.do(ctx, req, &apiResp); err != nil {
+		return models.WeatherData{}, err
+	}
+	return c.mapResponse(apiResp, location), nil
+}
+
+// getJSON executes a single GET against path on the API host with params and the API key,
+// decoding the JSON response into out. timeout bounds this single request.
+func (c *OpenWeatherClient) getJSON(ctx context.Context, path string, params url.Values, timeout time.Duration, out interface{}) error {
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := c.newRequest(reqCtx, path, params)
+	if err != nil {
+		observability.WeatherAPICallsTotal.WithLabelValues("error").Inc()
+		observability.WeatherAPIErrorsTotal.WithLabelValues(string(CategorizeError(err))).Inc()
+		return fmt.Errorf("build request: %w", err)
+	}
+	return c.do(ctx, req, out)
+}
+
+// do sends req, records upstream metrics, maps HTTP errors to domain errors, and decodes the
+// JSON body into out. Propagates correlation ID from ctx.
+func (c *OpenWeatherClient) do(ctx context.Context, req *http.Request, out interface{}) error {
+	start := time.Now()
+
 	corrID := extractCorrelationID(ctx)
 	if corrID != "" {
 		req.Header.Set("X-Correlation-ID", corrID)
@@ -237,9 +608,9 @@ func (c *OpenWeatherClient) callAPI(ctx context.Context, location string, timeou
 		observability.WeatherAPIDuration.WithLabelValues("error").Observe(duration)
 		observability.WeatherAPIErrorsTotal.WithLabelValues(string(CategorizeError(err))).Inc()
 		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
-			return models.WeatherData{}, fmt.Errorf("request timeout: %w", err)
+			return fmt.Errorf("request timeout: %w", err)
 		}
-		return models.WeatherData{}, fmt.Errorf("http request failed: %w", err)
+		return fmt.Errorf("http request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -250,30 +621,33 @@ func (c *OpenWeatherClient) callAPI(ctx context.Context, location string, timeou
 
 	if err := c.handleErrorResponse(resp); err != nil {
 		observability.WeatherAPIErrorsTotal.WithLabelValues(string(CategorizeError(err))).Inc()
-		return models.WeatherData{}, err
+		return err
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		observability.WeatherAPICallsTotal.WithLabelValues("error").Inc()
 		observability.WeatherAPIErrorsTotal.WithLabelValues(string(CategorizeError(err))).Inc()
-		return models.WeatherData{}, fmt.Errorf("read response body: %w", err)
+		return fmt.Errorf("read response body: %w", err)
 	}
 
-	var apiResp openWeatherResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
+	if err := json.Unmarshal(body, out); err != nil {
 		observability.WeatherAPICallsTotal.WithLabelValues("error").Inc()
 		observability.WeatherAPIErrorsTotal.WithLabelValues(string(CategorizeError(err))).Inc()
-		return models.WeatherData{}, fmt.Errorf("parse response: %w", err)
+		return fmt.Errorf("parse response: %w", err)
 	}
-
-	return c.mapResponse(apiResp, location), nil
+	return nil
 }
 
 // isRetryable determines if an error should trigger a retry attempt.
 // Returns true for transient failures: rate limits (429), upstream failures (5xx),
 // timeouts, and context cancellations. Returns false for client errors (4xx except 429).
 func (c *OpenWeatherClient) isRetryable(err error) bool {
+	return isRetryableError(err)
+}
+
+// isRetryableError implements isRetryable for every provider.
+func isRetryableError(err error) bool {
 	if err == nil {
 		return false
 	}
@@ -297,9 +671,14 @@ func (c *OpenWeatherClient) isRetryable(err error) bool {
 // Delay doubles with each attempt (exponential), capped at retryMaxDelay, with 10% random jitter
 // to prevent thundering herd problems.
 func (c *OpenWeatherClient) calculateBackoff(attempt int) time.Duration {
-	delay := float64(c.retryBaseDelay) * math.Pow(2, float64(attempt-1))
-	if delay > float64(c.retryMaxDelay) {
-		delay = float64(c.retryMaxDelay)
+	return backoffDelay(attempt, c.retryBaseDelay, c.retryMaxDelay)
+}
+
+// backoffDelay implements calculateBackoff for every provider.
+func backoffDelay(attempt int, baseDelay, maxDelay time.Duration) time.Duration {
+	delay := float64(baseDelay) * math.Pow(2, float64(attempt-1))
+	if delay > float64(maxDelay) {
+		delay = float64(maxDelay)
 	}
 
 	jitter := delay * 0.1 * rand.Float64()
@@ -307,7 +686,9 @@ func (c *OpenWeatherClient) calculateBackoff(attempt int) time.Duration {
 }
 
 // buildRequest constructs an HTTP GET request to the OpenWeatherMap API with location,
-// API key, and units=metric query parameters. Sets Accept header for JSON response.
+// API key, and units=metric query parameters. Coordinate keys are sent as lat/lon, anything
+// else as q, and a language set with WithLanguage is sent as lang. Sets Accept header for
+// JSON response.
 func (c *OpenWeatherClient) buildRequest(ctx context.Context, location string) (*http.Request, error) {
 	baseURL, err := url.Parse(c.apiURL)
 	if err != nil {
@@ -315,9 +696,12 @@ func (c *OpenWeatherClient) buildRequest(ctx context.Context, location string) (
 	}
 
 	params := url.Values{}
-	params.Set("q", location)
+	setLocationParams(params, location)
 	params.Set("appid", c.apiKey)
 	params.Set("units", "metric")
+	if lang := languageFromContext(ctx); lang != "" {
+		params.Set("lang", lang)
+	}
 	baseURL.RawQuery = params.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, "GET", baseURL.String(), nil)
@@ -329,6 +713,37 @@ func (c *OpenWeatherClient) buildRequest(ctx context.Context, location string) (
 	return req, nil
 }
 
+// setLocationParams sets the upstream location parameters: lat and lon for a coordinate key
+// (see validation.CoordinateKey), otherwise q for a name lookup.
+func setLocationParams(params url.Values, location string) {
+	if lat, lon, ok := validation.ParseCoordinateKey(location); ok {
+		params.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+		params.Set("lon", strconv.FormatFloat(lon, 'f', -1, 64))
+		return
+	}
+	params.Set("q", location)
+}
+
+// newRequest constructs an HTTP GET request for path on the configured API host (scheme and
+// host of apiURL), adding the API key to params. Used for endpoints other than current weather.
+func (c *OpenWeatherClient) newRequest(ctx context.Context, path string, params url.Values) (*http.Request, error) {
+	baseURL, err := url.Parse(c.apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API URL: %w", err)
+	}
+
+	params.Set("appid", c.apiKey)
+	endpoint := url.URL{Scheme: baseURL.Scheme, Host: baseURL.Host, Path: path, RawQuery: params.Encode()}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
 // parseRateLimitHeaders extracts rate limit information from HTTP response headers.
 // Parses Retry-After (seconds or HTTP date), X-RateLimit-Reset (Unix timestamp),
 // X-RateLimit-Limit, and X-RateLimit-Remaining for metrics.
@@ -372,12 +787,15 @@ func parseRateLimitHeaders(resp *http.Response) *rateLimitInfo {
 }
 
 // handleErrorResponse maps HTTP status codes to domain errors.
-// 401 -> ErrInvalidAPIKey, 404 -> ErrLocationNotFound, 429 -> ErrRateLimited (with header info),
+// 401 and 403 (key not permitted for the endpoint) -> ErrInvalidAPIKey, 404 -> ErrLocationNotFound,
+// 429 -> ErrRateLimited (with header info),
 // 5xx -> ErrUpstreamFailure. Returns nil for 2xx status codes.
 func (c *OpenWeatherClient) handleErrorResponse(resp *http.Response) error {
 	switch resp.StatusCode {
 	case http.StatusUnauthorized:
 		return fmt.Errorf("%w: invalid API key", ErrInvalidAPIKey)
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: HTTP 403", ErrInvalidAPIKey)
 	case http.StatusNotFound:
 		return fmt.Errorf("%w", ErrLocationNotFound)
 	case http.StatusTooManyRequests:
@@ -406,6 +824,7 @@ func (c *OpenWeatherClient) handleErrorResponse(resp *http.Response) error {
 // mapResponse transforms OpenWeatherMap API response format to WeatherData model.
 // Uses description if available, otherwise falls back to main condition. Uses API name
 // if provided, otherwise uses requested location. Normalizes location to lowercase.
+// Timestamp is the upstream observation time (dt), falling back to the fetch time when absent.
 func (c *OpenWeatherClient) mapResponse(apiResp openWeatherResponse, location string) models.WeatherData {
 	conditions := ""
 	if len(apiResp.Weather) > 0 {
@@ -420,14 +839,47 @@ func (c *OpenWeatherClient) mapResponse(apiResp openWeatherResponse, location st
 		displayName = location
 	}
 
+	fetchedAt := time.Now()
+	observedAt := fetchedAt
+	if apiResp.Dt > 0 {
+		observedAt = time.Unix(apiResp.Dt, 0).UTC()
+	}
+	var coords *models.Coordinates
+	if apiResp.Coord != nil {
+		coords = &models.Coordinates{Lat: apiResp.Coord.Lat, Lon: apiResp.Coord.Lon}
+	}
+
 	return models.WeatherData{
-		Location:    strings.ToLower(displayName),
-		Temperature: apiResp.Main.Temp,
-		Conditions:  conditions,
-		Humidity:    apiResp.Main.Humidity,
-		WindSpeed:   apiResp.Wind.Speed,
-		Timestamp:   time.Now(),
+		Location:       strings.ToLower(displayName),
+		Country:        apiResp.Sys.Country,
+		Coordinates:    coords,
+		Temperature:    apiResp.Main.Temp,
+		FeelsLike:      apiResp.Main.FeelsLike,
+		TemperatureMin: apiResp.Main.TempMin,
+		TemperatureMax: apiResp.Main.TempMax,
+		Conditions:     conditions,
+		Humidity:       apiResp.Main.Humidity,
+		Pressure:       apiResp.Main.Pressure,
+		Visibility:     apiResp.Visibility,
+		Cloudiness:     apiResp.Clouds.All,
+		WindSpeed:      apiResp.Wind.Speed,
+		WindDirection:  apiResp.Wind.Deg,
+		WindGust:       apiResp.Wind.Gust,
+		Rain1h:         apiResp.Rain.OneHour,
+		Snow1h:         apiResp.Snow.OneHour,
+		Sunrise:        unixTimeOrZero(apiResp.Sys.Sunrise),
+		Sunset:         unixTimeOrZero(apiResp.Sys.Sunset),
+		Timestamp:      observedAt,
+		FetchedAt:      fetchedAt,
+	}
+}
+
+// unixTimeOrZero converts Unix seconds to UTC time; 0 (field absent upstream) is the zero time.
+func unixTimeOrZero(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
 	}
+	return time.Unix(sec, 0).UTC()
 }
 
 // extractCorrelationID extracts correlation ID from request context if present.
@@ -45,8 +45,12 @@ func BenchmarkClient_MapResponse(b *testing.B) {
 	client, _ := NewOpenWeatherClient("key", "url", time.Second)
 	apiResp := openWeatherResponse{
 		Main: struct {
-			Temp     float64 `json:"temp"`
-			Humidity int     `json:"humidity"`
+			Temp      float64 `json:"temp"`
+			FeelsLike float64 `json:"feels_like"`
+			TempMin   float64 `json:"temp_min"`
+			TempMax   float64 `json:"temp_max"`
+			Pressure  int     `json:"pressure"`
+			Humidity  int     `json:"humidity"`
 		}{Temp: 15.5, Humidity: 65},
 		Weather: []struct {
 			Main        string `json:"main"`
@@ -54,6 +58,8 @@ func BenchmarkClient_MapResponse(b *testing.B) {
 		}{{Main: "Clear", Description: "clear sky"}},
 		Wind: struct {
 			Speed float64 `json:"speed"`
+			Deg   int     `json:"deg"`
+			Gust  float64 `json:"gust"`
 		}{Speed: 10.2},
 		Name: "Seattle",
 	}
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kjstillabower/weather-alert-service/internal/circuitbreaker"
 	"github.com/kjstillabower/weather-alert-service/internal/models"
 )
 
@@ -418,8 +419,12 @@ func TestOpenWeatherClient_mapResponse(t *testing.T) {
 			apiResp: openWeatherResponse{
 				Name: "Seattle",
 				Main: struct {
-					Temp     float64 `json:"temp"`
-					Humidity int     `json:"humidity"`
+					Temp      float64 `json:"temp"`
+					FeelsLike float64 `json:"feels_like"`
+					TempMin   float64 `json:"temp_min"`
+					TempMax   float64 `json:"temp_max"`
+					Pressure  int     `json:"pressure"`
+					Humidity  int     `json:"humidity"`
 				}{
 					Temp:     15.5,
 					Humidity: 65,
@@ -435,6 +440,8 @@ func TestOpenWeatherClient_mapResponse(t *testing.T) {
 				},
 				Wind: struct {
 					Speed float64 `json:"speed"`
+					Deg   int     `json:"deg"`
+					Gust  float64 `json:"gust"`
 				}{
 					Speed: 3.2,
 				},
@@ -453,8 +460,12 @@ func TestOpenWeatherClient_mapResponse(t *testing.T) {
 			apiResp: openWeatherResponse{
 				Name: "Portland",
 				Main: struct {
-					Temp     float64 `json:"temp"`
-					Humidity int     `json:"humidity"`
+					Temp      float64 `json:"temp"`
+					FeelsLike float64 `json:"feels_like"`
+					TempMin   float64 `json:"temp_min"`
+					TempMax   float64 `json:"temp_max"`
+					Pressure  int     `json:"pressure"`
+					Humidity  int     `json:"humidity"`
 				}{
 					Temp:     20.0,
 					Humidity: 50,
@@ -470,6 +481,8 @@ func TestOpenWeatherClient_mapResponse(t *testing.T) {
 				},
 				Wind: struct {
 					Speed float64 `json:"speed"`
+					Deg   int     `json:"deg"`
+					Gust  float64 `json:"gust"`
 				}{
 					Speed: 2.5,
 				},
@@ -488,8 +501,12 @@ func TestOpenWeatherClient_mapResponse(t *testing.T) {
 			apiResp: openWeatherResponse{
 				Name: "",
 				Main: struct {
-					Temp     float64 `json:"temp"`
-					Humidity int     `json:"humidity"`
+					Temp      float64 `json:"temp"`
+					FeelsLike float64 `json:"feels_like"`
+					TempMin   float64 `json:"temp_min"`
+					TempMax   float64 `json:"temp_max"`
+					Pressure  int     `json:"pressure"`
+					Humidity  int     `json:"humidity"`
 				}{
 					Temp:     10.0,
 					Humidity: 70,
@@ -505,6 +522,8 @@ func TestOpenWeatherClient_mapResponse(t *testing.T) {
 				},
 				Wind: struct {
 					Speed float64 `json:"speed"`
+					Deg   int     `json:"deg"`
+					Gust  float64 `json:"gust"`
 				}{
 					Speed: 1.0,
 				},
@@ -1062,3 +1081,327 @@ func TestOpenWeatherClient_RateLimitErrorType(t *testing.T) {
 		t.Errorf("GetCurrentWeather() elapsed = %v, expected ~1s (not too long)", elapsed)
 	}
 }
+
+// TestOpenWeatherClient_GetOfficialAlerts verifies the location is geocoded on the API host,
+// the One Call request uses the resolved coordinates, and alerts are mapped with UTC times.
+func TestOpenWeatherClient_GetOfficialAlerts(t *testing.T) {
+	// Arrange: API URL has a path; alert endpoints must use only its host
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("appid") == "" {
+			t.Errorf("%s: expected API key in query", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/geo/1.0/direct":
+			if r.URL.Query().Get("q") != "chicago" || r.URL.Query().Get("limit") != "1" {
+				t.Errorf("geocode query = %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `[{"name":"Chicago","lat":41.8781,"lon":-87.6298}]`)
+		case "/data/3.0/onecall":
+			if r.URL.Query().Get("lat") != "41.8781" || r.URL.Query().Get("lon") != "-87.6298" {
+				t.Errorf("onecall query = %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"alerts":[{"sender_name":"NWS Chicago","event":"Wind Advisory","start":1704110400,"end":1704132000,"description":"Gusts to 50 mph.","tags":["Wind"]}]}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := NewOpenWeatherClient("test-api-key-12345", server.URL+"/data/2.5/weather", 2*time.Second)
+	if err != nil {
+		t.Fatalf("NewOpenWeatherClient() error = %v", err)
+	}
+
+	// Act
+	got, err := client.GetOfficialAlerts(context.Background(), "chicago")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetOfficialAlerts() error = %v", err)
+	}
+	want := models.OfficialAlert{
+		Sender:      "NWS Chicago",
+		Event:       "Wind Advisory",
+		Start:       time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		End:         time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC),
+		Description: "Gusts to 50 mph.",
+	}
+	if len(got) != 1 || got[0].Sender != want.Sender || got[0].Event != want.Event || !got[0].Start.Equal(want.Start) || !got[0].End.Equal(want.End) || got[0].Description != want.Description || len(got[0].Tags) != 1 {
+		t.Errorf("GetOfficialAlerts() = %+v, want [%+v with tag Wind]", got, want)
+	}
+}
+
+// TestOpenWeatherClient_GetOfficialAlerts_Errors verifies an unknown location, no alerts in
+// effect, and that a key without a One Call subscription is reported as unsupported.
+func TestOpenWeatherClient_GetOfficialAlerts_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		geocode   string
+		oneCall   int
+		wantCount int
+		wantErr   error
+	}{
+		{name: "location not geocoded", geocode: `[]`, oneCall: http.StatusOK, wantErr: ErrLocationNotFound},
+		{name: "no alerts", geocode: `[{"lat":1,"lon":2}]`, oneCall: http.StatusOK, wantCount: 0},
+		{name: "one call unauthorized", geocode: `[{"lat":1,"lon":2}]`, oneCall: http.StatusUnauthorized, wantErr: ErrAlertsUnsupported},
+		{name: "one call forbidden", geocode: `[{"lat":1,"lon":2}]`, oneCall: http.StatusForbidden, wantErr: ErrAlertsUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/geo/1.0/direct" {
+					fmt.Fprint(w, tt.geocode)
+					return
+				}
+				w.WriteHeader(tt.oneCall)
+				fmt.Fprint(w, `{"lat":1,"lon":2}`)
+			}))
+			defer server.Close()
+			client, _ := NewOpenWeatherClient("test-api-key-12345", server.URL, 2*time.Second)
+
+			got, err := client.GetOfficialAlerts(context.Background(), "nowhere")
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetOfficialAlerts() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got == nil || len(got) != tt.wantCount {
+				t.Errorf("GetOfficialAlerts() = %#v, %v; want %d alerts", got, err, tt.wantCount)
+			}
+		})
+	}
+}
+
+// TestOpenWeatherClient_GetOfficialAlerts_UnsupportedKeepsBreakerClosed verifies that
+// alert requests on a key without One Call access do not open the shared circuit breaker.
+func TestOpenWeatherClient_GetOfficialAlerts_UnsupportedKeepsBreakerClosed(t *testing.T) {
+	// Arrange: One Call rejects the key; geocoding and current weather work
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/geo/1.0/direct":
+			fmt.Fprint(w, `[{"lat":1,"lon":2}]`)
+		case "/data/3.0/onecall":
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"cod":401,"message":"Invalid API key."}`)
+		default:
+			fmt.Fprint(w, `{"name":"Chicago","main":{"temp":5},"weather":[{"main":"Clear"}]}`)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewOpenWeatherClient("test-api-key-12345", server.URL+"/data/2.5/weather", 2*time.Second)
+	cb := circuitbreaker.New(circuitbreaker.Config{FailureThreshold: 2, SuccessThreshold: 1, Timeout: time.Minute})
+	client.SetCircuitBreaker(cb)
+
+	// Act
+	for i := 0; i < 5; i++ {
+		if _, err := client.GetOfficialAlerts(context.Background(), "chicago"); !errors.Is(err, ErrAlertsUnsupported) {
+			t.Fatalf("GetOfficialAlerts() error = %v, want ErrAlertsUnsupported", err)
+		}
+	}
+
+	// Assert
+	if cb.State() != circuitbreaker.StateClosed {
+		t.Errorf("breaker state = %v, want closed", cb.State())
+	}
+	if _, err := client.GetCurrentWeather(context.Background(), "chicago"); err != nil {
+		t.Errorf("GetCurrentWeather() error = %v, want success", err)
+	}
+}
+
+// TestOpenWeatherClient_GetForecast verifies the 5-day/3-hour forecast request and mapping.
+func TestOpenWeatherClient_GetForecast(t *testing.T) {
+	// Arrange: API URL has a path; the forecast endpoint must use only its host
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/forecast" {
+			t.Errorf("path = %s, want /data/2.5/forecast", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("q") != "chicago" || q.Get("units") != "metric" || q.Get("appid") == "" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"city":{"name":"Chicago"},"list":[
+			{"dt":1704110400,"main":{"temp":-2.5,"humidity":80},"weather":[{"main":"Snow","description":"light snow"}],"wind":{"speed":6.2},"pop":0.7},
+			{"dt":1704121200,"main":{"temp":-3.1,"humidity":75},"weather":[{"main":"Clouds"}],"wind":{"speed":4.0},"pop":0}]}`)
+	}))
+	defer server.Close()
+	client, err := NewOpenWeatherClient("test-api-key-12345", server.URL+"/data/2.5/weather", 2*time.Second)
+	if err != nil {
+		t.Fatalf("NewOpenWeatherClient() error = %v", err)
+	}
+
+	// Act
+	got, err := client.GetForecast(context.Background(), "chicago")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	if got.Location != "chicago" || len(got.Periods) != 2 || got.Timestamp.IsZero() {
+		t.Fatalf("GetForecast() = %+v", got)
+	}
+	want := models.ForecastPeriod{Time: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), Temperature: -2.5, Conditions: "light snow", Humidity: 80, WindSpeed: 6.2, PrecipitationProbability: 0.7}
+	if p := got.Periods[0]; !p.Time.Equal(want.Time) || p.Temperature != want.Temperature || p.Conditions != want.Conditions || p.Humidity != want.Humidity || p.WindSpeed != want.WindSpeed || p.PrecipitationProbability != want.PrecipitationProbability {
+		t.Errorf("Periods[0] = %+v, want %+v", p, want)
+	}
+	if got.Periods[1].Conditions != "Clouds" {
+		t.Errorf("Periods[1].Conditions = %q, want Clouds (main when no description)", got.Periods[1].Conditions)
+	}
+}
+
+// TestOpenWeatherClient_GetCurrentWeather_Coordinates verifies a coordinate key is sent as lat/lon
+// rather than q, and the upstream place name is used as the location.
+func TestOpenWeatherClient_GetCurrentWeather_Coordinates(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Has("q") || q.Get("lat") != "41.88" || q.Get("lon") != "-87.63" {
+			t.Errorf("query = %s, want lat=41.88&lon=-87.63 without q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name":"Chicago","main":{"temp":12.5,"humidity":60},"weather":[{"main":"Clear","description":"clear sky"}],"wind":{"speed":3.1}}`)
+	}))
+	defer server.Close()
+	client, err := NewOpenWeatherClient("test-api-key-12345", server.URL, 2*time.Second)
+	if err != nil {
+		t.Fatalf("NewOpenWeatherClient() error = %v", err)
+	}
+
+	// Act
+	got, err := client.GetCurrentWeather(context.Background(), "41.88,-87.63")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetCurrentWeather() error = %v", err)
+	}
+	if got.Location != "chicago" {
+		t.Errorf("Location = %q, want chicago", got.Location)
+	}
+}
+
+// TestOpenWeatherClient_GetCurrentWeather_Language verifies a language set with WithLanguage is
+// sent as the lang parameter and omitted otherwise.
+func TestOpenWeatherClient_GetCurrentWeather_Language(t *testing.T) {
+	// Arrange
+	var langs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		langs = append(langs, r.URL.Query().Get("lang"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name":"Paris","main":{"temp":12.5,"humidity":60},"weather":[{"main":"Clear","description":"ciel dégagé"}],"wind":{"speed":3.1}}`)
+	}))
+	defer server.Close()
+	client, err := NewOpenWeatherClient("test-api-key-12345", server.URL, 2*time.Second)
+	if err != nil {
+		t.Fatalf("NewOpenWeatherClient() error = %v", err)
+	}
+
+	// Act
+	if _, err := client.GetCurrentWeather(WithLanguage(context.Background(), "fr"), "paris"); err != nil {
+		t.Fatalf("GetCurrentWeather(fr) error = %v", err)
+	}
+	if _, err := client.GetCurrentWeather(context.Background(), "paris"); err != nil {
+		t.Fatalf("GetCurrentWeather() error = %v", err)
+	}
+
+	// Assert
+	if len(langs) != 2 || langs[0] != "fr" || langs[1] != "" {
+		t.Errorf("lang params = %q, want [fr \"\"]", langs)
+	}
+}
+
+// TestOpenWeatherClient_GetCurrentWeather_ExtendedFields verifies the full upstream payload is
+// mapped, with Timestamp taken from the observation time and FetchedAt from the fetch.
+func TestOpenWeatherClient_GetCurrentWeather_ExtendedFields(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"coord":{"lon":-87.65,"lat":41.85},"weather":[{"main":"Snow","description":"light snow"}],
+			"main":{"temp":-2.1,"feels_like":-7.4,"temp_min":-3,"temp_max":-1.2,"pressure":1012,"humidity":86},
+			"visibility":4000,"wind":{"speed":5.7,"deg":320,"gust":9.8},"clouds":{"all":100},"snow":{"1h":0.6},
+			"dt":1704902400,"sys":{"country":"US","sunrise":1704892000,"sunset":1704926500},"name":"Chicago"}`)
+	}))
+	defer server.Close()
+	client, err := NewOpenWeatherClient("test-api-key-12345", server.URL, 2*time.Second)
+	if err != nil {
+		t.Fatalf("NewOpenWeatherClient() error = %v", err)
+	}
+	before := time.Now()
+
+	// Act
+	got, err := client.GetCurrentWeather(context.Background(), "chicago")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetCurrentWeather() error = %v", err)
+	}
+	if got.Country != "US" || got.Coordinates == nil || got.Coordinates.Lat != 41.85 || got.Coordinates.Lon != -87.65 {
+		t.Errorf("country/coordinates = %q, %+v", got.Country, got.Coordinates)
+	}
+	if got.FeelsLike != -7.4 || got.TemperatureMin != -3 || got.TemperatureMax != -1.2 || got.Pressure != 1012 || got.Visibility != 4000 {
+		t.Errorf("main fields = %+v", got)
+	}
+	if got.WindDirection != 320 || got.WindGust != 9.8 || got.Cloudiness != 100 || got.Snow1h != 0.6 || got.Rain1h != 0 {
+		t.Errorf("wind/clouds/precipitation fields = %+v", got)
+	}
+	if !got.Timestamp.Equal(time.Unix(1704902400, 0)) || !got.Sunrise.Equal(time.Unix(1704892000, 0)) || !got.Sunset.Equal(time.Unix(1704926500, 0)) {
+		t.Errorf("timestamp = %v, sunrise = %v, sunset = %v", got.Timestamp, got.Sunrise, got.Sunset)
+	}
+	if got.FetchedAt.Before(before) {
+		t.Errorf("FetchedAt = %v, want fetch time after %v", got.FetchedAt, before)
+	}
+}
+
+// TestOpenWeatherClient_SearchLocations verifies geocoding matches are mapped to places with
+// coordinate-key IDs and duplicate IDs are dropped.
+func TestOpenWeatherClient_SearchLocations(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/geo/1.0/direct" || r.URL.Query().Get("q") != "springfield" || r.URL.Query().Get("limit") != "5" {
+			t.Errorf("request = %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[
+			{"name":"Springfield","lat":39.7990175,"lon":-89.6439575,"country":"US","state":"Illinois"},
+			{"name":"Springfield","lat":39.799,"lon":-89.644,"country":"US","state":"Illinois"},
+			{"name":"Springfield","lat":37.2153,"lon":-93.2982,"country":"US","state":"Missouri"}]`)
+	}))
+	defer server.Close()
+	client, err := NewOpenWeatherClient("test-api-key-12345", server.URL+"/data/2.5/weather", 2*time.Second)
+	if err != nil {
+		t.Fatalf("NewOpenWeatherClient() error = %v", err)
+	}
+
+	// Act
+	got, err := client.SearchLocations(context.Background(), "springfield")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SearchLocations() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("SearchLocations() = %+v, want 2 places", got)
+	}
+	want := models.Place{ID: "39.80,-89.64", Name: "Springfield", State: "Illinois", Country: "US", Coordinates: models.Coordinates{Lat: 39.7990175, Lon: -89.6439575}}
+	if got[0] != want || got[1].ID != "37.22,-93.30" || got[1].State != "Missouri" {
+		t.Errorf("SearchLocations() = %+v, want first %+v", got, want)
+	}
+}
+
+// TestOpenWeatherClient_GetForecast_NotFound verifies a 404 maps to ErrLocationNotFound.
+func TestOpenWeatherClient_GetForecast_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"cod":"404","message":"city not found"}`)
+	}))
+	defer server.Close()
+	client, _ := NewOpenWeatherClient("test-api-key-12345", server.URL, 2*time.Second)
+
+	_, err := client.GetForecast(context.Background(), "nowhere")
+
+	if !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("GetForecast() error = %v, want ErrLocationNotFound", err)
+	}
+}
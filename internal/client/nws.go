@@ -0,0 +1,417 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// defaultNWSUserAgent identifies the service to api.weather.gov, which rejects requests
+// without a User-Agent.
+const defaultNWSUserAgent = "weather-alert-service"
+
+// NWSClient implements WeatherClient for the US National Weather Service API
+// (api.weather.gov). Current weather is the latest observation from the station nearest the
+// location, and forecasts and alerts come from the NWS gridpoint forecast and active alerts.
+// NWS covers the United States only; other locations return ErrLocationNotFound. NWS has no
+// geocoding, so names are resolved with the Open-Meteo geocoding API, restricted to the US. No
+// API key is needed. Condition descriptions are English regardless of WithLanguage.
+type NWSClient struct {
+	upstream
+	apiURL       string // NWS API base URL, e.g. https://api.weather.gov
+	geocodingURL string // Open-Meteo geocoding API base URL
+}
+
+// NewNWSClient creates an NWSClient. userAgent is sent with every request as NWS asks (an
+// application name and contact); empty uses "weather-alert-service". Retry settings behave as
+// in NewOpenWeatherClientWithRetry.
+func NewNWSClient(apiURL, geocodingURL, userAgent string, timeout time.Duration, retryAttempts int, retryBaseDelay, retryMaxDelay time.Duration) (*NWSClient, error) {
+	if err := validateBaseURL("NWS API URL", apiURL); err != nil {
+		return nil, err
+	}
+	if err := validateBaseURL("NWS geocoding URL", geocodingURL); err != nil {
+		return nil, err
+	}
+	if userAgent == "" {
+		userAgent = defaultNWSUserAgent
+	}
+	c := &NWSClient{
+		upstream:     newUpstream(timeout, retryAttempts, retryBaseDelay, retryMaxDelay),
+		apiURL:       strings.TrimRight(apiURL, "/"),
+		geocodingURL: strings.TrimRight(geocodingURL, "/"),
+	}
+	c.header.Set("Accept", "application/geo+json")
+	c.header.Set("User-Agent", userAgent)
+	return c, nil
+}
+
+// ProviderName returns "nws".
+func (c *NWSClient) ProviderName() string {
+	return "nws"
+}
+
+// nwsValue is an NWS quantitative value. value is null when the station did not report it.
+type nwsValue struct {
+	Value    *float64 `json:"value"`
+	UnitCode string   `json:"unitCode"`
+}
+
+// nwsPointResponse is the part of GET /points/{lat},{lon} used to find stations and forecasts.
+type nwsPointResponse struct {
+	Properties struct {
+		ForecastHourly      string `json:"forecastHourly"`
+		ObservationStations string `json:"observationStations"`
+		RelativeLocation    struct {
+			Properties struct {
+				City  string `json:"city"`
+				State string `json:"state"`
+			} `json:"properties"`
+		} `json:"relativeLocation"`
+	} `json:"properties"`
+}
+
+// nwsStationsResponse lists observation stations, nearest first.
+type nwsStationsResponse struct {
+	Features []struct {
+		Properties struct {
+			StationIdentifier string `json:"stationIdentifier"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+// nwsObservationResponse is a station observation. Values carry WMO unit codes.
+type nwsObservationResponse struct {
+	Properties struct {
+		Timestamp             time.Time       `json:"timestamp"`
+		TextDescription       string          `json:"textDescription"`
+		Temperature           nwsValue        `json:"temperature"`
+		WindDirection         nwsValue        `json:"windDirection"`
+		WindSpeed             nwsValue        `json:"windSpeed"`
+		WindGust              nwsValue        `json:"windGust"`
+		SeaLevelPressure      nwsValue        `json:"seaLevelPressure"`
+		BarometricPressure    nwsValue        `json:"barometricPressure"`
+		Visibility            nwsValue        `json:"visibility"`
+		RelativeHumidity      nwsValue        `json:"relativeHumidity"`
+		PrecipitationLastHour nwsValue        `json:"precipitationLastHour"`
+		HeatIndex             nwsValue        `json:"heatIndex"`
+		WindChill             nwsValue        `json:"windChill"`
+		CloudLayers           []nwsCloudLayer `json:"cloudLayers"`
+	} `json:"properties"`
+}
+
+// nwsCloudLayer is one reported cloud layer; amount is a METAR cover code such as "BKN".
+type nwsCloudLayer struct {
+	Amount string `json:"amount"`
+}
+
+// nwsForecastResponse is an hourly gridpoint forecast requested with units=si.
+type nwsForecastResponse struct {
+	Properties struct {
+		Periods []struct {
+			StartTime                  time.Time `json:"startTime"`
+			Temperature                float64   `json:"temperature"`
+			TemperatureUnit            string    `json:"temperatureUnit"`
+			WindSpeed                  string    `json:"windSpeed"`
+			ShortForecast              string    `json:"shortForecast"`
+			ProbabilityOfPrecipitation nwsValue  `json:"probabilityOfPrecipitation"`
+			RelativeHumidity           nwsValue  `json:"relativeHumidity"`
+		} `json:"periods"`
+	} `json:"properties"`
+}
+
+// nwsAlertsResponse is the active alerts collection. onset and ends are null for some alerts;
+// effective and expires are always set.
+type nwsAlertsResponse struct {
+	Features []struct {
+		Properties struct {
+			SenderName  string     `json:"senderName"`
+			Event       string     `json:"event"`
+			Effective   time.Time  `json:"effective"`
+			Onset       *time.Time `json:"onset"`
+			Expires     time.Time  `json:"expires"`
+			Ends        *time.Time `json:"ends"`
+			Description string     `json:"description"`
+			Category    string     `json:"category"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+// GetCurrentWeather returns the latest observation from the station nearest the location. Uses
+// the same retry, circuit breaker and timeout propagation as OpenWeatherClient.
+func (c *NWSClient) GetCurrentWeather(ctx context.Context, location string) (models.WeatherData, error) {
+	var data models.WeatherData
+	err := c.call(ctx, func(timeout time.Duration) error {
+		point, meta, err := c.point(ctx, location, timeout)
+		if err != nil {
+			return err
+		}
+		var stations nwsStationsResponse
+		if err := c.getJSON(ctx, meta.Properties.ObservationStations, nil, timeout, &stations); err != nil {
+			return err
+		}
+		if len(stations.Features) == 0 {
+			return fmt.Errorf("%w: no observation station", ErrLocationNotFound)
+		}
+		station := stations.Features[0].Properties.StationIdentifier
+		var obs nwsObservationResponse
+		if err := c.getJSON(ctx, c.apiURL+"/stations/"+url.PathEscape(station)+"/observations/latest", nil, timeout, &obs); err != nil {
+			return err
+		}
+		if obs.Properties.Temperature.Value == nil {
+			return fmt.Errorf("%w: station %s reported no temperature", ErrUpstreamFailure, station)
+		}
+		data = mapNWSObservation(obs, point)
+		return nil
+	})
+	if err != nil {
+		return models.WeatherData{}, err
+	}
+	return data, nil
+}
+
+// mapNWSObservation maps a station observation to WeatherData. Missing values are zero, except
+// FeelsLike, which is the reported heat index or wind chill, else the temperature.
+func mapNWSObservation(obs nwsObservationResponse, point geocodeResponse) models.WeatherData {
+	p := obs.Properties
+	fetchedAt := time.Now()
+	observedAt := fetchedAt
+	if !p.Timestamp.IsZero() {
+		observedAt = p.Timestamp.UTC()
+	}
+	temperature := nwsCelsius(p.Temperature)
+	feelsLike := temperature
+	if p.HeatIndex.Value != nil {
+		feelsLike = nwsCelsius(p.HeatIndex)
+	} else if p.WindChill.Value != nil {
+		feelsLike = nwsCelsius(p.WindChill)
+	}
+	pressure := p.SeaLevelPressure
+	if pressure.Value == nil {
+		pressure = p.BarometricPressure
+	}
+	return models.WeatherData{
+		Location:      strings.ToLower(point.Name),
+		Country:       "US",
+		Coordinates:   &models.Coordinates{Lat: point.Lat, Lon: point.Lon},
+		Temperature:   temperature,
+		FeelsLike:     feelsLike,
+		Conditions:    strings.ToLower(p.TextDescription),
+		Humidity:      int(math.Round(nwsNumber(p.RelativeHumidity))),
+		Pressure:      int(math.Round(nwsNumber(pressure) / 100)), // Pa to hPa
+		Visibility:    int(math.Round(nwsNumber(p.Visibility))),
+		Cloudiness:    nwsCloudiness(p.CloudLayers),
+		WindSpeed:     nwsMetersPerSecond(p.WindSpeed),
+		WindDirection: int(math.Round(nwsNumber(p.WindDirection))),
+		WindGust:      nwsMetersPerSecond(p.WindGust),
+		Rain1h:        nwsNumber(p.PrecipitationLastHour),
+		Timestamp:     observedAt,
+		FetchedAt:     fetchedAt,
+	}
+}
+
+// GetForecast returns the hourly gridpoint forecast, keeping the hours that start a 3-hour
+// period so it matches OpenWeatherMap's forecast steps.
+func (c *NWSClient) GetForecast(ctx context.Context, location string) (models.Forecast, error) {
+	var forecast models.Forecast
+	err := c.call(ctx, func(timeout time.Duration) error {
+		point, meta, err := c.point(ctx, location, timeout)
+		if err != nil {
+			return err
+		}
+		params := url.Values{}
+		params.Set("units", "si")
+		var apiResp nwsForecastResponse
+		if err := c.getJSON(ctx, meta.Properties.ForecastHourly, params, timeout, &apiResp); err != nil {
+			return err
+		}
+		forecast = mapNWSForecast(apiResp, point)
+		return nil
+	})
+	if err != nil {
+		return models.Forecast{}, err
+	}
+	return forecast, nil
+}
+
+// mapNWSForecast maps hourly periods to 3-hour forecast periods.
+func mapNWSForecast(apiResp nwsForecastResponse, point geocodeResponse) models.Forecast {
+	periods := make([]models.ForecastPeriod, 0, len(apiResp.Properties.Periods)/3)
+	for _, p := range apiResp.Properties.Periods {
+		if !forecastPeriodAligned(p.StartTime) {
+			continue
+		}
+		temperature := p.Temperature
+		if p.TemperatureUnit == "F" {
+			temperature = (temperature - 32) * 5 / 9
+		}
+		periods = append(periods, models.ForecastPeriod{
+			Time:                     p.StartTime.UTC(),
+			Temperature:              temperature,
+			Conditions:               strings.ToLower(p.ShortForecast),
+			Humidity:                 int(math.Round(nwsNumber(p.RelativeHumidity))),
+			WindSpeed:                parseNWSWindSpeed(p.WindSpeed),
+			PrecipitationProbability: nwsNumber(p.ProbabilityOfPrecipitation) / 100,
+		})
+	}
+	return models.Forecast{
+		Location:  strings.ToLower(point.Name),
+		Periods:   periods,
+		Timestamp: time.Now(),
+	}
+}
+
+// GetOfficialAlerts returns the NWS alerts in effect at the location. Start is the onset (else
+// the effective time) and End the expected end (else the expiry). The alert category is the
+// only tag.
+func (c *NWSClient) GetOfficialAlerts(ctx context.Context, location string) ([]models.OfficialAlert, error) {
+	var alerts []models.OfficialAlert
+	err := c.call(ctx, func(timeout time.Duration) error {
+		point, err := c.resolve(ctx, c.geocodingURL, location, "US", timeout)
+		if err != nil {
+			return err
+		}
+		params := url.Values{}
+		params.Set("point", nwsPointKey(point))
+		var apiResp nwsAlertsResponse
+		if err := c.getJSON(ctx, c.apiURL+"/alerts/active", params, timeout, &apiResp); err != nil {
+			return err
+		}
+		alerts = make([]models.OfficialAlert, 0, len(apiResp.Features))
+		for _, f := range apiResp.Features {
+			a := f.Properties
+			alert := models.OfficialAlert{
+				Sender:      a.SenderName,
+				Event:       a.Event,
+				Start:       a.Effective.UTC(),
+				End:         a.Expires.UTC(),
+				Description: a.Description,
+			}
+			if a.Onset != nil {
+				alert.Start = a.Onset.UTC()
+			}
+			if a.Ends != nil {
+				alert.End = a.Ends.UTC()
+			}
+			if a.Category != "" {
+				alert.Tags = []string{a.Category}
+			}
+			alerts = append(alerts, alert)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// SearchLocations returns up to five US places matching query from the Open-Meteo geocoding
+// API, with IDs as in OpenWeatherClient.SearchLocations.
+func (c *NWSClient) SearchLocations(ctx context.Context, query string) ([]models.Place, error) {
+	return c.searchLocations(ctx, c.geocodingURL, query, "US")
+}
+
+// ValidateAPIKey checks that the NWS API accepts requests with the configured User-Agent.
+// Returns ErrInvalidAPIKey when NWS answers 403. Uses a short timeout (5s) and no retries, like
+// OpenWeatherClient.ValidateAPIKey.
+func (c *NWSClient) ValidateAPIKey(ctx context.Context) error {
+	var status struct {
+		Status string `json:"status"`
+	}
+	if err := c.getJSON(ctx, c.apiURL+"/", nil, 5*time.Second, &status); err != nil {
+		return fmt.Errorf("validation request failed: %w", err)
+	}
+	return nil
+}
+
+// point resolves the location and reads its NWS point metadata. When the location came from a
+// coordinate key, the point is named after the nearest city NWS reports.
+func (c *NWSClient) point(ctx context.Context, location string, timeout time.Duration) (geocodeResponse, nwsPointResponse, error) {
+	point, err := c.resolve(ctx, c.geocodingURL, location, "US", timeout)
+	if err != nil {
+		return geocodeResponse{}, nwsPointResponse{}, err
+	}
+	var meta nwsPointResponse
+	if err := c.getJSON(ctx, c.apiURL+"/points/"+nwsPointKey(point), nil, timeout, &meta); err != nil {
+		return geocodeResponse{}, nwsPointResponse{}, err
+	}
+	if city := meta.Properties.RelativeLocation.Properties.City; city != "" {
+		if _, _, ok := validation.ParseCoordinateKey(location); ok {
+			point.Name = city
+		}
+	}
+	return point, meta, nil
+}
+
+// nwsPointKey formats coordinates as NWS expects: lat,lon with at most four decimals.
+func nwsPointKey(point geocodeResponse) string {
+	return strconv.FormatFloat(math.Round(point.Lat*1e4)/1e4, 'f', -1, 64) + "," +
+		strconv.FormatFloat(math.Round(point.Lon*1e4)/1e4, 'f', -1, 64)
+}
+
+// nwsNumber returns v's value, or 0 when it was not reported.
+func nwsNumber(v nwsValue) float64 {
+	if v.Value == nil {
+		return 0
+	}
+	return *v.Value
+}
+
+// nwsCelsius returns a temperature in °C; NWS reports wmoUnit:degC, but degF is converted.
+func nwsCelsius(v nwsValue) float64 {
+	t := nwsNumber(v)
+	if strings.HasSuffix(v.UnitCode, "degF") {
+		return (t - 32) * 5 / 9
+	}
+	return t
+}
+
+// nwsMetersPerSecond returns a speed in m/s from NWS's wmoUnit:km_h-1 (or m_s-1).
+func nwsMetersPerSecond(v nwsValue) float64 {
+	speed := nwsNumber(v)
+	if strings.HasSuffix(v.UnitCode, ":m_s-1") {
+		return speed
+	}
+	return speed / 3.6
+}
+
+// parseNWSWindSpeed parses a forecast wind speed such as "15 km/h", "10 mph" or "5 to 10 mph"
+// (the upper value is used) into m/s. Unparseable speeds are 0.
+func parseNWSWindSpeed(s string) float64 {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return 0
+	}
+	speed, err := strconv.ParseFloat(fields[len(fields)-2], 64)
+	if err != nil {
+		return 0
+	}
+	switch fields[len(fields)-1] {
+	case "mph":
+		return speed * 0.44704
+	case "km/h":
+		return speed / 3.6
+	}
+	return 0
+}
+
+// nwsCloudAmounts maps METAR cloud cover codes to percent.
+var nwsCloudAmounts = map[string]int{"SKC": 0, "CLR": 0, "FEW": 25, "SCT": 50, "BKN": 75, "OVC": 100, "VV": 100}
+
+// nwsCloudiness returns the highest cloud cover among the reported layers, in percent.
+func nwsCloudiness(layers []nwsCloudLayer) int {
+	cover := 0
+	for _, l := range layers {
+		if pct := nwsCloudAmounts[l.Amount]; pct > cover {
+			cover = pct
+		}
+	}
+	return cover
+}
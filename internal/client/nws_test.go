@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newNWSServer serves the NWS point, stations, observation, forecast and alerts endpoints for
+// 41.85,-87.65 plus Open-Meteo geocoding, checking the User-Agent on NWS calls.
+func newNWSServer(t *testing.T, observation string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/search" && r.Header.Get("User-Agent") != "test-agent (ops@example.com)" {
+			t.Errorf("%s: User-Agent = %q", r.URL.Path, r.Header.Get("User-Agent"))
+		}
+		switch r.URL.Path {
+		case "/v1/search":
+			if r.URL.Query().Get("countryCode") != "US" {
+				t.Errorf("geocode query = %s, want countryCode=US", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"results":[{"name":"Chicago","admin1":"Illinois","country_code":"US","latitude":41.85003,"longitude":-87.65006}]}`)
+		case "/points/41.85,-87.6501":
+			fmt.Fprintf(w, `{"properties":{"forecastHourly":"%[1]s/gridpoints/LOT/76,73/forecast/hourly",
+				"observationStations":"%[1]s/gridpoints/LOT/76,73/stations",
+				"relativeLocation":{"properties":{"city":"Chicago","state":"IL"}}}}`, server.URL)
+		case "/gridpoints/LOT/76,73/stations":
+			fmt.Fprint(w, `{"features":[{"properties":{"stationIdentifier":"KMDW"}},{"properties":{"stationIdentifier":"KORD"}}]}`)
+		case "/stations/KMDW/observations/latest":
+			fmt.Fprint(w, observation)
+		case "/gridpoints/LOT/76,73/forecast/hourly":
+			if r.URL.Query().Get("units") != "si" {
+				t.Errorf("forecast query = %s, want units=si", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"properties":{"periods":[
+				{"startTime":"2024-01-01T06:00:00-06:00","temperature":-2,"temperatureUnit":"C","windSpeed":"18 km/h","shortForecast":"Chance Light Snow",
+				 "probabilityOfPrecipitation":{"value":40},"relativeHumidity":{"value":85}},
+				{"startTime":"2024-01-01T07:00:00-06:00","temperature":-1,"temperatureUnit":"C","windSpeed":"18 km/h","shortForecast":"Cloudy",
+				 "probabilityOfPrecipitation":{"value":null},"relativeHumidity":{"value":80}}]}}`)
+		case "/alerts/active":
+			if r.URL.Query().Get("point") != "41.85,-87.6501" {
+				t.Errorf("alerts query = %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"features":[{"properties":{"senderName":"NWS Chicago IL","event":"Wind Advisory",
+				"effective":"2024-01-01T09:00:00-06:00","onset":"2024-01-01T12:00:00-06:00","expires":"2024-01-01T15:00:00-06:00","ends":null,
+				"description":"Gusts to 50 mph.","category":"Met"}}]}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+const nwsObservation = `{"properties":{"timestamp":"2024-01-01T11:53:00+00:00","textDescription":"Light Snow",
+	"temperature":{"unitCode":"wmoUnit:degC","value":-2.2},
+	"windDirection":{"unitCode":"wmoUnit:degree_(angle)","value":270},
+	"windSpeed":{"unitCode":"wmoUnit:km_h-1","value":18},
+	"windGust":{"unitCode":"wmoUnit:km_h-1","value":null},
+	"seaLevelPressure":{"unitCode":"wmoUnit:Pa","value":101260},
+	"visibility":{"unitCode":"wmoUnit:m","value":4020},
+	"relativeHumidity":{"unitCode":"wmoUnit:percent","value":85.4},
+	"precipitationLastHour":{"unitCode":"wmoUnit:mm","value":null},
+	"heatIndex":{"unitCode":"wmoUnit:degC","value":null},
+	"windChill":{"unitCode":"wmoUnit:degC","value":-8.1},
+	"cloudLayers":[{"amount":"SCT"},{"amount":"OVC"}]}}`
+
+// TestNWSClient_GetCurrentWeather verifies the point, station and observation lookups and the
+// unit conversions of the observation.
+func TestNWSClient_GetCurrentWeather(t *testing.T) {
+	// Arrange
+	server := newNWSServer(t, nwsObservation)
+	defer server.Close()
+	client, err := NewNWSClient(server.URL, server.URL, "test-agent (ops@example.com)", 2*time.Second, 1, time.Millisecond, time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewNWSClient() error = %v", err)
+	}
+
+	// Act
+	got, err := client.GetCurrentWeather(context.Background(), "chicago")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetCurrentWeather() error = %v", err)
+	}
+	if got.Location != "chicago" || got.Country != "US" || got.Temperature != -2.2 || got.FeelsLike != -8.1 || got.Conditions != "light snow" {
+		t.Errorf("GetCurrentWeather() = %+v", got)
+	}
+	if got.Pressure != 1013 || got.Humidity != 85 || got.Visibility != 4020 || got.Cloudiness != 100 || math.Abs(got.WindSpeed-5) > 0.01 || got.WindGust != 0 {
+		t.Errorf("GetCurrentWeather() values = %+v", got)
+	}
+}
+
+// TestNWSClient_GetForecast verifies hourly periods are reduced to 3-hour periods in UTC.
+func TestNWSClient_GetForecast(t *testing.T) {
+	server := newNWSServer(t, nwsObservation)
+	defer server.Close()
+	client, _ := NewNWSClient(server.URL, server.URL, "test-agent (ops@example.com)", 2*time.Second, 1, time.Millisecond, time.Millisecond)
+
+	got, err := client.GetForecast(context.Background(), "chicago")
+
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	if len(got.Periods) != 1 {
+		t.Fatalf("periods = %+v, want 1 (12:00 UTC)", got.Periods)
+	}
+	p := got.Periods[0]
+	if !p.Time.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) || p.Conditions != "chance light snow" || p.PrecipitationProbability != 0.4 || math.Abs(p.WindSpeed-5) > 0.01 {
+		t.Errorf("period = %+v", p)
+	}
+}
+
+// TestNWSClient_GetOfficialAlerts verifies alerts use the onset as start and fall back to the
+// expiry when the end is unknown.
+func TestNWSClient_GetOfficialAlerts(t *testing.T) {
+	server := newNWSServer(t, nwsObservation)
+	defer server.Close()
+	client, _ := NewNWSClient(server.URL, server.URL, "test-agent (ops@example.com)", 2*time.Second, 1, time.Millisecond, time.Millisecond)
+
+	got, err := client.GetOfficialAlerts(context.Background(), "chicago")
+
+	if err != nil {
+		t.Fatalf("GetOfficialAlerts() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Event != "Wind Advisory" || !got[0].Start.Equal(time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)) ||
+		!got[0].End.Equal(time.Date(2024, 1, 1, 21, 0, 0, 0, time.UTC)) || len(got[0].Tags) != 1 {
+		t.Errorf("GetOfficialAlerts() = %+v", got)
+	}
+}
+
+// TestNWSClient_Errors verifies locations outside NWS coverage, rejected requests and stations
+// without a temperature map to the shared sentinels.
+func TestNWSClient_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		pointStatus int
+		observation string
+		wantErr     error
+	}{
+		{name: "outside coverage", pointStatus: http.StatusNotFound, wantErr: ErrLocationNotFound},
+		{name: "user agent rejected", pointStatus: http.StatusForbidden, wantErr: ErrInvalidAPIKey},
+		{name: "no temperature", pointStatus: http.StatusOK, observation: `{"properties":{"temperature":{"value":null}}}`, wantErr: ErrUpstreamFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/points/1,2":
+					w.WriteHeader(tt.pointStatus)
+					fmt.Fprintf(w, `{"properties":{"observationStations":"%s/stations"}}`, server.URL)
+				case "/stations":
+					fmt.Fprint(w, `{"features":[{"properties":{"stationIdentifier":"KXYZ"}}]}`)
+				default:
+					fmt.Fprint(w, tt.observation)
+				}
+			}))
+			defer server.Close()
+			client, _ := NewNWSClient(server.URL, server.URL, "", 2*time.Second, 1, time.Millisecond, time.Millisecond)
+
+			_, err := client.GetCurrentWeather(context.Background(), "1.00,2.00")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetCurrentWeather() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestParseNWSWindSpeed verifies forecast wind speeds in km/h, mph and ranges.
+func TestParseNWSWindSpeed(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{in: "18 km/h", want: 5},
+		{in: "10 mph", want: 4.4704},
+		{in: "5 to 10 mph", want: 4.4704},
+		{in: "calm", want: 0},
+	}
+	for _, tc := range tests {
+		if got := parseNWSWindSpeed(tc.in); math.Abs(got-tc.want) > 0.001 {
+			t.Errorf("parseNWSWindSpeed(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
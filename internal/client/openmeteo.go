@@ -0,0 +1,288 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// openMeteoForecastDays is the forecast horizon requested from Open-Meteo, matching the 5 days
+// OpenWeatherMap provides.
+const openMeteoForecastDays = 5
+
+// openMeteoCurrent lists the current-condition variables requested from Open-Meteo.
+const openMeteoCurrent = "temperature_2m,relative_humidity_2m,apparent_temperature,weather_code,cloud_cover,pressure_msl,wind_speed_10m,wind_direction_10m,wind_gusts_10m,visibility"
+
+// OpenMeteoClient implements WeatherClient for the Open-Meteo forecast API. Names are resolved
+// with the Open-Meteo geocoding API. No API key is needed; a key for the commercial API is sent
+// as apikey when set. Open-Meteo has no official alerts, so GetOfficialAlerts returns
+// ErrAlertsUnsupported. Condition descriptions are English regardless of WithLanguage.
+type OpenMeteoClient struct {
+	upstream
+	apiKey       string
+	apiURL       string // forecast API base URL, e.g. https://api.open-meteo.com
+	geocodingURL string // geocoding API base URL, e.g. https://geocoding-api.open-meteo.com
+}
+
+// NewOpenMeteoClient creates an OpenMeteoClient. apiKey may be empty for the free API. Retry
+// settings behave as in NewOpenWeatherClientWithRetry.
+func NewOpenMeteoClient(apiKey, apiURL, geocodingURL string, timeout time.Duration, retryAttempts int, retryBaseDelay, retryMaxDelay time.Duration) (*OpenMeteoClient, error) {
+	if err := validateBaseURL("open-meteo API URL", apiURL); err != nil {
+		return nil, err
+	}
+	if err := validateBaseURL("open-meteo geocoding URL", geocodingURL); err != nil {
+		return nil, err
+	}
+	return &OpenMeteoClient{
+		upstream:     newUpstream(timeout, retryAttempts, retryBaseDelay, retryMaxDelay),
+		apiKey:       apiKey,
+		apiURL:       strings.TrimRight(apiURL, "/"),
+		geocodingURL: strings.TrimRight(geocodingURL, "/"),
+	}, nil
+}
+
+// ProviderName returns "open-meteo".
+func (c *OpenMeteoClient) ProviderName() string {
+	return "open-meteo"
+}
+
+// openMeteoForecastResponse is the JSON shape returned by the Open-Meteo forecast API with
+// timeformat=unixtime and wind speeds in m/s. Only the requested sections are present.
+type openMeteoForecastResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Current   struct {
+		Time                int64   `json:"time"`
+		Temperature         float64 `json:"temperature_2m"`
+		Humidity            float64 `json:"relative_humidity_2m"`
+		ApparentTemperature float64 `json:"apparent_temperature"`
+		WeatherCode         int     `json:"weather_code"`
+		CloudCover          float64 `json:"cloud_cover"`
+		Pressure            float64 `json:"pressure_msl"`
+		WindSpeed           float64 `json:"wind_speed_10m"`
+		WindDirection       float64 `json:"wind_direction_10m"`
+		WindGusts           float64 `json:"wind_gusts_10m"`
+		Visibility          float64 `json:"visibility"`
+	} `json:"current"`
+	Daily struct {
+		TemperatureMax []float64 `json:"temperature_2m_max"`
+		TemperatureMin []float64 `json:"temperature_2m_min"`
+		Sunrise        []int64   `json:"sunrise"`
+		Sunset         []int64   `json:"sunset"`
+	} `json:"daily"`
+	Hourly struct {
+		Time                     []int64   `json:"time"`
+		Temperature              []float64 `json:"temperature_2m"`
+		Humidity                 []float64 `json:"relative_humidity_2m"`
+		WeatherCode              []int     `json:"weather_code"`
+		WindSpeed                []float64 `json:"wind_speed_10m"`
+		PrecipitationProbability []float64 `json:"precipitation_probability"`
+	} `json:"hourly"`
+}
+
+// GetCurrentWeather retrieves current conditions for the location. Uses the same retry,
+// circuit breaker and timeout propagation as OpenWeatherClient.
+func (c *OpenMeteoClient) GetCurrentWeather(ctx context.Context, location string) (models.WeatherData, error) {
+	var data models.WeatherData
+	err := c.call(ctx, func(timeout time.Duration) error {
+		point, err := c.resolve(ctx, c.geocodingURL, location, "", timeout)
+		if err != nil {
+			return err
+		}
+		params := c.pointParams(point)
+		params.Set("current", openMeteoCurrent)
+		params.Set("daily", "temperature_2m_max,temperature_2m_min,sunrise,sunset")
+		params.Set("forecast_days", "1")
+		params.Set("timezone", "auto")
+		var apiResp openMeteoForecastResponse
+		if err := c.getJSON(ctx, c.apiURL+"/v1/forecast", params, timeout, &apiResp); err != nil {
+			return err
+		}
+		data = mapOpenMeteoCurrent(apiResp, point)
+		return nil
+	})
+	if err != nil {
+		return models.WeatherData{}, err
+	}
+	return data, nil
+}
+
+// mapOpenMeteoCurrent maps an Open-Meteo response to WeatherData. The location is the
+// geocoded name (or the coordinate key), lowercased as by OpenWeatherClient.
+func mapOpenMeteoCurrent(apiResp openMeteoForecastResponse, point geocodeResponse) models.WeatherData {
+	cur := apiResp.Current
+	fetchedAt := time.Now()
+	observedAt := fetchedAt
+	if cur.Time > 0 {
+		observedAt = time.Unix(cur.Time, 0).UTC()
+	}
+	data := models.WeatherData{
+		Location:      strings.ToLower(point.Name),
+		Country:       point.Country,
+		Coordinates:   &models.Coordinates{Lat: point.Lat, Lon: point.Lon},
+		Temperature:   cur.Temperature,
+		FeelsLike:     cur.ApparentTemperature,
+		Conditions:    wmoDescription(cur.WeatherCode),
+		Humidity:      int(math.Round(cur.Humidity)),
+		Pressure:      int(math.Round(cur.Pressure)),
+		Visibility:    int(math.Round(cur.Visibility)),
+		Cloudiness:    int(math.Round(cur.CloudCover)),
+		WindSpeed:     cur.WindSpeed,
+		WindDirection: int(math.Round(cur.WindDirection)),
+		WindGust:      cur.WindGusts,
+		Timestamp:     observedAt,
+		FetchedAt:     fetchedAt,
+	}
+	if d := apiResp.Daily; len(d.TemperatureMin) > 0 && len(d.TemperatureMax) > 0 {
+		data.TemperatureMin = d.TemperatureMin[0]
+		data.TemperatureMax = d.TemperatureMax[0]
+	}
+	if d := apiResp.Daily; len(d.Sunrise) > 0 && len(d.Sunset) > 0 {
+		data.Sunrise = unixTimeOrZero(d.Sunrise[0])
+		data.Sunset = unixTimeOrZero(d.Sunset[0])
+	}
+	return data
+}
+
+// GetForecast retrieves a 5-day forecast for the location from Open-Meteo's hourly data, keeping
+// the hours that start a 3-hour period so it matches OpenWeatherMap's forecast steps.
+func (c *OpenMeteoClient) GetForecast(ctx context.Context, location string) (models.Forecast, error) {
+	var forecast models.Forecast
+	err := c.call(ctx, func(timeout time.Duration) error {
+		point, err := c.resolve(ctx, c.geocodingURL, location, "", timeout)
+		if err != nil {
+			return err
+		}
+		params := c.pointParams(point)
+		params.Set("hourly", "temperature_2m,relative_humidity_2m,weather_code,wind_speed_10m,precipitation_probability")
+		params.Set("forecast_days", strconv.Itoa(openMeteoForecastDays))
+		var apiResp openMeteoForecastResponse
+		if err := c.getJSON(ctx, c.apiURL+"/v1/forecast", params, timeout, &apiResp); err != nil {
+			return err
+		}
+		forecast = mapOpenMeteoForecast(apiResp, point)
+		return nil
+	})
+	if err != nil {
+		return models.Forecast{}, err
+	}
+	return forecast, nil
+}
+
+// mapOpenMeteoForecast maps the hourly series to 3-hour forecast periods. Series shorter than
+// time (which Open-Meteo does not send) leave the missing values zero.
+func mapOpenMeteoForecast(apiResp openMeteoForecastResponse, point geocodeResponse) models.Forecast {
+	h := apiResp.Hourly
+	at := func(series []float64, i int) float64 {
+		if i < len(series) {
+			return series[i]
+		}
+		return 0
+	}
+	periods := make([]models.ForecastPeriod, 0, len(h.Time)/3)
+	for i, ts := range h.Time {
+		start := time.Unix(ts, 0).UTC()
+		if !forecastPeriodAligned(start) {
+			continue
+		}
+		code := 0
+		if i < len(h.WeatherCode) {
+			code = h.WeatherCode[i]
+		}
+		periods = append(periods, models.ForecastPeriod{
+			Time:                     start,
+			Temperature:              at(h.Temperature, i),
+			Conditions:               wmoDescription(code),
+			Humidity:                 int(math.Round(at(h.Humidity, i))),
+			WindSpeed:                at(h.WindSpeed, i),
+			PrecipitationProbability: at(h.PrecipitationProbability, i) / 100,
+		})
+	}
+	return models.Forecast{
+		Location:  strings.ToLower(point.Name),
+		Periods:   periods,
+		Timestamp: time.Now(),
+	}
+}
+
+// GetOfficialAlerts returns ErrAlertsUnsupported: Open-Meteo publishes no official alerts.
+func (c *OpenMeteoClient) GetOfficialAlerts(ctx context.Context, location string) ([]models.OfficialAlert, error) {
+	return nil, ErrAlertsUnsupported
+}
+
+// SearchLocations returns up to five places matching query from the Open-Meteo geocoding API,
+// with IDs as in OpenWeatherClient.SearchLocations.
+func (c *OpenMeteoClient) SearchLocations(ctx context.Context, query string) ([]models.Place, error) {
+	return c.searchLocations(ctx, c.geocodingURL, query, "")
+}
+
+// ValidateAPIKey checks that the forecast API accepts requests (and the API key, when set) with
+// a minimal request. Returns ErrInvalidAPIKey on 401 or 403. Uses a short timeout (5s) and no
+// retries, like OpenWeatherClient.ValidateAPIKey.
+func (c *OpenMeteoClient) ValidateAPIKey(ctx context.Context) error {
+	params := c.pointParams(geocodeResponse{})
+	params.Set("current", "temperature_2m")
+	var apiResp openMeteoForecastResponse
+	if err := c.getJSON(ctx, c.apiURL+"/v1/forecast", params, 5*time.Second, &apiResp); err != nil {
+		return fmt.Errorf("validation request failed: %w", err)
+	}
+	return nil
+}
+
+// pointParams returns the query parameters shared by forecast requests: the coordinates, the
+// units the models use, Unix timestamps and the API key when set.
+func (c *OpenMeteoClient) pointParams(point geocodeResponse) url.Values {
+	params := url.Values{}
+	params.Set("latitude", strconv.FormatFloat(point.Lat, 'f', -1, 64))
+	params.Set("longitude", strconv.FormatFloat(point.Lon, 'f', -1, 64))
+	params.Set("wind_speed_unit", "ms")
+	params.Set("timeformat", "unixtime")
+	if c.apiKey != "" {
+		params.Set("apikey", c.apiKey)
+	}
+	return params
+}
+
+// wmoDescriptions names the WMO weather interpretation codes Open-Meteo reports, worded like
+// OpenWeatherMap descriptions so condition rules and severity ranking treat both alike.
+var wmoDescriptions = map[int]string{
+	0:  "clear sky",
+	1:  "mainly clear",
+	2:  "partly cloudy",
+	3:  "overcast clouds",
+	45: "fog",
+	48: "depositing rime fog",
+	51: "light drizzle",
+	53: "drizzle",
+	55: "heavy drizzle",
+	56: "light freezing drizzle",
+	57: "heavy freezing drizzle",
+	61: "light rain",
+	63: "moderate rain",
+	65: "heavy rain",
+	66: "light freezing rain",
+	67: "heavy freezing rain",
+	71: "light snow",
+	73: "snow",
+	75: "heavy snow",
+	77: "snow grains",
+	80: "light shower rain",
+	81: "shower rain",
+	82: "violent shower rain",
+	85: "light shower snow",
+	86: "heavy shower snow",
+	95: "thunderstorm",
+	96: "thunderstorm with light hail",
+	99: "thunderstorm with heavy hail",
+}
+
+// wmoDescription returns the description of a WMO weather code, or "" for unknown codes.
+func wmoDescription(code int) string {
+	return wmoDescriptions[code]
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestOpenMeteoClient_GetCurrentWeather verifies the geocode and forecast requests and the
+// mapping of current conditions, daily extremes and the WMO weather code.
+func TestOpenMeteoClient_GetCurrentWeather(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/search":
+			if q.Get("name") != "chicago" || q.Get("count") != "1" {
+				t.Errorf("geocode query = %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"results":[{"name":"Chicago","admin1":"Illinois","country_code":"US","latitude":41.85,"longitude":-87.65}]}`)
+		case "/v1/forecast":
+			if q.Get("latitude") != "41.85" || q.Get("longitude") != "-87.65" || q.Get("wind_speed_unit") != "ms" || q.Get("apikey") != "customer-key" {
+				t.Errorf("forecast query = %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"latitude":41.85,"longitude":-87.65,
+				"current":{"time":1704110400,"temperature_2m":-2.5,"relative_humidity_2m":80,"apparent_temperature":-7.1,"weather_code":71,
+					"cloud_cover":100,"pressure_msl":1012.6,"wind_speed_10m":6.2,"wind_direction_10m":270,"wind_gusts_10m":11.4,"visibility":8000},
+				"daily":{"temperature_2m_max":[-1.0],"temperature_2m_min":[-6.3],"sunrise":[1704114000],"sunset":[1704148000]}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := NewOpenMeteoClient("customer-key", server.URL, server.URL, 2*time.Second, 1, time.Millisecond, time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewOpenMeteoClient() error = %v", err)
+	}
+
+	// Act
+	got, err := client.GetCurrentWeather(context.Background(), "chicago")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetCurrentWeather() error = %v", err)
+	}
+	if got.Location != "chicago" || got.Country != "US" || got.Temperature != -2.5 || got.FeelsLike != -7.1 || got.Conditions != "light snow" {
+		t.Errorf("GetCurrentWeather() = %+v", got)
+	}
+	if got.Humidity != 80 || got.Pressure != 1013 || got.WindDirection != 270 || got.TemperatureMin != -6.3 || got.TemperatureMax != -1.0 {
+		t.Errorf("GetCurrentWeather() values = %+v", got)
+	}
+	if !got.Timestamp.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) || got.Sunrise.IsZero() || got.Coordinates == nil {
+		t.Errorf("GetCurrentWeather() times/coordinates = %v, %v, %v", got.Timestamp, got.Sunrise, got.Coordinates)
+	}
+}
+
+// TestOpenMeteoClient_GetForecast verifies hourly data is reduced to 3-hour periods with
+// precipitation probability as a fraction, and coordinate keys skip geocoding.
+func TestOpenMeteoClient_GetForecast(t *testing.T) {
+	// Arrange: four hours starting at 12:00 UTC; 12:00 and 15:00 start periods
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/forecast" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"hourly":{"time":[1704110400,1704114000,1704117600,1704121200],
+			"temperature_2m":[1,2,3,4],"relative_humidity_2m":[70,71,72,73],"weather_code":[61,61,3,3],
+			"wind_speed_10m":[5,5,5,6],"precipitation_probability":[60,50,40,null]}}`)
+	}))
+	defer server.Close()
+	client, _ := NewOpenMeteoClient("", server.URL, server.URL, 2*time.Second, 1, time.Millisecond, time.Millisecond)
+
+	// Act
+	got, err := client.GetForecast(context.Background(), "41.85,-87.65")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	if len(got.Periods) != 2 {
+		t.Fatalf("periods = %+v, want 2", got.Periods)
+	}
+	first, second := got.Periods[0], got.Periods[1]
+	if first.Conditions != "light rain" || first.PrecipitationProbability != 0.6 || second.Temperature != 4 || second.PrecipitationProbability != 0 {
+		t.Errorf("periods = %+v", got.Periods)
+	}
+}
+
+// TestOpenMeteoClient_Errors verifies unknown locations and HTTP errors map to the shared
+// sentinels, and that official alerts are unsupported.
+func TestOpenMeteoClient_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		geocode  string
+		status   int
+		wantErr  error
+		attempts int
+	}{
+		{name: "location not geocoded", geocode: `{}`, status: http.StatusOK, wantErr: ErrLocationNotFound, attempts: 0},
+		{name: "invalid key", geocode: `{"results":[{"name":"x","latitude":1,"longitude":2}]}`, status: http.StatusForbidden, wantErr: ErrInvalidAPIKey, attempts: 1},
+		{name: "rate limited", geocode: `{"results":[{"name":"x","latitude":1,"longitude":2}]}`, status: http.StatusTooManyRequests, wantErr: ErrRateLimited, attempts: 2},
+		{name: "server error", geocode: `{"results":[{"name":"x","latitude":1,"longitude":2}]}`, status: http.StatusBadGateway, wantErr: ErrUpstreamFailure, attempts: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forecastCalls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v1/search" {
+					fmt.Fprint(w, tt.geocode)
+					return
+				}
+				forecastCalls++
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"error":true,"reason":"test"}`)
+			}))
+			defer server.Close()
+			client, _ := NewOpenMeteoClient("", server.URL, server.URL, 2*time.Second, 2, time.Millisecond, time.Millisecond)
+
+			_, err := client.GetCurrentWeather(context.Background(), "nowhere")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetCurrentWeather() error = %v, want %v", err, tt.wantErr)
+			}
+			if forecastCalls != tt.attempts {
+				t.Errorf("forecast calls = %d, want %d", forecastCalls, tt.attempts)
+			}
+		})
+	}
+
+	client, _ := NewOpenMeteoClient("", "https://api.open-meteo.com", "https://geocoding-api.open-meteo.com", time.Second, 1, 0, 0)
+	if _, err := client.GetOfficialAlerts(context.Background(), "chicago"); !errors.Is(err, ErrAlertsUnsupported) {
+		t.Errorf("GetOfficialAlerts() error = %v, want ErrAlertsUnsupported", err)
+	}
+}
+
+// TestOpenMeteoClient_SearchLocations verifies matches map to places keyed by coordinates.
+func TestOpenMeteoClient_SearchLocations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("count") != "5" {
+			t.Errorf("search query = %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"results":[
+			{"name":"Springfield","admin1":"Illinois","country_code":"US","latitude":39.80172,"longitude":-89.64371},
+			{"name":"Springfield","admin1":"Missouri","country_code":"US","latitude":37.21533,"longitude":-93.29824}]}`)
+	}))
+	defer server.Close()
+	client, _ := NewOpenMeteoClient("", server.URL, server.URL, 2*time.Second, 1, time.Millisecond, time.Millisecond)
+
+	got, err := client.SearchLocations(context.Background(), "springfield")
+
+	if err != nil {
+		t.Fatalf("SearchLocations() error = %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "39.80,-89.64" || got[0].State != "Illinois" || got[1].Country != "US" {
+		t.Errorf("SearchLocations() = %+v", got)
+	}
+}
+
+// TestNewOpenMeteoClient_InvalidURL verifies relative or empty base URLs are rejected.
+func TestNewOpenMeteoClient_InvalidURL(t *testing.T) {
+	if _, err := NewOpenMeteoClient("", "api.open-meteo.com", "https://geocoding-api.open-meteo.com", time.Second, 1, 0, 0); err == nil {
+		t.Error("NewOpenMeteoClient() with relative API URL: want error")
+	}
+	if _, err := NewOpenMeteoClient("", "https://api.open-meteo.com", "", time.Second, 1, 0, 0); err == nil {
+		t.Error("NewOpenMeteoClient() with empty geocoding URL: want error")
+	}
+}
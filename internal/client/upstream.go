@@ -0,0 +1,257 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/circuitbreaker"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// upstream is the HTTP, retry and circuit breaker plumbing shared by OpenMeteoClient and
+// NWSClient. It behaves like OpenWeatherClient: transient failures are retried with exponential
+// backoff, the retry loop runs inside the circuit breaker when one is set, and each request is
+// bounded by the remaining request budget.
+type upstream struct {
+	timeout        time.Duration
+	client         *http.Client
+	retryAttempts  int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+	circuitBreaker *circuitbreaker.CircuitBreaker
+	header         http.Header // sent with every request
+}
+
+func newUpstream(timeout time.Duration, retryAttempts int, retryBaseDelay, retryMaxDelay time.Duration) upstream {
+	return upstream{
+		timeout:        timeout,
+		client:         &http.Client{Timeout: timeout},
+		retryAttempts:  retryAttempts,
+		retryBaseDelay: retryBaseDelay,
+		retryMaxDelay:  retryMaxDelay,
+		header:         http.Header{"Accept": []string{"application/json"}},
+	}
+}
+
+// SetCircuitBreaker attaches an optional circuit breaker to the client. When set, every
+// upstream call runs inside the breaker.
+func (u *upstream) SetCircuitBreaker(cb *circuitbreaker.CircuitBreaker) {
+	u.circuitBreaker = cb
+}
+
+// call runs fetch with retries, inside the circuit breaker when one is set. fetch receives the
+// timeout for each request, derived from the context deadline as in OpenWeatherClient.
+func (u *upstream) call(ctx context.Context, fetch func(timeout time.Duration) error) error {
+	timeout := upstreamTimeout(ctx, u.timeout)
+	run := func() error {
+		backoff := func(attempt int) time.Duration { return backoffDelay(attempt, u.retryBaseDelay, u.retryMaxDelay) }
+		return retry(ctx, u.retryAttempts, backoff, func() error { return fetch(timeout) })
+	}
+	if u.circuitBreaker != nil {
+		if cbErr := u.circuitBreaker.Call(ctx, run); cbErr != nil {
+			return fmt.Errorf("circuit breaker: %w", cbErr)
+		}
+		return nil
+	}
+	return run()
+}
+
+// getJSON executes a single GET of rawURL with params, decoding the JSON response into out.
+// Records the same upstream metrics as OpenWeatherClient and maps HTTP errors with
+// statusError. timeout bounds this single request.
+func (u *upstream) getJSON(ctx context.Context, rawURL string, params url.Values, timeout time.Duration, out interface{}) error {
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := u.newRequest(reqCtx, rawURL, params)
+	if err != nil {
+		observability.WeatherAPICallsTotal.WithLabelValues("error").Inc()
+		observability.WeatherAPIErrorsTotal.WithLabelValues(string(CategorizeError(err))).Inc()
+		return fmt.Errorf("build request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := u.client.Do(req)
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		observability.WeatherAPICallsTotal.WithLabelValues("error").Inc()
+		observability.WeatherAPIDuration.WithLabelValues("error").Observe(duration)
+		observability.WeatherAPIErrorsTotal.WithLabelValues(string(CategorizeError(err))).Inc()
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return fmt.Errorf("request timeout: %w", err)
+		}
+		return fmt.Errorf("http request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	status := statusLabel(resp.StatusCode)
+	observability.WeatherAPICallsTotal.WithLabelValues(status).Inc()
+	observability.WeatherAPIDuration.WithLabelValues(status).Observe(time.Since(start).Seconds())
+
+	if err := statusError(resp); err != nil {
+		observability.WeatherAPIErrorsTotal.WithLabelValues(string(CategorizeError(err))).Inc()
+		return err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		observability.WeatherAPICallsTotal.WithLabelValues("error").Inc()
+		observability.WeatherAPIErrorsTotal.WithLabelValues(string(CategorizeError(err))).Inc()
+		return fmt.Errorf("read response body: %w", err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		observability.WeatherAPICallsTotal.WithLabelValues("error").Inc()
+		observability.WeatherAPIErrorsTotal.WithLabelValues(string(CategorizeError(err))).Inc()
+		return fmt.Errorf("parse response: %w", err)
+	}
+	return nil
+}
+
+// newRequest builds a GET of rawURL with params added to its query, the shared headers and the
+// correlation ID from ctx.
+func (u *upstream) newRequest(ctx context.Context, rawURL string, params url.Values) (*http.Request, error) {
+	endpoint, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API URL: %w", err)
+	}
+	if len(params) > 0 {
+		query := endpoint.Query()
+		for k, v := range params {
+			query[k] = v
+		}
+		endpoint.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	for k, v := range u.header {
+		req.Header[k] = v
+	}
+	if corrID := extractCorrelationID(ctx); corrID != "" {
+		req.Header.Set("X-Correlation-ID", corrID)
+	}
+	return req, nil
+}
+
+// statusError maps HTTP status codes to domain errors for the keyless providers. Like
+// handleErrorResponse, except 403 is ErrInvalidAPIKey too: Open-Meteo answers a bad
+// commercial key with 403, and NWS rejects requests without an identifying User-Agent with it.
+func statusError(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: HTTP %d", ErrInvalidAPIKey, resp.StatusCode)
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w", ErrLocationNotFound)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		info := parseRateLimitHeaders(resp)
+		if info.retryAfter > 0 {
+			observability.UpstreamRateLimitHeadersParsedTotal.Inc()
+			observability.UpstreamRateLimitRetryAfterSeconds.Observe(info.retryAfter.Seconds())
+		}
+		return &rateLimitedError{err: fmt.Errorf("%w", ErrRateLimited), retryAfter: info.retryAfter, resetAt: info.resetAt}
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("%w: HTTP %d", ErrUpstreamFailure, resp.StatusCode)
+	}
+	return nil
+}
+
+// validateBaseURL checks that rawURL is an absolute http(s) URL.
+func validateBaseURL(name, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s must be an absolute http(s) URL, got %q", name, rawURL)
+	}
+	return nil
+}
+
+// openMeteoSearchResponse is the JSON shape returned by the Open-Meteo geocoding API. results is
+// absent when nothing matches.
+type openMeteoSearchResponse struct {
+	Results []struct {
+		Name        string  `json:"name"`
+		Admin1      string  `json:"admin1"`
+		CountryCode string  `json:"country_code"`
+		Latitude    float64 `json:"latitude"`
+		Longitude   float64 `json:"longitude"`
+	} `json:"results"`
+}
+
+// searchPlaces queries the Open-Meteo geocoding API at geocodingURL for up to count matches,
+// optionally restricted to an ISO 3166-1 alpha-2 country. Both keyless providers use it: NWS has
+// no geocoding of its own.
+func (u *upstream) searchPlaces(ctx context.Context, geocodingURL, query, country string, count int, timeout time.Duration) ([]geocodeResponse, error) {
+	params := url.Values{}
+	params.Set("name", query)
+	params.Set("count", strconv.Itoa(count))
+	params.Set("format", "json")
+	if country != "" {
+		params.Set("countryCode", country)
+	}
+	var apiResp openMeteoSearchResponse
+	if err := u.getJSON(ctx, geocodingURL+"/v1/search", params, timeout, &apiResp); err != nil {
+		return nil, err
+	}
+	matches := make([]geocodeResponse, 0, len(apiResp.Results))
+	for _, r := range apiResp.Results {
+		matches = append(matches, geocodeResponse{
+			Name:    r.Name,
+			State:   r.Admin1,
+			Country: r.CountryCode,
+			Lat:     r.Latitude,
+			Lon:     r.Longitude,
+		})
+	}
+	return matches, nil
+}
+
+// resolve turns a location into coordinates like OpenWeatherClient.geocode: coordinate keys are
+// returned as-is, names are looked up with searchPlaces and return ErrLocationNotFound when
+// nothing matches.
+func (u *upstream) resolve(ctx context.Context, geocodingURL, location, country string, timeout time.Duration) (geocodeResponse, error) {
+	if lat, lon, ok := validation.ParseCoordinateKey(location); ok {
+		return geocodeResponse{Name: location, Lat: lat, Lon: lon}, nil
+	}
+	matches, err := u.searchPlaces(ctx, geocodingURL, location, country, 1, timeout)
+	if err != nil {
+		return geocodeResponse{}, err
+	}
+	if len(matches) == 0 {
+		return geocodeResponse{}, fmt.Errorf("%w", ErrLocationNotFound)
+	}
+	return matches[0], nil
+}
+
+// searchLocations implements SearchLocations for the keyless providers.
+func (u *upstream) searchLocations(ctx context.Context, geocodingURL, query, country string) ([]models.Place, error) {
+	var places []models.Place
+	err := u.call(ctx, func(timeout time.Duration) error {
+		matches, err := u.searchPlaces(ctx, geocodingURL, query, country, searchLimit, timeout)
+		if err != nil {
+			return err
+		}
+		places = mapPlaces(matches)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return places, nil
+}
+
+// forecastPeriodAligned reports whether t starts one of the 3-hour forecast periods
+// (00:00, 03:00, ... UTC) that the service trims forecasts by.
+func forecastPeriodAligned(t time.Time) bool {
+	t = t.UTC()
+	return t.Hour()%3 == 0 && t.Minute() == 0 && t.Second() == 0
+}
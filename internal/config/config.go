@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -17,14 +18,21 @@ type Config struct {
 
 	ServerPort string
 
-	WeatherAPIKey    string
+	WeatherProvider  string // "openweathermap", "open-meteo" or "nws"
+	WeatherAPIKey    string // required for openweathermap; optional for open-meteo (commercial API)
 	WeatherAPIURL    string
 	WeatherAPITimeout time.Duration
+	GeocodingURL     string // Open-Meteo geocoding API, used by the open-meteo and nws providers
+	WeatherUserAgent string // User-Agent sent to the nws provider
 
 	RequestTimeout time.Duration
 	CacheTTL       time.Duration
 	CacheBackend   string // "in_memory" or "memcached"
 	StaleCacheTTL  time.Duration // Maximum age for stale cache fallback
+	OfficialAlertsTTL time.Duration // Cache TTL for official alerts from the upstream provider
+	ForecastTTL     time.Duration // Cache TTL for forecasts
+	SearchTTL       time.Duration // Cache TTL for location search results
+	AirQualityTTL   time.Duration // Cache TTL for air quality
 	CoalesceEnabled bool
 	CoalesceTimeout time.Duration // Maximum wait time for coalesced request
 
@@ -57,6 +65,8 @@ type Config struct {
 
 	LocationMaxLength int
 	LocationMinLength int
+	BatchMaxLocations int // locations per POST /weather/batch; at most RateLimitBurst
+	BatchConcurrency  int
 
 	WarmCache    bool
 	WarmInterval time.Duration
@@ -65,6 +75,95 @@ type Config struct {
 	CircuitBreakerFailureThreshold int
 	CircuitBreakerSuccessThreshold int
 	CircuitBreakerTimeout    time.Duration
+
+	AlertRules             []AlertRule
+	AlertResolvedRetention time.Duration
+	AlertStaleAfter        time.Duration // drop alert state not re-evaluated within this window
+
+	SubscriptionsEnabled     bool
+	SubscriptionPollInterval time.Duration
+	SubscriptionMaxCount     int
+	WebhookTimeout           time.Duration
+	WebhookMaxAttempts       int
+	WebhookBaseDelay         time.Duration
+	WebhookMaxDelay          time.Duration
+	WebhookWorkers           int
+	WebhookQueueSize         int
+	WebhookDeadLetterSize    int
+	WebhookAllowedHosts      []string
+
+	StreamEnabled         bool
+	StreamMaxStreams      int
+	StreamKeepAlive       time.Duration
+	StreamReplayBuffer    int
+	StreamRefreshInterval time.Duration
+
+	ChangesEnabled          bool
+	ChangesTemperatureDelta float64
+	ChangesWindDelta        float64
+	ChangesMaxEvents        int
+	ChangesMaxLocations     int
+
+	HistoryEnabled        bool
+	HistoryFile           string
+	HistoryRetention      time.Duration
+	HistoryMaxPerLocation int
+	HistoryMaxLocations   int
+	HistoryPruneInterval  time.Duration
+	HistoryTrendWindow    time.Duration
+
+	HealthNotifyEnabled  bool
+	HealthNotifyInterval time.Duration
+	HealthNotifyDebounce time.Duration
+	HealthNotifyTimeout  time.Duration
+	HealthNotifyWebhooks []string
+	SMTPAddr             string
+	SMTPFrom             string
+	SMTPTo               []string
+	SMTPUsername         string
+	SMTPPassword         string
+
+	ReportJobs        []ReportJob
+	ReportLocation    *time.Location // time zone report schedules are evaluated in
+	ReportTimeout     time.Duration
+	ReportMaxAttempts int
+	ReportRetryDelay  time.Duration
+	ReportConcurrency int // locations of one report run fetched at a time
+
+	WatchlistFile         string
+	WatchlistMaxLocations int
+	AdminAPIToken         string
+
+	GroupsFile       string
+	GroupsMaxMembers int // locations per group; at most RateLimitBurst
+	Groups           map[string][]string // seed definitions, used until GroupsFile exists
+}
+
+// AlertRule is a threshold rule from the alerts section. Field use depends on Type:
+// Threshold for temperature_above/temperature_below/wind_above, Min/Max for humidity_range,
+// Pattern for conditions_match. Validated when the alert engine is built.
+type AlertRule struct {
+	Name      string   `yaml:"name"`
+	Type      string   `yaml:"type"`
+	Severity  string   `yaml:"severity"`
+	Threshold float64  `yaml:"threshold"`
+	Min       float64  `yaml:"min"`
+	Max       float64  `yaml:"max"`
+	Pattern   string   `yaml:"pattern"`
+	Locations []string `yaml:"locations"`
+}
+
+// ReportJob is a scheduled digest from the reports section. Schedule is a five-field cron
+// expression; Template (inline) or TemplateFile is a text/template; exactly one of Webhook or
+// File is the delivery target. Validated when the report scheduler is built.
+type ReportJob struct {
+	Name         string   `yaml:"name"`
+	Schedule     string   `yaml:"schedule"`
+	Locations    []string `yaml:"locations"`
+	Template     string   `yaml:"template"`
+	TemplateFile string   `yaml:"template_file"`
+	Webhook      string   `yaml:"webhook"`
+	File         string   `yaml:"file"`
 }
 
 type fileConfig struct {
@@ -75,19 +174,28 @@ type fileConfig struct {
 	} `yaml:"server"`
 
 	WeatherAPI struct {
-		URL     string `yaml:"url"`
-		Timeout string `yaml:"timeout"`
+		Provider     string `yaml:"provider"`
+		URL          string `yaml:"url"`
+		Timeout      string `yaml:"timeout"`
+		GeocodingURL string `yaml:"geocoding_url"`
+		UserAgent    string `yaml:"user_agent"`
 	} `yaml:"weather_api"`
 
 	Request struct {
 		Timeout           string `yaml:"timeout"`
 		LocationMaxLength int    `yaml:"location_max_length"`
 		LocationMinLength int    `yaml:"location_min_length"`
+		BatchMaxLocations int    `yaml:"batch_max_locations"`
+		BatchConcurrency  int    `yaml:"batch_concurrency"`
 	} `yaml:"request"`
 
 	Cache struct {
 		Backend      string `yaml:"backend"`
 		TTL          string `yaml:"ttl"`
+		AlertsTTL    string `yaml:"alerts_ttl"`
+		ForecastTTL  string `yaml:"forecast_ttl"`
+		SearchTTL    string `yaml:"search_ttl"`
+		AirQualityTTL string `yaml:"air_quality_ttl"`
 		WarmCache    *bool  `yaml:"warm_cache"`
 		WarmInterval string `yaml:"warm_interval"`
 		StaleCache   struct {
@@ -142,10 +250,94 @@ type fileConfig struct {
 		SuccessThreshold int    `yaml:"success_threshold"`
 		Timeout          string `yaml:"timeout"`
 	} `yaml:"circuit_breaker"`
+
+	Alerts struct {
+		ResolvedRetention string      `yaml:"resolved_retention"`
+		StaleAfter        string      `yaml:"stale_after"`
+		Rules             []AlertRule `yaml:"rules"`
+	} `yaml:"alerts"`
+
+	Subscriptions struct {
+		Enabled          bool   `yaml:"enabled"`
+		PollInterval     string `yaml:"poll_interval"`
+		MaxSubscriptions int    `yaml:"max_subscriptions"`
+		Delivery         struct {
+			Timeout        string   `yaml:"timeout"`
+			MaxAttempts    int      `yaml:"max_attempts"`
+			BaseDelay      string   `yaml:"base_delay"`
+			MaxDelay       string   `yaml:"max_delay"`
+			Workers        int      `yaml:"workers"`
+			QueueSize      int      `yaml:"queue_size"`
+			DeadLetterSize int      `yaml:"dead_letter_size"`
+			AllowedHosts   []string `yaml:"allowed_hosts"`
+		} `yaml:"delivery"`
+	} `yaml:"subscriptions"`
+
+	Stream struct {
+		Enabled           bool   `yaml:"enabled"`
+		MaxStreams        int    `yaml:"max_streams"`
+		KeepaliveInterval string `yaml:"keepalive_interval"`
+		ReplayBuffer      int    `yaml:"replay_buffer"`
+		RefreshInterval   string `yaml:"refresh_interval"`
+	} `yaml:"stream"`
+
+	Changes struct {
+		Enabled              bool    `yaml:"enabled"`
+		TemperatureDelta     float64 `yaml:"temperature_delta"`
+		WindDelta            float64 `yaml:"wind_delta"`
+		MaxEventsPerLocation int     `yaml:"max_events_per_location"`
+		MaxLocations         int     `yaml:"max_locations"`
+	} `yaml:"changes"`
+
+	History struct {
+		Enabled        bool   `yaml:"enabled"`
+		File           string `yaml:"file"`
+		Retention      string `yaml:"retention"`
+		MaxPerLocation int    `yaml:"max_per_location"`
+		MaxLocations   int    `yaml:"max_locations"`
+		PruneInterval  string `yaml:"prune_interval"`
+		TrendWindow    string `yaml:"trend_window"`
+	} `yaml:"history"`
+
+	HealthNotifications struct {
+		Enabled  bool     `yaml:"enabled"`
+		Interval string   `yaml:"interval"`
+		Debounce string   `yaml:"debounce"`
+		Timeout  string   `yaml:"timeout"`
+		Webhooks []string `yaml:"webhooks"`
+		SMTP     struct {
+			Addr     string   `yaml:"addr"`
+			From     string   `yaml:"from"`
+			To       []string `yaml:"to"`
+			Username string   `yaml:"username"`
+		} `yaml:"smtp"`
+	} `yaml:"health_notifications"`
+
+	Reports struct {
+		Timezone    string      `yaml:"timezone"`
+		Timeout     string      `yaml:"timeout"`
+		MaxAttempts int         `yaml:"max_attempts"`
+		RetryDelay  string      `yaml:"retry_delay"`
+		Concurrency int         `yaml:"concurrency"`
+		Jobs        []ReportJob `yaml:"jobs"`
+	} `yaml:"reports"`
+
+	Watchlist struct {
+		File         string `yaml:"file"`
+		MaxLocations int    `yaml:"max_locations"`
+	} `yaml:"watchlist"`
+
+	Groups struct {
+		File        string              `yaml:"file"`
+		MaxMembers  int                 `yaml:"max_members"`
+		Definitions map[string][]string `yaml:"definitions"`
+	} `yaml:"groups"`
 }
 
 type secretsFile struct {
 	WeatherAPIKey string `yaml:"weather_api_key"`
+	AdminAPIToken string `yaml:"admin_api_token"`
+	SMTPPassword  string `yaml:"smtp_password"`
 }
 
 // Load reads configuration from config/{ENV_NAME}.yaml (default dev) and config/secrets.yaml.
@@ -188,28 +380,33 @@ func Load() (*Config, error) {
 
 	cfg.WeatherAPIKey = os.Getenv("WEATHER_API_KEY")
 	if cfg.WeatherAPIKey == "" {
-		secretsPath := filepath.Join(cwd, "config", "secrets.yaml")
-		secretsData, err := os.ReadFile(secretsPath)
+		sec, err := readSecretsFile(cwd)
 		if err != nil {
-			if !os.IsNotExist(err) {
-				return nil, fmt.Errorf("read secrets file: %w", err)
-			}
-		} else {
-			var sec secretsFile
-			if err := yaml.Unmarshal(secretsData, &sec); err != nil {
-				return nil, fmt.Errorf("parse secrets file: %w", err)
-			}
-			cfg.WeatherAPIKey = sec.WeatherAPIKey
+			return nil, err
 		}
+		cfg.WeatherAPIKey = sec.WeatherAPIKey
 	}
-	if cfg.WeatherAPIKey == "" {
+	cfg.WeatherProvider = strings.TrimSpace(strings.ToLower(fc.WeatherAPI.Provider))
+	if cfg.WeatherProvider == "" {
+		cfg.WeatherProvider = "openweathermap"
+	}
+	defaultURL, ok := defaultWeatherAPIURLs[cfg.WeatherProvider]
+	if !ok {
+		return nil, fmt.Errorf("weather_api.provider must be openweathermap, open-meteo or nws, got %q", cfg.WeatherProvider)
+	}
+	if cfg.WeatherAPIKey == "" && cfg.WeatherProvider == "openweathermap" {
 		return nil, fmt.Errorf("WEATHER_API_KEY required (set env or config/secrets.yaml weather_api_key)")
 	}
 
 	cfg.WeatherAPIURL = fc.WeatherAPI.URL
 	if cfg.WeatherAPIURL == "" {
-		cfg.WeatherAPIURL = "https://api.openweathermap.org/data/2.5/weather"
+		cfg.WeatherAPIURL = defaultURL
+	}
+	cfg.GeocodingURL = fc.WeatherAPI.GeocodingURL
+	if cfg.GeocodingURL == "" {
+		cfg.GeocodingURL = "https://geocoding-api.open-meteo.com"
 	}
+	cfg.WeatherUserAgent = fc.WeatherAPI.UserAgent
 	cfg.WeatherAPITimeout = parseDurationOrZero(fc.WeatherAPI.Timeout, 2*time.Second)
 
 	cfg.RequestTimeout = parseDuration(fc.Request.Timeout, 5*time.Second)
@@ -220,6 +417,13 @@ func Load() (*Config, error) {
 	if cfg.CacheTTL <= 0 {
 		cfg.CacheTTL = 5 * time.Minute
 	}
+	cfg.OfficialAlertsTTL = parseDuration(fc.Cache.AlertsTTL, 10*time.Minute)
+	if cfg.OfficialAlertsTTL <= 0 {
+		cfg.OfficialAlertsTTL = 10 * time.Minute
+	}
+	cfg.ForecastTTL = parseDuration(fc.Cache.ForecastTTL, 30*time.Minute)
+	cfg.SearchTTL = parseDuration(fc.Cache.SearchTTL, 24*time.Hour)
+	cfg.AirQualityTTL = parseDuration(fc.Cache.AirQualityTTL, 15*time.Minute)
 	cfg.CacheBackend = strings.TrimSpace(strings.ToLower(os.Getenv("CACHE_BACKEND")))
 	if cfg.CacheBackend == "" {
 		cfg.CacheBackend = strings.TrimSpace(strings.ToLower(fc.Cache.Backend))
@@ -338,6 +542,16 @@ func Load() (*Config, error) {
 			cfg.LocationMinLength = n
 		}
 	}
+	// A batch takes one rate-limit token per location, so the default is capped at the burst;
+	// a larger explicit value could never be admitted and fails validation.
+	cfg.BatchMaxLocations = fc.Request.BatchMaxLocations
+	if cfg.BatchMaxLocations <= 0 {
+		cfg.BatchMaxLocations = min(50, cfg.RateLimitBurst)
+	}
+	cfg.BatchConcurrency = fc.Request.BatchConcurrency
+	if cfg.BatchConcurrency <= 0 {
+		cfg.BatchConcurrency = 8
+	}
 
 	cfg.WarmCache = false
 	if fc.Cache.WarmCache != nil {
@@ -370,12 +584,180 @@ func Load() (*Config, error) {
 		cfg.CircuitBreakerTimeout = 30 * time.Second
 	}
 
+	cfg.AlertRules = fc.Alerts.Rules
+	cfg.AlertResolvedRetention = parseDuration(fc.Alerts.ResolvedRetention, 24*time.Hour)
+	cfg.AlertStaleAfter = parseDuration(fc.Alerts.StaleAfter, 6*time.Hour)
+
+	sub := fc.Subscriptions
+	cfg.SubscriptionsEnabled = sub.Enabled
+	cfg.SubscriptionPollInterval = parseDuration(sub.PollInterval, 5*time.Minute)
+	cfg.SubscriptionMaxCount = sub.MaxSubscriptions
+	if cfg.SubscriptionMaxCount <= 0 {
+		cfg.SubscriptionMaxCount = 100
+	}
+	cfg.WebhookTimeout = parseDuration(sub.Delivery.Timeout, 5*time.Second)
+	cfg.WebhookMaxAttempts = sub.Delivery.MaxAttempts
+	if cfg.WebhookMaxAttempts <= 0 {
+		cfg.WebhookMaxAttempts = 4
+	}
+	cfg.WebhookBaseDelay = parseDuration(sub.Delivery.BaseDelay, time.Second)
+	cfg.WebhookMaxDelay = parseDuration(sub.Delivery.MaxDelay, 30*time.Second)
+	cfg.WebhookWorkers = sub.Delivery.Workers
+	if cfg.WebhookWorkers <= 0 {
+		cfg.WebhookWorkers = 4
+	}
+	cfg.WebhookQueueSize = sub.Delivery.QueueSize
+	if cfg.WebhookQueueSize <= 0 {
+		cfg.WebhookQueueSize = 1000
+	}
+	cfg.WebhookDeadLetterSize = sub.Delivery.DeadLetterSize
+	if cfg.WebhookDeadLetterSize <= 0 {
+		cfg.WebhookDeadLetterSize = 100
+	}
+	cfg.WebhookAllowedHosts = sub.Delivery.AllowedHosts
+
+	cfg.StreamEnabled = fc.Stream.Enabled
+	cfg.StreamMaxStreams = fc.Stream.MaxStreams
+	if cfg.StreamMaxStreams <= 0 {
+		cfg.StreamMaxStreams = 100
+	}
+	cfg.StreamKeepAlive = parseDuration(fc.Stream.KeepaliveInterval, 15*time.Second)
+	cfg.StreamReplayBuffer = fc.Stream.ReplayBuffer
+	if cfg.StreamReplayBuffer <= 0 {
+		cfg.StreamReplayBuffer = 50
+	}
+	// Default to the cache TTL so each refresh finds an expired entry; "0" disables refresh
+	// and streams then only see fetches triggered by other traffic or the warmer.
+	cfg.StreamRefreshInterval = parseDurationOrZero(fc.Stream.RefreshInterval, cfg.CacheTTL)
+
+	cfg.ChangesEnabled = fc.Changes.Enabled
+	cfg.ChangesTemperatureDelta = fc.Changes.TemperatureDelta
+	if cfg.ChangesTemperatureDelta <= 0 {
+		cfg.ChangesTemperatureDelta = 3
+	}
+	cfg.ChangesWindDelta = fc.Changes.WindDelta
+	if cfg.ChangesWindDelta <= 0 {
+		cfg.ChangesWindDelta = 5
+	}
+	cfg.ChangesMaxEvents = fc.Changes.MaxEventsPerLocation
+	if cfg.ChangesMaxEvents <= 0 {
+		cfg.ChangesMaxEvents = 100
+	}
+	cfg.ChangesMaxLocations = fc.Changes.MaxLocations
+	if cfg.ChangesMaxLocations <= 0 {
+		cfg.ChangesMaxLocations = 1000
+	}
+
+	cfg.HistoryEnabled = fc.History.Enabled
+	cfg.HistoryFile = strings.TrimSpace(fc.History.File)
+	if cfg.HistoryFile == "" {
+		cfg.HistoryFile = filepath.Join("data", "history.db")
+	}
+	cfg.HistoryRetention = parseDuration(fc.History.Retention, 7*24*time.Hour)
+	cfg.HistoryMaxPerLocation = fc.History.MaxPerLocation
+	if cfg.HistoryMaxPerLocation <= 0 {
+		cfg.HistoryMaxPerLocation = 2016
+	}
+	cfg.HistoryMaxLocations = fc.History.MaxLocations
+	if cfg.HistoryMaxLocations <= 0 {
+		cfg.HistoryMaxLocations = 500
+	}
+	cfg.HistoryPruneInterval = parseDuration(fc.History.PruneInterval, time.Hour)
+	cfg.HistoryTrendWindow = parseDuration(fc.History.TrendWindow, 3*time.Hour)
+
+	hn := fc.HealthNotifications
+	cfg.HealthNotifyEnabled = hn.Enabled
+	cfg.HealthNotifyInterval = parseDuration(hn.Interval, 30*time.Second)
+	// "0" notifies every transition immediately; the default suppresses flaps shorter than 1m.
+	cfg.HealthNotifyDebounce = parseDurationOrZero(hn.Debounce, time.Minute)
+	if cfg.HealthNotifyDebounce < 0 {
+		cfg.HealthNotifyDebounce = time.Minute
+	}
+	cfg.HealthNotifyTimeout = parseDuration(hn.Timeout, 10*time.Second)
+	cfg.HealthNotifyWebhooks = hn.Webhooks
+	cfg.SMTPAddr = strings.TrimSpace(hn.SMTP.Addr)
+	cfg.SMTPFrom = strings.TrimSpace(hn.SMTP.From)
+	cfg.SMTPTo = hn.SMTP.To
+	cfg.SMTPUsername = hn.SMTP.Username
+	if cfg.HealthNotifyEnabled && cfg.SMTPUsername != "" {
+		cfg.SMTPPassword = os.Getenv("SMTP_PASSWORD")
+		if cfg.SMTPPassword == "" {
+			sec, err := readSecretsFile(cwd)
+			if err != nil {
+				return nil, err
+			}
+			cfg.SMTPPassword = sec.SMTPPassword
+		}
+	}
+
+	cfg.ReportJobs = fc.Reports.Jobs
+	cfg.ReportLocation = time.UTC
+	if tz := strings.TrimSpace(fc.Reports.Timezone); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("reports.timezone: %w", err)
+		}
+		cfg.ReportLocation = loc
+	}
+	cfg.ReportTimeout = parseDuration(fc.Reports.Timeout, 30*time.Second)
+	cfg.ReportMaxAttempts = fc.Reports.MaxAttempts
+	if cfg.ReportMaxAttempts <= 0 {
+		cfg.ReportMaxAttempts = 3
+	}
+	cfg.ReportRetryDelay = parseDuration(fc.Reports.RetryDelay, 30*time.Second)
+	cfg.ReportConcurrency = fc.Reports.Concurrency
+	if cfg.ReportConcurrency <= 0 {
+		cfg.ReportConcurrency = 8
+	}
+
+	cfg.WatchlistFile = strings.TrimSpace(fc.Watchlist.File)
+	if cfg.WatchlistFile == "" {
+		cfg.WatchlistFile = filepath.Join("data", "watchlist.json")
+	}
+	cfg.WatchlistMaxLocations = fc.Watchlist.MaxLocations
+	if cfg.WatchlistMaxLocations <= 0 {
+		cfg.WatchlistMaxLocations = 200
+	}
+	cfg.GroupsFile = strings.TrimSpace(fc.Groups.File)
+	if cfg.GroupsFile == "" {
+		cfg.GroupsFile = filepath.Join("data", "groups.json")
+	}
+	cfg.GroupsMaxMembers = fc.Groups.MaxMembers
+	if cfg.GroupsMaxMembers <= 0 {
+		cfg.GroupsMaxMembers = min(50, cfg.RateLimitBurst)
+	}
+	cfg.Groups = fc.Groups.Definitions
+	cfg.AdminAPIToken = os.Getenv("ADMIN_API_TOKEN")
+	if cfg.AdminAPIToken == "" {
+		sec, err := readSecretsFile(cwd)
+		if err != nil {
+			return nil, err
+		}
+		cfg.AdminAPIToken = sec.AdminAPIToken
+	}
+
 	if err := validate(cfg); err != nil {
 		return nil, err
 	}
 	return cfg, nil
 }
 
+// readSecretsFile reads config/secrets.yaml under cwd. A missing file yields empty secrets.
+func readSecretsFile(cwd string) (secretsFile, error) {
+	var sec secretsFile
+	secretsData, err := os.ReadFile(filepath.Join(cwd, "config", "secrets.yaml"))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return sec, fmt.Errorf("read secrets file: %w", err)
+		}
+		return sec, nil
+	}
+	if err := yaml.Unmarshal(secretsData, &sec); err != nil {
+		return sec, fmt.Errorf("parse secrets file: %w", err)
+	}
+	return sec, nil
+}
+
 // parseDuration parses a duration string and returns defaultVal if parsing fails or result is <= 0.
 // Used for parsing duration fields from YAML config with safe fallback to defaults.
 func parseDuration(s string, defaultVal time.Duration) time.Duration {
@@ -400,6 +782,22 @@ func parseDurationOrZero(s string, defaultVal time.Duration) time.Duration {
 	return d
 }
 
+// defaultWeatherAPIURLs is the weather_api.url default for each weather_api.provider.
+var defaultWeatherAPIURLs = map[string]string{
+	"openweathermap": "https://api.openweathermap.org/data/2.5/weather",
+	"open-meteo":     "https://api.open-meteo.com",
+	"nws":            "https://api.weather.gov",
+}
+
+// urlHost returns the lowercased host of rawURL, or "" when it does not parse.
+func urlHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
 // validate performs post-load validation of configuration values.
 // Ensures WeatherAPITimeout is positive, RequestTimeout >= WeatherAPITimeout,
 // and CacheBackend is a valid value. Auto-adjusts RequestTimeout if needed.
@@ -416,5 +814,24 @@ func validate(cfg *Config) error {
 	default:
 		return fmt.Errorf("cache.backend must be in_memory or memcached, got %q", cfg.CacheBackend)
 	}
+	for provider, defaultURL := range defaultWeatherAPIURLs {
+		if provider != cfg.WeatherProvider && urlHost(cfg.WeatherAPIURL) == urlHost(defaultURL) {
+			return fmt.Errorf("weather_api.url %q is the %s API but weather_api.provider is %q; remove weather_api.url to use the provider default", cfg.WeatherAPIURL, provider, cfg.WeatherProvider)
+		}
+	}
+	if cfg.BatchMaxLocations > cfg.RateLimitBurst {
+		return fmt.Errorf("request.batch_max_locations (%d) must not exceed reliability.rate_limit_burst (%d)", cfg.BatchMaxLocations, cfg.RateLimitBurst)
+	}
+	if cfg.GroupsMaxMembers > cfg.RateLimitBurst {
+		return fmt.Errorf("groups.max_members (%d) must not exceed reliability.rate_limit_burst (%d)", cfg.GroupsMaxMembers, cfg.RateLimitBurst)
+	}
+	if cfg.HealthNotifyEnabled {
+		if len(cfg.HealthNotifyWebhooks) == 0 && cfg.SMTPAddr == "" {
+			return fmt.Errorf("health_notifications.enabled requires at least one webhook or smtp.addr")
+		}
+		if cfg.SMTPAddr != "" && (cfg.SMTPFrom == "" || len(cfg.SMTPTo) == 0) {
+			return fmt.Errorf("health_notifications.smtp requires from and to when addr is set")
+		}
+	}
 	return nil
 }
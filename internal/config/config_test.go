@@ -144,6 +144,18 @@ shutdown:
 	if cfg.WeatherAPITimeout <= 0 {
 		t.Error("Load() with empty duration should fall back to default (2s for weather_api.timeout)")
 	}
+	if cfg.OfficialAlertsTTL != 10*time.Minute {
+		t.Errorf("OfficialAlertsTTL = %v, want default 10m", cfg.OfficialAlertsTTL)
+	}
+	if cfg.ForecastTTL != 30*time.Minute {
+		t.Errorf("ForecastTTL = %v, want default 30m", cfg.ForecastTTL)
+	}
+	if cfg.SearchTTL != 24*time.Hour {
+		t.Errorf("SearchTTL = %v, want default 24h", cfg.SearchTTL)
+	}
+	if cfg.AirQualityTTL != 15*time.Minute {
+		t.Errorf("AirQualityTTL = %v, want default 15m", cfg.AirQualityTTL)
+	}
 }
 
 // TestLoad_InvalidDurationFallsBackToDefault verifies that Load uses default
@@ -459,6 +471,407 @@ func TestLoad_TestingModeTrue(t *testing.T) {
 	}
 }
 
+// TestLoad_AlertsConfig verifies that Load parses the alerts section, including per-type rule
+// fields, resolved_retention and stale_after, and defaults both when omitted.
+func TestLoad_AlertsConfig(t *testing.T) {
+	savedKey := os.Getenv("WEATHER_API_KEY")
+	os.Setenv("WEATHER_API_KEY", "test-key")
+	defer func() {
+		if savedKey != "" {
+			os.Setenv("WEATHER_API_KEY", savedKey)
+		} else {
+			os.Unsetenv("WEATHER_API_KEY")
+		}
+	}()
+
+	alertsYAML := minimalEnvYAML + `
+alerts:
+  resolved_retention: "6h"
+  stale_after: "2h"
+  rules:
+    - name: heat
+      type: temperature_above
+      threshold: 35
+      severity: critical
+      locations: [phoenix]
+    - name: muggy
+      type: humidity_range
+      min: 80
+      max: 100
+    - name: snow
+      type: conditions_match
+      pattern: "(?i)snow"
+`
+	origWd, _ := os.Getwd()
+	dir := t.TempDir()
+	writeEnvFile(t, dir, alertsYAML)
+	os.Chdir(dir)
+	defer os.Chdir(origWd)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.AlertResolvedRetention != 6*time.Hour || cfg.AlertStaleAfter != 2*time.Hour {
+		t.Errorf("AlertResolvedRetention, AlertStaleAfter = %v, %v, want 6h, 2h", cfg.AlertResolvedRetention, cfg.AlertStaleAfter)
+	}
+	if len(cfg.AlertRules) != 3 {
+		t.Fatalf("len(AlertRules) = %d, want 3", len(cfg.AlertRules))
+	}
+	heat := cfg.AlertRules[0]
+	if heat.Name != "heat" || heat.Type != "temperature_above" || heat.Threshold != 35 || heat.Severity != "critical" || len(heat.Locations) != 1 {
+		t.Errorf("AlertRules[0] = %+v, want heat rule", heat)
+	}
+	if cfg.AlertRules[1].Min != 80 || cfg.AlertRules[1].Max != 100 {
+		t.Errorf("AlertRules[1] = %+v, want min 80 max 100", cfg.AlertRules[1])
+	}
+	if cfg.AlertRules[2].Pattern != "(?i)snow" {
+		t.Errorf("AlertRules[2].Pattern = %q, want (?i)snow", cfg.AlertRules[2].Pattern)
+	}
+
+	writeEnvFile(t, dir, minimalEnvYAML)
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() without alerts error = %v", err)
+	}
+	if cfg.AlertResolvedRetention != 24*time.Hour || cfg.AlertStaleAfter != 6*time.Hour || len(cfg.AlertRules) != 0 {
+		t.Errorf("defaults = (%v, %v, %d rules), want (24h, 6h, 0 rules)", cfg.AlertResolvedRetention, cfg.AlertStaleAfter, len(cfg.AlertRules))
+	}
+}
+
+// TestLoad_SubscriptionsConfig verifies the subscriptions section and delivery defaults.
+func TestLoad_SubscriptionsConfig(t *testing.T) {
+	savedKey := os.Getenv("WEATHER_API_KEY")
+	os.Setenv("WEATHER_API_KEY", "test-key")
+	defer func() {
+		if savedKey != "" {
+			os.Setenv("WEATHER_API_KEY", savedKey)
+		} else {
+			os.Unsetenv("WEATHER_API_KEY")
+		}
+	}()
+
+	subsYAML := minimalEnvYAML + `
+subscriptions:
+  enabled: true
+  poll_interval: "1m"
+  max_subscriptions: 10
+  delivery:
+    max_attempts: 6
+    base_delay: "500ms"
+    allowed_hosts: ["10.0.0.0/8", "hooks.internal"]
+`
+	origWd, _ := os.Getwd()
+	dir := t.TempDir()
+	writeEnvFile(t, dir, subsYAML)
+	os.Chdir(dir)
+	defer os.Chdir(origWd)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.SubscriptionsEnabled || cfg.SubscriptionPollInterval != time.Minute || cfg.SubscriptionMaxCount != 10 {
+		t.Errorf("enabled/poll/max = (%v, %v, %d), want (true, 1m, 10)", cfg.SubscriptionsEnabled, cfg.SubscriptionPollInterval, cfg.SubscriptionMaxCount)
+	}
+	if cfg.WebhookMaxAttempts != 6 || cfg.WebhookBaseDelay != 500*time.Millisecond || cfg.WebhookMaxDelay != 30*time.Second {
+		t.Errorf("delivery = (%d, %v, %v), want (6, 500ms, 30s default)", cfg.WebhookMaxAttempts, cfg.WebhookBaseDelay, cfg.WebhookMaxDelay)
+	}
+	if len(cfg.WebhookAllowedHosts) != 2 {
+		t.Errorf("WebhookAllowedHosts = %v, want 2 entries", cfg.WebhookAllowedHosts)
+	}
+}
+
+// TestLoad_WatchlistAndAdminToken verifies watchlist defaults, overrides, and the admin token
+// lookup from the secrets file.
+func TestLoad_WatchlistAndAdminToken(t *testing.T) {
+	savedKey := os.Getenv("WEATHER_API_KEY")
+	savedToken := os.Getenv("ADMIN_API_TOKEN")
+	os.Setenv("WEATHER_API_KEY", "test-key")
+	os.Unsetenv("ADMIN_API_TOKEN")
+	defer func() {
+		if savedKey != "" {
+			os.Setenv("WEATHER_API_KEY", savedKey)
+		} else {
+			os.Unsetenv("WEATHER_API_KEY")
+		}
+		if savedToken != "" {
+			os.Setenv("ADMIN_API_TOKEN", savedToken)
+		}
+	}()
+
+	origWd, _ := os.Getwd()
+	dir := t.TempDir()
+	writeEnvFile(t, dir, minimalEnvYAML)
+	os.Chdir(dir)
+	defer os.Chdir(origWd)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.WatchlistFile != filepath.Join("data", "watchlist.json") || cfg.WatchlistMaxLocations != 200 || cfg.AdminAPIToken != "" {
+		t.Errorf("defaults = (%q, %d, %q), want (data/watchlist.json, 200, empty)", cfg.WatchlistFile, cfg.WatchlistMaxLocations, cfg.AdminAPIToken)
+	}
+
+	writeEnvFile(t, dir, minimalEnvYAML+`
+watchlist:
+  file: "/var/lib/weather/watchlist.json"
+  max_locations: 25
+`)
+	writeSecretsFile(t, dir, "admin_api_token: from-file\n")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.WatchlistFile != "/var/lib/weather/watchlist.json" || cfg.WatchlistMaxLocations != 25 || cfg.AdminAPIToken != "from-file" {
+		t.Errorf("overrides = (%q, %d, %q)", cfg.WatchlistFile, cfg.WatchlistMaxLocations, cfg.AdminAPIToken)
+	}
+}
+
+// TestLoad_Groups verifies group defaults and that definitions are read from YAML.
+func TestLoad_Groups(t *testing.T) {
+	savedKey := os.Getenv("WEATHER_API_KEY")
+	os.Setenv("WEATHER_API_KEY", "test-key")
+	defer func() {
+		if savedKey != "" {
+			os.Setenv("WEATHER_API_KEY", savedKey)
+		} else {
+			os.Unsetenv("WEATHER_API_KEY")
+		}
+	}()
+
+	origWd, _ := os.Getwd()
+	dir := t.TempDir()
+	writeEnvFile(t, dir, minimalEnvYAML)
+	os.Chdir(dir)
+	defer os.Chdir(origWd)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.GroupsFile != filepath.Join("data", "groups.json") || cfg.GroupsMaxMembers != 10 || len(cfg.Groups) != 0 {
+		t.Errorf("defaults = (%q, %d, %v), want (data/groups.json, 10 (rate_limit_burst), none)", cfg.GroupsFile, cfg.GroupsMaxMembers, cfg.Groups)
+	}
+
+	writeEnvFile(t, dir, minimalEnvYAML+`
+groups:
+  max_members: 10
+  definitions:
+    northeast: [boston, new york, hartford]
+`)
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.GroupsMaxMembers != 10 || strings.Join(cfg.Groups["northeast"], ",") != "boston,new york,hartford" {
+		t.Errorf("overrides = (%d, %v)", cfg.GroupsMaxMembers, cfg.Groups)
+	}
+
+	// A group costs one rate-limit token per member, so it must fit in the burst
+	writeEnvFile(t, dir, minimalEnvYAML+"groups:\n  max_members: 11\n")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "groups.max_members") {
+		t.Errorf("Load() with max_members above burst: error = %v, want groups.max_members error", err)
+	}
+}
+
+// TestLoad_WeatherProvider verifies the provider defaults to openweathermap, the keyless
+// providers load without WEATHER_API_KEY and get their own default URL, and unknown providers
+// or another provider's URL fail.
+func TestLoad_WeatherProvider(t *testing.T) {
+	savedKey := os.Getenv("WEATHER_API_KEY")
+	os.Unsetenv("WEATHER_API_KEY")
+	defer func() {
+		if savedKey != "" {
+			os.Setenv("WEATHER_API_KEY", savedKey)
+		}
+	}()
+
+	origWd, _ := os.Getwd()
+	dir := t.TempDir()
+	os.Chdir(dir)
+	defer os.Chdir(origWd)
+	withProvider := func(provider string) string {
+		return strings.Replace(minimalEnvYAML, `  url: "https://api.example.com"`, `  provider: "`+provider+`"`, 1)
+	}
+
+	// openweathermap (the default) still requires the API key
+	writeEnvFile(t, dir, withProvider(""))
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "WEATHER_API_KEY") {
+		t.Errorf("Load() openweathermap without key error = %v, want WEATHER_API_KEY required", err)
+	}
+
+	writeEnvFile(t, dir, withProvider("nws"))
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() nws error = %v", err)
+	}
+	if cfg.WeatherProvider != "nws" || cfg.WeatherAPIURL != "https://api.weather.gov" || cfg.GeocodingURL != "https://geocoding-api.open-meteo.com" {
+		t.Errorf("nws config = (%q, %q, %q)", cfg.WeatherProvider, cfg.WeatherAPIURL, cfg.GeocodingURL)
+	}
+
+	writeEnvFile(t, dir, withProvider("Open-Meteo"))
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() open-meteo error = %v", err)
+	}
+	if cfg.WeatherProvider != "open-meteo" || cfg.WeatherAPIURL != "https://api.open-meteo.com" {
+		t.Errorf("open-meteo config = (%q, %q)", cfg.WeatherProvider, cfg.WeatherAPIURL)
+	}
+
+	writeEnvFile(t, dir, withProvider("weatherstack"))
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "weather_api.provider") {
+		t.Errorf("Load() unknown provider error = %v, want weather_api.provider error", err)
+	}
+
+	// a url left over from another provider fails instead of silently calling that API
+	writeEnvFile(t, dir, strings.Replace(minimalEnvYAML, `  url: "https://api.example.com"`, `  provider: "nws"
+  url: "https://api.openweathermap.org/data/2.5/weather"`, 1))
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "weather_api.url") {
+		t.Errorf("Load() nws with OpenWeatherMap url error = %v, want weather_api.url error", err)
+	}
+}
+
+// TestLoad_HealthNotifications verifies defaults, the SMTP password lookup from the secrets
+// file, and that enabling notifications without a target fails.
+func TestLoad_HealthNotifications(t *testing.T) {
+	savedKey := os.Getenv("WEATHER_API_KEY")
+	savedPassword := os.Getenv("SMTP_PASSWORD")
+	os.Setenv("WEATHER_API_KEY", "test-key")
+	os.Unsetenv("SMTP_PASSWORD")
+	defer func() {
+		if savedKey != "" {
+			os.Setenv("WEATHER_API_KEY", savedKey)
+		} else {
+			os.Unsetenv("WEATHER_API_KEY")
+		}
+		if savedPassword != "" {
+			os.Setenv("SMTP_PASSWORD", savedPassword)
+		}
+	}()
+
+	origWd, _ := os.Getwd()
+	dir := t.TempDir()
+	writeEnvFile(t, dir, minimalEnvYAML)
+	os.Chdir(dir)
+	defer os.Chdir(origWd)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.HealthNotifyEnabled || cfg.HealthNotifyInterval != 30*time.Second || cfg.HealthNotifyDebounce != time.Minute || cfg.HealthNotifyTimeout != 10*time.Second {
+		t.Errorf("defaults = (%v, %v, %v, %v)", cfg.HealthNotifyEnabled, cfg.HealthNotifyInterval, cfg.HealthNotifyDebounce, cfg.HealthNotifyTimeout)
+	}
+
+	writeEnvFile(t, dir, minimalEnvYAML+`
+health_notifications:
+  enabled: true
+  debounce: "0"
+  smtp:
+    addr: "smtp.example.com:587"
+    from: "service@example.com"
+    to: ["oncall@example.com"]
+    username: "service"
+`)
+	writeSecretsFile(t, dir, "smtp_password: from-file\n")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.HealthNotifyDebounce != 0 || cfg.SMTPAddr != "smtp.example.com:587" || len(cfg.SMTPTo) != 1 || cfg.SMTPPassword != "from-file" {
+		t.Errorf("overrides = (%v, %q, %v, %q)", cfg.HealthNotifyDebounce, cfg.SMTPAddr, cfg.SMTPTo, cfg.SMTPPassword)
+	}
+
+	writeEnvFile(t, dir, minimalEnvYAML+`
+health_notifications:
+  enabled: true
+`)
+	if _, err := Load(); err == nil {
+		t.Error("Load() with notifications enabled and no targets: error = nil, want error")
+	}
+}
+
+// TestLoad_Reports verifies report job parsing, defaults and timezone validation.
+func TestLoad_Reports(t *testing.T) {
+	savedKey := os.Getenv("WEATHER_API_KEY")
+	os.Setenv("WEATHER_API_KEY", "test-key")
+	defer func() {
+		if savedKey != "" {
+			os.Setenv("WEATHER_API_KEY", savedKey)
+		} else {
+			os.Unsetenv("WEATHER_API_KEY")
+		}
+	}()
+
+	origWd, _ := os.Getwd()
+	dir := t.TempDir()
+	os.Chdir(dir)
+	defer os.Chdir(origWd)
+
+	writeEnvFile(t, dir, minimalEnvYAML+`
+reports:
+  timezone: "America/Chicago"
+  jobs:
+    - name: "morning"
+      schedule: "0 7 * * 1-5"
+      locations: ["chicago", "denver"]
+      webhook: "https://hooks.example.com/weather"
+`)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.ReportJobs) != 1 || cfg.ReportJobs[0].Schedule != "0 7 * * 1-5" || len(cfg.ReportJobs[0].Locations) != 2 {
+		t.Errorf("ReportJobs = %+v", cfg.ReportJobs)
+	}
+	if cfg.ReportLocation.String() != "America/Chicago" || cfg.ReportTimeout != 30*time.Second || cfg.ReportMaxAttempts != 3 || cfg.ReportRetryDelay != 30*time.Second || cfg.ReportConcurrency != 8 {
+		t.Errorf("settings = (%v, %v, %d, %v, %d)", cfg.ReportLocation, cfg.ReportTimeout, cfg.ReportMaxAttempts, cfg.ReportRetryDelay, cfg.ReportConcurrency)
+	}
+
+	writeEnvFile(t, dir, minimalEnvYAML+`
+reports:
+  timezone: "Mars/Olympus_Mons"
+`)
+	if _, err := Load(); err == nil {
+		t.Error("Load() with unknown timezone: error = nil, want error")
+	}
+}
+
+// TestLoad_BatchLimits verifies the batch size defaults to the rate limit burst when that is
+// below 50, and that an explicit size above the burst fails validation.
+func TestLoad_BatchLimits(t *testing.T) {
+	savedKey := os.Getenv("WEATHER_API_KEY")
+	os.Setenv("WEATHER_API_KEY", "test-key")
+	defer func() {
+		if savedKey != "" {
+			os.Setenv("WEATHER_API_KEY", savedKey)
+		} else {
+			os.Unsetenv("WEATHER_API_KEY")
+		}
+	}()
+
+	origWd, _ := os.Getwd()
+	dir := t.TempDir()
+	os.Chdir(dir)
+	defer os.Chdir(origWd)
+
+	writeEnvFile(t, dir, minimalEnvYAML)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.BatchMaxLocations != 10 || cfg.BatchConcurrency != 8 {
+		t.Errorf("defaults = (%d, %d), want (10, 8)", cfg.BatchMaxLocations, cfg.BatchConcurrency)
+	}
+
+	writeEnvFile(t, dir, strings.Replace(minimalEnvYAML, "request:\n", "request:\n  batch_max_locations: 11\n", 1))
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "batch_max_locations") {
+		t.Errorf("Load() with batch above burst: error = %v, want batch_max_locations error", err)
+	}
+}
+
 const minimalEnvYAML = `
 server:
   port: "8080"
@@ -0,0 +1,243 @@
+package expr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// Limits enforced by Compile.
+const (
+	MaxLength = 1024 // characters
+	MaxNodes  = 256  // literals, fields and operators
+	MaxDepth  = 32   // nested parentheses and unary operators
+)
+
+// Type is the static type of an expression.
+type Type int
+
+// Expression types.
+const (
+	TypeBool Type = iota
+	TypeNumber
+	TypeString
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeBool:
+		return "bool"
+	case TypeNumber:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+// Error is a compile error. Pos is the 1-based character position in the source.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+// Program is a compiled, type-checked boolean expression over models.WeatherData, such as
+//
+//	temperature < 0 && humidity > 80 || conditions ~ "snow"
+//
+// The language has no loops, calls or regular expressions, and Compile caps length, term count
+// and nesting, so evaluation cost is linear in a bounded tree. Safe for concurrent use.
+type Program struct {
+	root   node
+	fields []string
+}
+
+// Compile parses and type-checks src. The expression must be boolean. Errors are *Error.
+func Compile(src string) (*Program, error) {
+	if n := utf8.RuneCountInString(src); n > MaxLength {
+		return nil, &Error{Pos: MaxLength + 1, Msg: fmt.Sprintf("expression longer than %d characters", MaxLength)}
+	}
+	if strings.TrimSpace(src) == "" {
+		return nil, &Error{Pos: 1, Msg: "expression is empty"}
+	}
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s", t.kind)}
+	}
+	if root.typ() != TypeBool {
+		return nil, &Error{Pos: 1, Msg: fmt.Sprintf("expression must be bool, got %s", root.typ())}
+	}
+	return &Program{root: root, fields: referencedFields(root)}, nil
+}
+
+// Eval evaluates the program against data.
+func (p *Program) Eval(data models.WeatherData) bool {
+	return p.root.eval(&data).b
+}
+
+// Fields returns the names of the fields the expression reads, sorted.
+func (p *Program) Fields() []string {
+	return append([]string(nil), p.fields...)
+}
+
+// value holds an evaluated result; the node's static type says which member is set.
+type value struct {
+	b   bool
+	num float64
+	str string
+}
+
+type field struct {
+	t   Type
+	get func(*models.WeatherData) value
+}
+
+// fields are addressable by their JSON names.
+var fields = map[string]field{
+	"location":    {TypeString, func(d *models.WeatherData) value { return value{str: d.Location} }},
+	"temperature": {TypeNumber, func(d *models.WeatherData) value { return value{num: d.Temperature} }},
+	"conditions":  {TypeString, func(d *models.WeatherData) value { return value{str: d.Conditions} }},
+	"humidity":    {TypeNumber, func(d *models.WeatherData) value { return value{num: float64(d.Humidity)} }},
+	"windSpeed":   {TypeNumber, func(d *models.WeatherData) value { return value{num: d.WindSpeed} }},
+	"stale":       {TypeBool, func(d *models.WeatherData) value { return value{b: d.Stale} }},
+}
+
+var fieldList = func() string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}()
+
+type node interface {
+	typ() Type
+	pos() int
+	eval(*models.WeatherData) value
+}
+
+type literalNode struct {
+	v value
+	t Type
+	p int
+}
+
+func (n *literalNode) typ() Type                      { return n.t }
+func (n *literalNode) pos() int                       { return n.p }
+func (n *literalNode) eval(*models.WeatherData) value { return n.v }
+
+type fieldNode struct {
+	name string
+	f    field
+	p    int
+}
+
+func (n *fieldNode) typ() Type                        { return n.f.t }
+func (n *fieldNode) pos() int                         { return n.p }
+func (n *fieldNode) eval(d *models.WeatherData) value { return n.f.get(d) }
+
+type unaryNode struct {
+	op tokenKind
+	x  node
+	p  int
+}
+
+func (n *unaryNode) typ() Type {
+	if n.op == tokNot {
+		return TypeBool
+	}
+	return TypeNumber
+}
+
+func (n *unaryNode) pos() int { return n.p }
+
+func (n *unaryNode) eval(d *models.WeatherData) value {
+	v := n.x.eval(d)
+	if n.op == tokNot {
+		return value{b: !v.b}
+	}
+	return value{num: -v.num}
+}
+
+type binaryNode struct {
+	op   tokenKind
+	x, y node
+	t    Type
+	p    int
+}
+
+func (n *binaryNode) typ() Type { return n.t }
+func (n *binaryNode) pos() int  { return n.p }
+
+func (n *binaryNode) eval(d *models.WeatherData) value {
+	// && and || short-circuit.
+	switch n.op {
+	case tokAnd:
+		return value{b: n.x.eval(d).b && n.y.eval(d).b}
+	case tokOr:
+		return value{b: n.x.eval(d).b || n.y.eval(d).b}
+	}
+	x, y := n.x.eval(d), n.y.eval(d)
+	switch n.op {
+	case tokPlus:
+		return value{num: x.num + y.num}
+	case tokMinus:
+		return value{num: x.num - y.num}
+	case tokStar:
+		return value{num: x.num * y.num}
+	case tokLt:
+		return value{b: x.num < y.num}
+	case tokLe:
+		return value{b: x.num <= y.num}
+	case tokGt:
+		return value{b: x.num > y.num}
+	case tokGe:
+		return value{b: x.num >= y.num}
+	case tokMatch:
+		// Case-insensitive substring match; deliberately not a regular expression.
+		return value{b: strings.Contains(strings.ToLower(x.str), strings.ToLower(y.str))}
+	case tokEq:
+		return value{b: x == y}
+	default: // tokNe
+		return value{b: x != y}
+	}
+}
+
+// referencedFields walks the tree and returns the distinct field names, sorted.
+func referencedFields(root node) []string {
+	seen := make(map[string]struct{})
+	var walk func(node)
+	walk = func(n node) {
+		switch n := n.(type) {
+		case *fieldNode:
+			seen[n.name] = struct{}{}
+		case *unaryNode:
+			walk(n.x)
+		case *binaryNode:
+			walk(n.x)
+			walk(n.y)
+		}
+	}
+	walk(root)
+	out := make([]string, 0, len(seen))
+	for name := range seen {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
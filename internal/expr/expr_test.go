@@ -0,0 +1,121 @@
+package expr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+var snowyChicago = models.WeatherData{
+	Location:    "chicago",
+	Temperature: -3.5,
+	Conditions:  "Light Snow",
+	Humidity:    86,
+	WindSpeed:   7.2,
+}
+
+// TestProgram_Eval verifies operators, precedence and field access against one reading.
+func TestProgram_Eval(t *testing.T) {
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{`temperature < 0 && humidity > 80 || conditions ~ "rain"`, true},
+		{`temperature < -5 && humidity > 80 || conditions ~ "SNOW"`, true},
+		{`temperature < -5 && (humidity > 80 || conditions ~ "snow")`, false},
+		{`conditions == "Light Snow"`, true},
+		{`conditions == "light snow"`, false},
+		{`conditions != "Clear" && location == "chicago"`, true},
+		{`!(windSpeed >= 7.2)`, false},
+		{`temperature * 9 + 160 < 5 * 32 - 20`, true}, // -31.5 + 160 < 140
+		{`-temperature > 3`, true},
+		{`stale == false && !stale`, true},
+		{`humidity <= 86 && humidity >= 86`, true},
+		{`true`, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.src, func(t *testing.T) {
+			p, err := Compile(tc.src)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			if got := p.Eval(snowyChicago); got != tc.want {
+				t.Errorf("Eval() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestCompile_Errors verifies syntax and type errors report the offending position.
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantPos int
+		wantMsg string
+	}{
+		{name: "empty", src: "  ", wantPos: 1, wantMsg: "empty"},
+		{name: "unknown field", src: "temp < 0", wantPos: 1, wantMsg: `unknown field "temp"`},
+		{name: "type mismatch", src: `temperature < "cold"`, wantPos: 13, wantMsg: "needs number operands"},
+		{name: "match on number", src: "humidity ~ 80", wantPos: 10, wantMsg: "needs string operands"},
+		{name: "eq mixed types", src: `conditions == 1`, wantPos: 12, wantMsg: "cannot compare string with number"},
+		{name: "not on number", src: "!humidity", wantPos: 1, wantMsg: "needs a bool operand"},
+		{name: "non-bool result", src: "humidity + 1", wantPos: 1, wantMsg: "must be bool"},
+		{name: "chained comparison", src: "0 < humidity < 100", wantPos: 14, wantMsg: "cannot be chained"},
+		{name: "unclosed paren", src: "(humidity > 1", wantPos: 14, wantMsg: "expected ')'"},
+		{name: "trailing token", src: "humidity > 1)", wantPos: 13, wantMsg: "unexpected ')'"},
+		{name: "missing operand", src: "humidity >", wantPos: 11, wantMsg: "unexpected end"},
+		{name: "single equals", src: "humidity = 1", wantPos: 10, wantMsg: "unexpected character '='"},
+		{name: "unterminated string", src: `conditions ~ "snow`, wantPos: 14, wantMsg: "unterminated string"},
+		{name: "bad number", src: "humidity > 1.2.3", wantPos: 12, wantMsg: "invalid number"},
+		{name: "positions count characters", src: `"né" == conditions && x`, wantPos: 23, wantMsg: `unknown field "x"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Compile(tc.src)
+
+			var cerr *Error
+			if !errors.As(err, &cerr) {
+				t.Fatalf("Compile() error = %v, want *Error", err)
+			}
+			if cerr.Pos != tc.wantPos || !strings.Contains(cerr.Msg, tc.wantMsg) {
+				t.Errorf("error = %v, want position %d containing %q", cerr, tc.wantPos, tc.wantMsg)
+			}
+		})
+	}
+}
+
+// TestCompile_Limits verifies the length, term count and nesting caps.
+func TestCompile_Limits(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantMsg string
+	}{
+		{name: "length", src: "humidity > 1" + strings.Repeat(" ", MaxLength), wantMsg: "longer than"},
+		{name: "nodes", src: strings.Repeat("stale||", MaxNodes/2) + "stale", wantMsg: "more than"},
+		{name: "depth", src: strings.Repeat("(", MaxDepth+1) + "true" + strings.Repeat(")", MaxDepth+1), wantMsg: "nested deeper"},
+		{name: "unary depth", src: strings.Repeat("!", MaxDepth+1) + "true", wantMsg: "nested deeper"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Compile(tc.src)
+			if err == nil || !strings.Contains(err.Error(), tc.wantMsg) {
+				t.Errorf("Compile() error = %v, want %q", err, tc.wantMsg)
+			}
+		})
+	}
+}
+
+// TestProgram_Fields verifies referenced fields are reported once, sorted.
+func TestProgram_Fields(t *testing.T) {
+	p, err := Compile(`temperature < 0 && humidity > 80 || conditions ~ "snow" || temperature > 30`)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if got := strings.Join(p.Fields(), ","); got != "conditions,humidity,temperature" {
+		t.Errorf("Fields() = %q, want conditions,humidity,temperature", got)
+	}
+}
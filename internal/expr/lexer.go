@@ -0,0 +1,185 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokTrue
+	tokFalse
+	tokLParen
+	tokRParen
+	tokOr    // ||
+	tokAnd   // &&
+	tokNot   // !
+	tokEq    // ==
+	tokNe    // !=
+	tokLt    // <
+	tokLe    // <=
+	tokGt    // >
+	tokGe    // >=
+	tokMatch // ~
+	tokPlus  // +
+	tokMinus // -
+	tokStar  // *
+)
+
+var tokenNames = map[tokenKind]string{
+	tokEOF: "end of expression", tokIdent: "field", tokNumber: "number", tokString: "string",
+	tokTrue: "true", tokFalse: "false", tokLParen: "'('", tokRParen: "')'",
+	tokOr: "'||'", tokAnd: "'&&'", tokNot: "'!'", tokEq: "'=='", tokNe: "'!='",
+	tokLt: "'<'", tokLe: "'<='", tokGt: "'>'", tokGe: "'>='", tokMatch: "'~'",
+	tokPlus: "'+'", tokMinus: "'-'", tokStar: "'*'",
+}
+
+func (k tokenKind) String() string { return tokenNames[k] }
+
+type token struct {
+	kind tokenKind
+	text string  // identifier name or decoded string literal
+	num  float64 // number literal value
+	pos  int     // 1-based character position of the first character
+}
+
+// lex splits src into tokens. Positions count characters (runes), not bytes, so they line
+// up with what a client sees in its own editor.
+func lex(src string) ([]token, error) {
+	var toks []token
+	pos := 0 // rune index
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		start := pos + 1
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+			pos++
+			continue
+		case r == '_' || unicode.IsLetter(r):
+			j := i
+			for j < len(src) {
+				c, n := utf8.DecodeRuneInString(src[j:])
+				if c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+					break
+				}
+				j += n
+				pos++
+			}
+			word := src[i:j]
+			switch word {
+			case "true":
+				toks = append(toks, token{kind: tokTrue, pos: start})
+			case "false":
+				toks = append(toks, token{kind: tokFalse, pos: start})
+			default:
+				toks = append(toks, token{kind: tokIdent, text: word, pos: start})
+			}
+			i = j
+			continue
+		case r >= '0' && r <= '9' || r == '.':
+			j := i
+			for j < len(src) && (src[j] >= '0' && src[j] <= '9' || src[j] == '.') {
+				j++
+			}
+			v, err := strconv.ParseFloat(src[i:j], 64)
+			if err != nil {
+				return nil, &Error{Pos: start, Msg: "invalid number " + strconv.Quote(src[i:j])}
+			}
+			toks = append(toks, token{kind: tokNumber, num: v, pos: start})
+			pos += j - i
+			i = j
+			continue
+		case r == '"':
+			var b strings.Builder
+			j := i + 1
+			pos++
+			closed := false
+			for j < len(src) {
+				c, n := utf8.DecodeRuneInString(src[j:])
+				j += n
+				pos++
+				if c == '"' {
+					closed = true
+					break
+				}
+				if c == '\\' {
+					if j >= len(src) {
+						break
+					}
+					e, m := utf8.DecodeRuneInString(src[j:])
+					if e != '"' && e != '\\' {
+						return nil, &Error{Pos: pos, Msg: "invalid escape; only \\\" and \\\\ are supported"}
+					}
+					j += m
+					pos++
+					c = e
+				}
+				b.WriteRune(c)
+			}
+			if !closed {
+				return nil, &Error{Pos: start, Msg: "unterminated string"}
+			}
+			toks = append(toks, token{kind: tokString, text: b.String(), pos: start})
+			i = j
+			continue
+		}
+
+		kind, width := operator(src[i:])
+		if width == 0 {
+			return nil, &Error{Pos: start, Msg: "unexpected character " + strconv.QuoteRune(r)}
+		}
+		toks = append(toks, token{kind: kind, pos: start})
+		i += width
+		pos += width
+	}
+	return append(toks, token{kind: tokEOF, pos: pos + 1}), nil
+}
+
+// operator matches the longest operator at the start of s. width is 0 when none matches.
+func operator(s string) (tokenKind, int) {
+	if len(s) >= 2 {
+		switch s[:2] {
+		case "||":
+			return tokOr, 2
+		case "&&":
+			return tokAnd, 2
+		case "==":
+			return tokEq, 2
+		case "!=":
+			return tokNe, 2
+		case "<=":
+			return tokLe, 2
+		case ">=":
+			return tokGe, 2
+		}
+	}
+	switch s[0] {
+	case '(':
+		return tokLParen, 1
+	case ')':
+		return tokRParen, 1
+	case '!':
+		return tokNot, 1
+	case '<':
+		return tokLt, 1
+	case '>':
+		return tokGt, 1
+	case '~':
+		return tokMatch, 1
+	case '+':
+		return tokPlus, 1
+	case '-':
+		return tokMinus, 1
+	case '*':
+		return tokStar, 1
+	}
+	return 0, 0
+}
@@ -0,0 +1,225 @@
+package expr
+
+import "fmt"
+
+// parser is a recursive-descent parser that type-checks as it builds the tree, so every
+// node it returns is well-typed. Precedence, lowest first:
+//
+//	||   &&   == != < <= > >= ~   + -   *   unary ! -
+type parser struct {
+	toks  []token
+	i     int
+	depth int
+	nodes int
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+// enter guards recursion depth and total node count; both bound evaluation cost.
+func (p *parser) enter(pos int) error {
+	p.depth++
+	if p.depth > MaxDepth {
+		return &Error{Pos: pos, Msg: fmt.Sprintf("expression nested deeper than %d levels", MaxDepth)}
+	}
+	return nil
+}
+
+func (p *parser) leave() { p.depth-- }
+
+func (p *parser) add(n node) (node, error) {
+	p.nodes++
+	if p.nodes > MaxNodes {
+		return nil, &Error{Pos: n.pos(), Msg: fmt.Sprintf("expression has more than %d terms", MaxNodes)}
+	}
+	return n, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		op := p.next()
+		y, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if x, err = p.binary(op, x, y); err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	x, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		op := p.next()
+		y, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		if x, err = p.binary(op, x, y); err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+func (p *parser) parseComparison() (node, error) {
+	x, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if !isComparison(p.peek().kind) {
+		return x, nil
+	}
+	op := p.next()
+	y, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); isComparison(t.kind) {
+		return nil, &Error{Pos: t.pos, Msg: "comparisons cannot be chained; combine them with && or ||"}
+	}
+	return p.binary(op, x, y)
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	x, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for k := p.peek().kind; k == tokPlus || k == tokMinus; k = p.peek().kind {
+		op := p.next()
+		y, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		if x, err = p.binary(op, x, y); err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokStar {
+		op := p.next()
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if x, err = p.binary(op, x, y); err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	if t.kind != tokNot && t.kind != tokMinus {
+		return p.parsePrimary()
+	}
+	p.next()
+	if err := p.enter(t.pos); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	want := TypeNumber
+	if t.kind == tokNot {
+		want = TypeBool
+	}
+	if x.typ() != want {
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("%s needs a %s operand, got %s", t.kind, want, x.typ())}
+	}
+	return p.add(&unaryNode{op: t.kind, x: x, p: t.pos})
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return p.add(&literalNode{v: value{num: t.num}, t: TypeNumber, p: t.pos})
+	case tokString:
+		return p.add(&literalNode{v: value{str: t.text}, t: TypeString, p: t.pos})
+	case tokTrue, tokFalse:
+		return p.add(&literalNode{v: value{b: t.kind == tokTrue}, t: TypeBool, p: t.pos})
+	case tokIdent:
+		f, ok := fields[t.text]
+		if !ok {
+			return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("unknown field %q; fields are %s", t.text, fieldList)}
+		}
+		return p.add(&fieldNode{name: t.text, f: f, p: t.pos})
+	case tokLParen:
+		if err := p.enter(t.pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c.kind != tokRParen {
+			return nil, &Error{Pos: c.pos, Msg: fmt.Sprintf("expected ')' to close '(' at position %d, got %s", t.pos, c.kind)}
+		}
+		return x, nil
+	case tokEOF:
+		return nil, &Error{Pos: t.pos, Msg: "unexpected end of expression"}
+	default:
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s", t.kind)}
+	}
+}
+
+// binary type-checks op applied to x and y and returns the node.
+func (p *parser) binary(op token, x, y node) (node, error) {
+	var want, result Type
+	switch op.kind {
+	case tokOr, tokAnd:
+		want, result = TypeBool, TypeBool
+	case tokLt, tokLe, tokGt, tokGe:
+		want, result = TypeNumber, TypeBool
+	case tokPlus, tokMinus, tokStar:
+		want, result = TypeNumber, TypeNumber
+	case tokMatch:
+		want, result = TypeString, TypeBool
+	case tokEq, tokNe:
+		if x.typ() != y.typ() {
+			return nil, &Error{Pos: op.pos, Msg: fmt.Sprintf("cannot compare %s with %s", x.typ(), y.typ())}
+		}
+		return p.add(&binaryNode{op: op.kind, x: x, y: y, t: TypeBool, p: op.pos})
+	}
+	if x.typ() != want || y.typ() != want {
+		return nil, &Error{Pos: op.pos, Msg: fmt.Sprintf("%s needs %s operands, got %s and %s", op.kind, want, x.typ(), y.typ())}
+	}
+	return p.add(&binaryNode{op: op.kind, x: x, y: y, t: result, p: op.pos})
+}
+
+func isComparison(k tokenKind) bool {
+	switch k {
+	case tokEq, tokNe, tokLt, tokLe, tokGt, tokGe, tokMatch:
+		return true
+	}
+	return false
+}
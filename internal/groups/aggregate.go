@@ -0,0 +1,99 @@
+package groups
+
+import (
+	"strings"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// Reading is the weather of one group member, keyed by the member's location as configured.
+type Reading struct {
+	Location string
+	Weather  models.WeatherData
+}
+
+// Aggregate summarizes a group's readings. Extremes name the member they came from; on ties
+// the earlier member wins. Fields are omitted when no member reported weather.
+type Aggregate struct {
+	Reporting       int          `json:"reporting"` // members with weather
+	TemperatureMin  *Extreme     `json:"temperatureMin,omitempty"`
+	TemperatureMax  *Extreme     `json:"temperatureMax,omitempty"`
+	HighestWind     *Extreme     `json:"highestWind,omitempty"`
+	WorstConditions *WorstMember `json:"worstConditions,omitempty"`
+}
+
+// Extreme is a reading value and the member it was observed at, in the readings' units.
+type Extreme struct {
+	Location string  `json:"location"`
+	Value    float64 `json:"value"`
+}
+
+// WorstMember is the member with the most severe conditions per ConditionSeverity.
+type WorstMember struct {
+	Location   string `json:"location"`
+	Conditions string `json:"conditions"`
+}
+
+// Summarize computes the aggregate of readings, which must share one unit system.
+func Summarize(readings []Reading) Aggregate {
+	agg := Aggregate{Reporting: len(readings)}
+	worst := -1
+	for _, r := range readings {
+		w := r.Weather
+		if agg.TemperatureMin == nil || w.Temperature < agg.TemperatureMin.Value {
+			agg.TemperatureMin = &Extreme{Location: r.Location, Value: w.Temperature}
+		}
+		if agg.TemperatureMax == nil || w.Temperature > agg.TemperatureMax.Value {
+			agg.TemperatureMax = &Extreme{Location: r.Location, Value: w.Temperature}
+		}
+		if agg.HighestWind == nil || w.WindSpeed > agg.HighestWind.Value {
+			agg.HighestWind = &Extreme{Location: r.Location, Value: w.WindSpeed}
+		}
+		if severity := ConditionSeverity(w.Conditions); severity > worst {
+			worst = severity
+			agg.WorstConditions = &WorstMember{Location: r.Location, Conditions: w.Conditions}
+		}
+	}
+	return agg
+}
+
+// conditionLevels ranks condition keywords of OpenWeatherMap-style descriptions, most severe
+// first.
+var conditionLevels = []struct {
+	keywords []string
+	level    int
+}{
+	{keywords: []string{"tornado"}, level: 9},
+	{keywords: []string{"squall"}, level: 8},
+	{keywords: []string{"thunderstorm"}, level: 7},
+	{keywords: []string{"freezing"}, level: 6},
+	{keywords: []string{"snow", "sleet"}, level: 5},
+	{keywords: []string{"rain", "shower"}, level: 4},
+	{keywords: []string{"drizzle"}, level: 3},
+	{keywords: []string{"fog", "mist", "haze", "smoke", "dust", "sand", "ash"}, level: 2},
+	{keywords: []string{"cloud"}, level: 1},
+}
+
+// ConditionSeverity ranks a conditions description: higher is worse, and clear or
+// unrecognized conditions are 0. The most severe keyword sets the level ("light rain, mist"
+// ranks as rain); within a level, "heavy", "extreme" or "violent" rank above plain and
+// "light" below.
+func ConditionSeverity(conditions string) int {
+	c := strings.ToLower(conditions)
+	for _, l := range conditionLevels {
+		for _, kw := range l.keywords {
+			if !strings.Contains(c, kw) {
+				continue
+			}
+			intensity := 1
+			switch {
+			case strings.Contains(c, "heavy") || strings.Contains(c, "extreme") || strings.Contains(c, "violent"):
+				intensity = 2
+			case strings.Contains(c, "light"):
+				intensity = 0
+			}
+			return l.level*3 + intensity
+		}
+	}
+	return 0
+}
@@ -0,0 +1,68 @@
+package groups
+
+import (
+	"testing"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// TestConditionSeverity verifies condition descriptions rank by kind, then intensity.
+func TestConditionSeverity(t *testing.T) {
+	// Each entry is worse than the one before it.
+	ordered := []string{
+		"clear sky",
+		"few clouds",
+		"mist",
+		"light intensity drizzle",
+		"light rain",
+		"moderate rain",
+		"heavy intensity rain",
+		"light snow",
+		"freezing rain",
+		"thunderstorm with light rain",
+		"thunderstorm with heavy rain",
+		"squalls",
+		"tornado",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		prev, cur := ConditionSeverity(ordered[i-1]), ConditionSeverity(ordered[i])
+		if cur <= prev {
+			t.Errorf("ConditionSeverity(%q) = %d, want > ConditionSeverity(%q) = %d", ordered[i], cur, ordered[i-1], prev)
+		}
+	}
+	if got := ConditionSeverity("light rain, mist"); got != ConditionSeverity("light rain") {
+		t.Errorf("ConditionSeverity(light rain, mist) = %d, want the rain level %d", got, ConditionSeverity("light rain"))
+	}
+}
+
+// TestSummarize verifies extremes name their member, ties go to the earlier member, and an
+// empty group has no extremes.
+func TestSummarize(t *testing.T) {
+	// Arrange
+	readings := []Reading{
+		{Location: "boston", Weather: models.WeatherData{Temperature: 4, WindSpeed: 9, Conditions: "light rain"}},
+		{Location: "new york", Weather: models.WeatherData{Temperature: 7, WindSpeed: 9, Conditions: "overcast clouds"}},
+		{Location: "hartford", Weather: models.WeatherData{Temperature: 2, WindSpeed: 5, Conditions: "heavy snow"}},
+	}
+
+	// Act
+	got := Summarize(readings)
+
+	// Assert
+	if got.Reporting != 3 {
+		t.Errorf("Reporting = %d, want 3", got.Reporting)
+	}
+	if *got.TemperatureMin != (Extreme{Location: "hartford", Value: 2}) || *got.TemperatureMax != (Extreme{Location: "new york", Value: 7}) {
+		t.Errorf("temperature min, max = %+v, %+v", *got.TemperatureMin, *got.TemperatureMax)
+	}
+	if *got.HighestWind != (Extreme{Location: "boston", Value: 9}) {
+		t.Errorf("HighestWind = %+v, want boston (first of tie)", *got.HighestWind)
+	}
+	if *got.WorstConditions != (WorstMember{Location: "hartford", Conditions: "heavy snow"}) {
+		t.Errorf("WorstConditions = %+v", *got.WorstConditions)
+	}
+	if empty := Summarize(nil); empty.Reporting != 0 || empty.TemperatureMin != nil || empty.WorstConditions != nil {
+		t.Errorf("Summarize(nil) = %+v", empty)
+	}
+}
@@ -0,0 +1,210 @@
+package groups
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrNotFound is returned when a group does not exist.
+	ErrNotFound = errors.New("group not found")
+	// ErrInvalidName is returned for names that are not 1-64 lowercase letters, digits, '-'
+	// or '_'.
+	ErrInvalidName = errors.New("group name must be 1-64 letters, digits, '-' or '_'")
+	// ErrNoMembers is returned when a group would have no locations.
+	ErrNoMembers = errors.New("group must have at least one location")
+	// ErrTooManyMembers is returned when a group would exceed the configured maximum.
+	ErrTooManyMembers = errors.New("group has too many locations")
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// Group is a named list of locations.
+type Group struct {
+	Name      string   `json:"name"`
+	Locations []string `json:"locations"`
+}
+
+// Store holds the named location groups served by /groups/{name}/weather and edited through
+// /admin/groups. When path is set, every change is written to disk before it takes effect; a
+// failed write leaves the groups unchanged.
+type Store struct {
+	mu         sync.Mutex
+	path       string
+	maxMembers int
+	groups     map[string][]string
+}
+
+type fileFormat struct {
+	Groups map[string][]string `json:"groups"`
+}
+
+// Open loads the groups from path. If the file does not exist, the groups are seeded from
+// seed (the groups.definitions config) and the file is created on the first change. An empty
+// path keeps the groups in memory only. maxMembers caps the locations per group (0 =
+// unlimited) and applies to loaded groups too.
+func Open(path string, seed map[string][]string, maxMembers int) (*Store, error) {
+	s := &Store{path: path, maxMembers: maxMembers, groups: make(map[string][]string)}
+	initial := seed
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			var f fileFormat
+			if err := json.Unmarshal(data, &f); err != nil {
+				return nil, fmt.Errorf("parse groups %s: %w", path, err)
+			}
+			initial = f.Groups
+		case !os.IsNotExist(err):
+			return nil, fmt.Errorf("read groups: %w", err)
+		}
+	}
+	for name, locations := range initial {
+		key, members, err := s.normalize(name, locations)
+		if err != nil {
+			return nil, fmt.Errorf("group %q: %w", name, err)
+		}
+		s.groups[key] = members
+	}
+	return s, nil
+}
+
+// List returns every group, sorted by name.
+func (s *Store) List() []Group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Group, 0, len(s.groups))
+	for name, members := range s.groups {
+		out = append(out, Group{Name: name, Locations: append([]string(nil), members...)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Get returns the named group, with locations in their configured order, or ErrNotFound.
+func (s *Store) Get(name string) (Group, error) {
+	key := normalizeName(name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members, ok := s.groups[key]
+	if !ok {
+		return Group{}, ErrNotFound
+	}
+	return Group{Name: key, Locations: append([]string(nil), members...)}, nil
+}
+
+// Put creates or replaces the named group. Locations are normalized like the watchlist's and
+// deduplicated, keeping first-seen order. Returns the stored group and whether it was created.
+func (s *Store) Put(name string, locations []string) (Group, bool, error) {
+	key, members, err := s.normalize(name, locations)
+	if err != nil {
+		return Group{}, false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, existed := s.groups[key]
+	s.groups[key] = members
+	if err := s.commitLocked(); err != nil {
+		if existed {
+			s.groups[key] = prev
+		} else {
+			delete(s.groups, key)
+		}
+		return Group{}, false, err
+	}
+	return Group{Name: key, Locations: append([]string(nil), members...)}, !existed, nil
+}
+
+// Delete removes the named group or returns ErrNotFound.
+func (s *Store) Delete(name string) error {
+	key := normalizeName(name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.groups[key]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.groups, key)
+	if err := s.commitLocked(); err != nil {
+		s.groups[key] = prev
+		return err
+	}
+	return nil
+}
+
+// normalize validates a group definition and returns its key and member list.
+func (s *Store) normalize(name string, locations []string) (string, []string, error) {
+	key := normalizeName(name)
+	if !namePattern.MatchString(key) {
+		return "", nil, ErrInvalidName
+	}
+	seen := make(map[string]bool, len(locations))
+	members := make([]string, 0, len(locations))
+	for _, loc := range locations {
+		loc = strings.ToLower(strings.TrimSpace(loc))
+		if loc == "" || seen[loc] {
+			continue
+		}
+		seen[loc] = true
+		members = append(members, loc)
+	}
+	if len(members) == 0 {
+		return "", nil, ErrNoMembers
+	}
+	if s.maxMembers > 0 && len(members) > s.maxMembers {
+		return "", nil, fmt.Errorf("%w (max %d)", ErrTooManyMembers, s.maxMembers)
+	}
+	return key, members, nil
+}
+
+// commitLocked persists the groups.
+func (s *Store) commitLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	if err := writeFileAtomic(s.path, fileFormat{Groups: s.groups}); err != nil {
+		return fmt.Errorf("persist groups: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes v as JSON to a temp file in the target directory and renames it
+// over path, so a crash mid-write never leaves a truncated file.
+func writeFileAtomic(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".groups-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
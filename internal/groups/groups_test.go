@@ -0,0 +1,124 @@
+package groups
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestOpen_SeedsWhenFileMissing verifies seeded groups are normalized and deduplicated in
+// order, and that no file is written until the first change.
+func TestOpen_SeedsWhenFileMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "groups.json")
+
+	s, err := Open(path, map[string][]string{"NorthEast": {" Boston ", "new york", "BOSTON", "hartford"}}, 0)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	got, err := s.Get("NorthEast ")
+	if want := (Group{Name: "northeast", Locations: []string{"boston", "new york", "hartford"}}); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %v, %v; want %v", got, err, want)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("file exists before any change (stat err = %v)", err)
+	}
+}
+
+// TestOpen_RejectsInvalidSeed verifies a bad definition fails Open with the group named.
+func TestOpen_RejectsInvalidSeed(t *testing.T) {
+	tests := []struct {
+		name string
+		seed map[string][]string
+		want error
+	}{
+		{name: "bad name", seed: map[string][]string{"north east": {"boston"}}, want: ErrInvalidName},
+		{name: "no members", seed: map[string][]string{"empty": {" "}}, want: ErrNoMembers},
+		{name: "too many", seed: map[string][]string{"big": {"a", "b", "c"}}, want: ErrTooManyMembers},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Open("", tc.seed, 2); !errors.Is(err, tc.want) {
+				t.Errorf("Open() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+// TestStore_PersistsAcrossOpen verifies changes are written and the file wins over the seed
+// on the next Open.
+func TestStore_PersistsAcrossOpen(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "nested", "groups.json")
+	s, _ := Open(path, map[string][]string{"northeast": {"boston"}}, 0)
+
+	// Act
+	if _, created, err := s.Put("pacific", []string{"Seattle", "Portland"}); err != nil || !created {
+		t.Fatalf("Put() = (%v, %v), want (true, nil)", created, err)
+	}
+	if _, created, err := s.Put("northeast", []string{"boston", "hartford"}); err != nil || created {
+		t.Fatalf("Put(existing) = (%v, %v), want (false, nil)", created, err)
+	}
+	if err := s.Delete("pacific"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	// Assert
+	reopened, err := Open(path, map[string][]string{"south": {"austin"}}, 0)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	want := []Group{{Name: "northeast", Locations: []string{"boston", "hartford"}}}
+	if got := reopened.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("reopened List() = %v, want %v (file overrides seed)", got, want)
+	}
+}
+
+// TestStore_Errors verifies unknown groups and invalid definitions leave the store unchanged.
+func TestStore_Errors(t *testing.T) {
+	s, _ := Open("", map[string][]string{"northeast": {"boston"}}, 2)
+
+	if _, err := s.Get("south"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(unknown) error = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete("south"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete(unknown) error = %v, want ErrNotFound", err)
+	}
+	if _, _, err := s.Put("northeast", []string{"a", "b", "c"}); !errors.Is(err, ErrTooManyMembers) {
+		t.Errorf("Put() over cap error = %v, want ErrTooManyMembers", err)
+	}
+	if got, _ := s.Get("northeast"); !reflect.DeepEqual(got.Locations, []string{"boston"}) {
+		t.Errorf("Get() after failed Put = %v, want [boston]", got)
+	}
+}
+
+// TestStore_PersistFailureRollsBack verifies a failed write leaves the groups untouched.
+func TestStore_PersistFailureRollsBack(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(filepath.Join(dir, "sub", "groups.json"), map[string][]string{"northeast": {"boston"}}, 0)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	// A regular file where the parent directory should be makes every write fail.
+	if err := os.WriteFile(filepath.Join(dir, "sub"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := s.Put("northeast", []string{"hartford"}); err == nil {
+		t.Fatal("Put() error = nil, want persist error")
+	}
+	if _, _, err := s.Put("pacific", []string{"seattle"}); err == nil {
+		t.Fatal("Put(new) error = nil, want persist error")
+	}
+	if err := s.Delete("northeast"); err == nil {
+		t.Fatal("Delete() error = nil, want persist error")
+	}
+
+	want := []Group{{Name: "northeast", Locations: []string{"boston"}}}
+	if got := s.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want unchanged %v", got, want)
+	}
+}
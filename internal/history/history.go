@@ -0,0 +1,328 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// ErrClosed is returned by reads after Close.
+var ErrClosed = errors.New("history store closed")
+
+// Config configures a Store.
+type Config struct {
+	Path           string        // bbolt database file; parent directories are created
+	Retention      time.Duration // observations older than this are pruned
+	MaxPerLocation int           // observations kept per location; oldest are pruned first
+	MaxLocations   int           // locations stored; observations for new locations beyond this are skipped
+	QueueSize      int           // pending writes; overflow is dropped and counted
+}
+
+// Store retains a bounded time series of upstream observations per location in an embedded
+// bbolt database: one bucket per normalized location, keyed by the reading's timestamp (a
+// reading with the same timestamp replaces the earlier one).
+//
+// Record is a fetch hook and only enqueues; Run performs the writes in batches off the
+// request path, so a reading becomes visible to Range shortly after it is fetched.
+type Store struct {
+	cfg    Config
+	db     *bolt.DB
+	queue  chan observation
+	logger *zap.Logger
+
+	mu     sync.Mutex     // protects counts
+	counts map[string]int // observations per location bucket
+}
+
+type observation struct {
+	key  string
+	data models.WeatherData
+}
+
+// Open opens or creates the database at cfg.Path, applying defaults for zero config values.
+// logger may be nil.
+func Open(cfg Config, logger *zap.Logger) (*Store, error) {
+	if cfg.Retention <= 0 {
+		cfg.Retention = 7 * 24 * time.Hour
+	}
+	if cfg.MaxPerLocation <= 0 {
+		cfg.MaxPerLocation = 2016
+	}
+	if cfg.MaxLocations <= 0 {
+		cfg.MaxLocations = 500
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 1000
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o755); err != nil {
+		return nil, fmt.Errorf("history: create directory: %w", err)
+	}
+	// The timeout keeps a second process on the same file from hanging startup on the lock.
+	db, err := bolt.Open(cfg.Path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("history: open %s: %w", cfg.Path, err)
+	}
+	s := &Store{
+		cfg:    cfg,
+		db:     db,
+		queue:  make(chan observation, cfg.QueueSize),
+		logger: logger,
+		counts: make(map[string]int),
+	}
+	err = db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			s.counts[string(name)] = b.Stats().KeyN
+			return nil
+		})
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("history: load %s: %w", cfg.Path, err)
+	}
+	return s, nil
+}
+
+// Record enqueues data for key. It matches service.FetchHook and is registered with
+// WeatherService.AddFetchHook. Never blocks: when the queue is full the observation is dropped.
+func (s *Store) Record(ctx context.Context, key string, data models.WeatherData) {
+	select {
+	case s.queue <- observation{key: validation.NormalizeLocation(key), data: data}:
+	default:
+		observability.HistoryWritesTotal.WithLabelValues("dropped").Inc()
+	}
+}
+
+// Run writes queued observations in batches and prunes every pruneInterval (<= 0 disables
+// periodic pruning; writes still enforce limits for the location written). On cancellation
+// it writes what is already queued and returns. Call Close after Run returns.
+func (s *Store) Run(ctx context.Context, pruneInterval time.Duration) {
+	var tick <-chan time.Time
+	if pruneInterval > 0 {
+		ticker := time.NewTicker(pruneInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			s.write(s.drain(nil))
+			return
+		case obs := <-s.queue:
+			s.write(s.drain([]observation{obs}))
+		case <-tick:
+			if err := s.Prune(time.Now()); err != nil && s.logger != nil {
+				s.logger.Warn("history prune failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// drain appends whatever is queued without blocking, up to a batch size.
+func (s *Store) drain(batch []observation) []observation {
+	for len(batch) < 256 {
+		select {
+		case obs := <-s.queue:
+			batch = append(batch, obs)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// write stores batch in one transaction and trims each written location to its limits.
+func (s *Store) write(batch []observation) {
+	if len(batch) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[string]int, len(batch)) // working copy; applied only on commit
+	cutoff := timeKey(time.Now().Add(-s.cfg.Retention))
+	written, skipped := 0, 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, obs := range batch {
+			n, known := counts[obs.key]
+			if !known {
+				n, known = s.counts[obs.key]
+			}
+			if !known && len(s.counts)+newBuckets(counts, s.counts) >= s.cfg.MaxLocations {
+				skipped++
+				continue
+			}
+			b, err := tx.CreateBucketIfNotExists([]byte(obs.key))
+			if err != nil {
+				return err
+			}
+			if obs.data.Timestamp.IsZero() {
+				obs.data.Timestamp = time.Now()
+			}
+			k := timeKey(obs.data.Timestamp)
+			value, err := json.Marshal(obs.data)
+			if err != nil {
+				return err
+			}
+			if b.Get(k) == nil {
+				n++
+			}
+			if err := b.Put(k, value); err != nil {
+				return err
+			}
+			removed, err := trim(b, cutoff, n, s.cfg.MaxPerLocation)
+			if err != nil {
+				return err
+			}
+			counts[obs.key] = n - removed
+			written++
+		}
+		return nil
+	})
+	if err != nil {
+		observability.HistoryWritesTotal.WithLabelValues("error").Add(float64(len(batch)))
+		if s.logger != nil {
+			s.logger.Warn("history write failed", zap.Int("observations", len(batch)), zap.Error(err))
+		}
+		return
+	}
+	for k, n := range counts {
+		s.counts[k] = n
+	}
+	observability.HistoryWritesTotal.WithLabelValues("written").Add(float64(written))
+	if skipped > 0 {
+		observability.HistoryWritesTotal.WithLabelValues("skipped").Add(float64(skipped))
+	}
+}
+
+// newBuckets counts locations in pending that are not yet in committed.
+func newBuckets(pending, committed map[string]int) int {
+	n := 0
+	for k := range pending {
+		if _, ok := committed[k]; !ok {
+			n++
+		}
+	}
+	return n
+}
+
+// trim deletes keys before cutoff, then the oldest remaining keys while the bucket holds
+// more than max (max <= 0: no size limit). count is the bucket's key count before trimming.
+// Returns the number deleted.
+func trim(b *bolt.Bucket, cutoff []byte, count, max int) (int, error) {
+	removed := 0
+	c := b.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.First() {
+		oversize := max > 0 && count-removed > max
+		if bytes.Compare(k, cutoff) >= 0 && !oversize {
+			break
+		}
+		if err := c.Delete(); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Prune removes observations older than the retention window and drops empty locations.
+// Locations that are no longer fetched are only cleaned up here.
+func (s *Store) Prune(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := timeKey(now.Add(-s.cfg.Retention))
+	counts := make(map[string]int)
+	var empty [][]byte
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		err := tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			removed, err := trim(b, cutoff, s.counts[string(name)], 0)
+			if err != nil {
+				return err
+			}
+			n := s.counts[string(name)] - removed
+			if n <= 0 {
+				empty = append(empty, append([]byte(nil), name...))
+			}
+			counts[string(name)] = n
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, name := range empty {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+			delete(counts, string(name))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("history: prune: %w", err)
+	}
+	s.counts = counts
+	return nil
+}
+
+// Range returns observations for location with since <= timestamp <= until, oldest first.
+// A zero since or until leaves that end open.
+func (s *Store) Range(location string, since, until time.Time) ([]models.WeatherData, error) {
+	out := []models.WeatherData{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(validation.NormalizeLocation(location)))
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		k, v := c.First()
+		if !since.IsZero() {
+			k, v = c.Seek(timeKey(since))
+		}
+		var end []byte
+		if !until.IsZero() {
+			end = timeKey(until)
+		}
+		for ; k != nil; k, v = c.Next() {
+			if end != nil && bytes.Compare(k, end) > 0 {
+				break
+			}
+			var data models.WeatherData
+			if err := json.Unmarshal(v, &data); err != nil {
+				return fmt.Errorf("decode observation: %w", err)
+			}
+			out = append(out, data)
+		}
+		return nil
+	})
+	if errors.Is(err, bolt.ErrDatabaseNotOpen) {
+		return nil, ErrClosed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("history: read %s: %w", location, err)
+	}
+	return out, nil
+}
+
+// Close closes the database. Call after Run has returned.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// timeKey encodes t as a big-endian Unix nanosecond key, so byte order is time order.
+func timeKey(t time.Time) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(t.UnixNano()))
+	return k
+}
@@ -0,0 +1,139 @@
+package history
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+func openTestStore(t *testing.T, cfg Config) *Store {
+	t.Helper()
+	if cfg.Path == "" {
+		cfg.Path = filepath.Join(t.TempDir(), "history.db")
+	}
+	s, err := Open(cfg, nil)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func reading(at time.Time, temp float64) models.WeatherData {
+	return models.WeatherData{Location: "chicago", Temperature: temp, Timestamp: at}
+}
+
+// TestStore_RecordAndRange verifies queued observations are written by Run and read back in
+// time order, bounded by since/until, for the normalized location only.
+func TestStore_RecordAndRange(t *testing.T) {
+	// Arrange
+	s := openTestStore(t, Config{})
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i := 3; i >= 0; i-- { // recorded newest first; Range must still sort by time
+		s.Record(context.Background(), "Chicago", reading(base.Add(time.Duration(i)*10*time.Minute), float64(i)))
+	}
+	s.Record(context.Background(), "boston", reading(base, 99))
+
+	// Act: Run flushes the queue on cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Run(ctx, 0)
+
+	// Assert
+	all, err := s.Range("chicago", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Range() error = %v", err)
+	}
+	if len(all) != 4 || all[0].Temperature != 0 || all[3].Temperature != 3 {
+		t.Fatalf("Range(all) = %+v, want temperatures 0..3 oldest first", all)
+	}
+	window, _ := s.Range(" CHICAGO ", base.Add(10*time.Minute), base.Add(20*time.Minute))
+	if len(window) != 2 || window[0].Temperature != 1 || window[1].Temperature != 2 {
+		t.Errorf("Range(window) = %+v, want temperatures 1 and 2", window)
+	}
+	if none, _ := s.Range("paris", time.Time{}, time.Time{}); none == nil || len(none) != 0 {
+		t.Errorf("Range(unknown) = %#v, want empty non-nil slice", none)
+	}
+}
+
+// TestStore_Limits verifies per-location count, retention on write, and the location cap.
+func TestStore_Limits(t *testing.T) {
+	s := openTestStore(t, Config{MaxPerLocation: 3, MaxLocations: 2, Retention: 2 * time.Hour})
+	now := time.Now()
+	batch := []observation{
+		{key: "chicago", data: reading(now.Add(-3*time.Hour), -1)}, // older than retention
+	}
+	for i := 0; i < 5; i++ {
+		batch = append(batch, observation{key: "chicago", data: reading(now.Add(time.Duration(i-5)*time.Minute), float64(i))})
+	}
+	batch = append(batch,
+		observation{key: "boston", data: reading(now, 1)},
+		observation{key: "paris", data: reading(now, 1)}, // third location: skipped
+	)
+
+	s.write(batch)
+
+	got, _ := s.Range("chicago", time.Time{}, time.Time{})
+	if len(got) != 3 || got[0].Temperature != 2 {
+		t.Errorf("chicago = %+v, want the newest 3 (2, 3, 4)", got)
+	}
+	if got, _ := s.Range("paris", time.Time{}, time.Time{}); len(got) != 0 {
+		t.Errorf("paris = %+v, want skipped at the location cap", got)
+	}
+	if s.counts["chicago"] != 3 || s.counts["boston"] != 1 || len(s.counts) != 2 {
+		t.Errorf("counts = %v, want chicago 3, boston 1", s.counts)
+	}
+}
+
+// TestStore_PrunePersistsAcrossOpen verifies Prune drops expired observations and empty
+// locations, and that what remains survives reopening the file.
+func TestStore_PrunePersistsAcrossOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "history.db")
+	s, err := Open(Config{Path: path, Retention: time.Hour}, nil)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	now := time.Now()
+	s.write([]observation{
+		{key: "chicago", data: reading(now.Add(-30*time.Minute), 1)},
+		{key: "chicago", data: reading(now.Add(-10*time.Minute), 2)},
+		{key: "boston", data: reading(now.Add(-30*time.Minute), 3)},
+	})
+
+	if err := s.Prune(now.Add(40 * time.Minute)); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reopened := openTestStore(t, Config{Path: path, Retention: time.Hour})
+	if got, _ := reopened.Range("chicago", time.Time{}, time.Time{}); len(got) != 1 || got[0].Temperature != 2 {
+		t.Errorf("chicago after prune = %+v, want only temperature 2", got)
+	}
+	if _, ok := reopened.counts["boston"]; ok || reopened.counts["chicago"] != 1 {
+		t.Errorf("counts after reopen = %v, want chicago 1 and boston removed", reopened.counts)
+	}
+}
+
+// TestStore_RecordDropsWhenQueueFull verifies Record never blocks the fetch path.
+func TestStore_RecordDropsWhenQueueFull(t *testing.T) {
+	s := openTestStore(t, Config{QueueSize: 1})
+	done := make(chan struct{})
+	go func() {
+		s.Record(context.Background(), "chicago", reading(time.Now(), 1))
+		s.Record(context.Background(), "chicago", reading(time.Now(), 2))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Record blocked with a full queue")
+	}
+	if len(s.queue) != 1 {
+		t.Errorf("queued = %d, want 1", len(s.queue))
+	}
+}
@@ -0,0 +1,69 @@
+package history
+
+import (
+	"math"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// Series summarizes one numeric field over a window.
+type Series struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Mean  float64 `json:"mean"`
+	First float64 `json:"first"`
+	Last  float64 `json:"last"`
+	// Change is Last - First.
+	Change float64 `json:"change"`
+	// RatePerHour is the least-squares slope in units per hour; nil with fewer than two
+	// observations at distinct times.
+	RatePerHour *float64 `json:"ratePerHour"`
+}
+
+// Trend summarizes observations over a window. Series are nil when Samples is 0.
+type Trend struct {
+	Samples     int       `json:"samples"`
+	From        time.Time `json:"from,omitempty"` // first observation in the window
+	To          time.Time `json:"to,omitempty"`   // last observation in the window
+	Temperature *Series   `json:"temperature"`
+	WindSpeed   *Series   `json:"windSpeed"`
+	Humidity    *Series   `json:"humidity"`
+}
+
+// Summarize computes a Trend from observations sorted oldest first, as returned by Range.
+func Summarize(obs []models.WeatherData) Trend {
+	t := Trend{Samples: len(obs)}
+	if len(obs) == 0 {
+		return t
+	}
+	t.From, t.To = obs[0].Timestamp, obs[len(obs)-1].Timestamp
+	t.Temperature = summarize(obs, func(d models.WeatherData) float64 { return d.Temperature })
+	t.WindSpeed = summarize(obs, func(d models.WeatherData) float64 { return d.WindSpeed })
+	t.Humidity = summarize(obs, func(d models.WeatherData) float64 { return float64(d.Humidity) })
+	return t
+}
+
+func summarize(obs []models.WeatherData, get func(models.WeatherData) float64) *Series {
+	s := &Series{Min: math.Inf(1), Max: math.Inf(-1), First: get(obs[0]), Last: get(obs[len(obs)-1])}
+	s.Change = s.Last - s.First
+	origin := obs[0].Timestamp
+	var sumX, sumY, sumXY, sumXX float64
+	for _, d := range obs {
+		y := get(d)
+		x := d.Timestamp.Sub(origin).Hours()
+		s.Min = math.Min(s.Min, y)
+		s.Max = math.Max(s.Max, y)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	n := float64(len(obs))
+	s.Mean = sumY / n
+	if denom := n*sumXX - sumX*sumX; len(obs) >= 2 && denom > 1e-12 {
+		rate := (n*sumXY - sumX*sumY) / denom
+		s.RatePerHour = &rate
+	}
+	return s
+}
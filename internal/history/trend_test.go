@@ -0,0 +1,52 @@
+package history
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// TestSummarize verifies min/max/mean, change and the per-hour rate.
+func TestSummarize(t *testing.T) {
+	// Arrange: cooling 2°C/hour, wind rising 1 m/s per half hour
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	obs := []models.WeatherData{
+		{Temperature: 10, WindSpeed: 2, Humidity: 50, Timestamp: base},
+		{Temperature: 9, WindSpeed: 3, Humidity: 60, Timestamp: base.Add(30 * time.Minute)},
+		{Temperature: 8, WindSpeed: 4, Humidity: 70, Timestamp: base.Add(time.Hour)},
+	}
+
+	// Act
+	trend := Summarize(obs)
+
+	// Assert
+	if trend.Samples != 3 || !trend.From.Equal(base) || !trend.To.Equal(base.Add(time.Hour)) {
+		t.Errorf("samples/from/to = %d %v %v", trend.Samples, trend.From, trend.To)
+	}
+	temp := trend.Temperature
+	if temp.Min != 8 || temp.Max != 10 || temp.Mean != 9 || temp.Change != -2 {
+		t.Errorf("temperature = %+v, want min 8 max 10 mean 9 change -2", temp)
+	}
+	if temp.RatePerHour == nil || math.Abs(*temp.RatePerHour+2) > 1e-9 {
+		t.Errorf("temperature rate = %v, want -2/hour", temp.RatePerHour)
+	}
+	if wind := trend.WindSpeed; wind.Change != 2 || wind.RatePerHour == nil || math.Abs(*wind.RatePerHour-2) > 1e-9 {
+		t.Errorf("wind = %+v, want change 2 and 2/hour", wind)
+	}
+	if trend.Humidity.Mean != 60 {
+		t.Errorf("humidity mean = %v, want 60", trend.Humidity.Mean)
+	}
+}
+
+// TestSummarize_TooFewSamples verifies empty input has no series and one sample has no rate.
+func TestSummarize_TooFewSamples(t *testing.T) {
+	if trend := Summarize(nil); trend.Samples != 0 || trend.Temperature != nil {
+		t.Errorf("Summarize(nil) = %+v, want zero samples and nil series", trend)
+	}
+	one := Summarize([]models.WeatherData{{Temperature: 5, Timestamp: time.Now()}})
+	if one.Temperature == nil || one.Temperature.Mean != 5 || one.Temperature.RatePerHour != nil {
+		t.Errorf("Summarize(one) temperature = %+v, want mean 5 and nil rate", one.Temperature)
+	}
+}
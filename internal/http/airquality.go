@@ -0,0 +1,44 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kjstillabower/weather-alert-service/internal/client"
+	"github.com/kjstillabower/weather-alert-service/internal/degraded"
+	"github.com/kjstillabower/weather-alert-service/internal/idle"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// GetAirQuality handles GET /weather/{location}/air-quality. Returns the current AQI (1-5) and
+// pollutant concentrations. Returns 404 AIR_QUALITY_UNAVAILABLE when the provider has no reading
+// for the location and 501 AIR_QUALITY_UNSUPPORTED when the provider has no air quality data.
+func (h *Handler) GetAirQuality(w http.ResponseWriter, r *http.Request) {
+	location, err := validation.ValidateLocation(mux.Vars(r)["location"], h.locationMinLength, h.locationMaxLength)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", validationErrorMessage(err))
+		return
+	}
+
+	idle.RecordRequest()
+	aq, err := h.weatherService.GetAirQuality(r.Context(), location)
+	switch {
+	case errors.Is(err, client.ErrAirQualityUnsupported):
+		observability.HTTPErrorsTotal.WithLabelValues(r.Method, getRoute(r), string(client.ErrorCategoryAirQualityUnsupported)).Inc()
+		writeError(w, r, http.StatusNotImplemented, "AIR_QUALITY_UNSUPPORTED", "air quality is not available from the configured weather provider")
+		return
+	case errors.Is(err, client.ErrAirQualityUnavailable):
+		observability.HTTPErrorsTotal.WithLabelValues(r.Method, getRoute(r), string(client.ErrorCategoryAirQualityUnavailable)).Inc()
+		writeError(w, r, http.StatusNotFound, "AIR_QUALITY_UNAVAILABLE", "no air quality reading for this location")
+		return
+	case err != nil:
+		degraded.RecordError()
+		writeServiceError(w, r, err)
+		return
+	}
+	degraded.RecordSuccess()
+	writeJSON(w, http.StatusOK, aq)
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/client"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// airQualityWeatherClient is a mockWeatherClient that reports air quality.
+type airQualityWeatherClient struct {
+	mockWeatherClient
+	airQuality models.AirQuality
+	aqErr      error
+}
+
+func (m *airQualityWeatherClient) GetAirQuality(ctx context.Context, location string) (models.AirQuality, error) {
+	return m.airQuality, m.aqErr
+}
+
+var airQualityRoute = testRoute{"GET", "/weather/{location}/air-quality", (*Handler).GetAirQuality}
+
+// TestHandler_GetAirQuality verifies the AQI and pollutant concentrations are returned as JSON.
+func TestHandler_GetAirQuality(t *testing.T) {
+	// Arrange
+	router := newTestRouter(newTestHandler(&airQualityWeatherClient{airQuality: models.AirQuality{
+		Location:   "beijing",
+		AQI:        4,
+		Category:   "poor",
+		Pollutants: models.Pollutants{PM25: 61.2, PM10: 88, O3: 40.1, NO2: 35.6},
+		Timestamp:  time.Now(),
+	}}, nil), airQualityRoute)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/beijing/air-quality", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	pollutants, _ := resp["pollutants"].(map[string]interface{})
+	if resp["aqi"] != float64(4) || resp["category"] != "poor" || pollutants["pm25"] != 61.2 || pollutants["no2"] != 35.6 {
+		t.Errorf("response = %v, want AQI 4 (poor) with pollutants", resp)
+	}
+}
+
+// TestHandler_GetAirQuality_Errors verifies invalid input, missing readings, providers without
+// air quality, and upstream failures.
+func TestHandler_GetAirQuality_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		client     client.WeatherClient
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "invalid location", path: "/weather/beijing%3B/air-quality", client: &airQualityWeatherClient{}, wantStatus: http.StatusBadRequest, wantCode: "INVALID_LOCATION"},
+		{name: "no reading", path: "/weather/beijing/air-quality", client: &airQualityWeatherClient{aqErr: fmt.Errorf("%w for beijing", client.ErrAirQualityUnavailable)}, wantStatus: http.StatusNotFound, wantCode: "AIR_QUALITY_UNAVAILABLE"},
+		{name: "provider unsupported", path: "/weather/beijing/air-quality", client: &mockWeatherClient{}, wantStatus: http.StatusNotImplemented, wantCode: "AIR_QUALITY_UNSUPPORTED"},
+		{name: "upstream failure", path: "/weather/beijing/air-quality", client: &airQualityWeatherClient{aqErr: errors.New("upstream down")}, wantStatus: http.StatusServiceUnavailable, wantCode: "UPSTREAM_UNAVAILABLE"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newTestRouter(newTestHandler(tc.client, nil), airQualityRoute).ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/alerts"
+)
+
+// SetAlertEngine attaches the threshold alert engine served by GET /alerts.
+// When unset, GET /alerts returns an empty list.
+func (h *Handler) SetAlertEngine(engine *alerts.Engine) {
+	h.alertEngine = engine
+}
+
+// GetAlerts handles GET /alerts. Returns firing and recently resolved rule states.
+// Optional query parameter status=firing|resolved filters the list.
+func (h *Handler) GetAlerts(w http.ResponseWriter, r *http.Request) {
+	filter := alerts.Status(strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status"))))
+	switch filter {
+	case "", alerts.StatusFiring, alerts.StatusResolved:
+	default:
+		writeError(w, r, http.StatusBadRequest, "INVALID_STATUS", "status must be firing or resolved")
+		return
+	}
+
+	states := []alerts.State{}
+	if h.alertEngine != nil {
+		for _, st := range h.alertEngine.States() {
+			if filter == "" || st.Status == filter {
+				states = append(states, st)
+			}
+		}
+	}
+	firing := 0
+	for _, st := range states {
+		if st.Status == alerts.StatusFiring {
+			firing++
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"alerts":    states,
+		"firing":    firing,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/alerts"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/service"
+)
+
+// TestHandler_GetAlerts_FromFreshFetch verifies that a weather fetch evaluated by the alert
+// engine (registered as a fetch hook) is reported as firing by GET /alerts.
+func TestHandler_GetAlerts_FromFreshFetch(t *testing.T) {
+	// Arrange: engine with a wind rule wired into the service, upstream reports high wind
+	engine, err := alerts.NewEngine([]alerts.RuleConfig{
+		{Name: "high-wind", Type: "wind_above", Threshold: 15, Severity: "critical"},
+	}, 0, nil)
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	mockClient := &mockWeatherClient{weather: models.WeatherData{Location: "chicago", WindSpeed: 22, Timestamp: time.Now()}}
+	weatherService := service.NewWeatherService(mockClient, &mockCache{}, 5*time.Minute, 0, false, 0)
+	weatherService.AddFetchHook(engine.Observe)
+	logger := zap.NewNop()
+	handler := NewHandler(weatherService, mockClient, nil, logger, nil, 100, 1)
+	handler.SetAlertEngine(engine)
+
+	router := newTestRouter(handler, getWeatherRoute, testRoute{"GET", "/alerts", (*Handler).GetAlerts})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/weather/chicago", nil))
+
+	// Act: query alerts
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/alerts?status=firing", nil))
+
+	// Assert: one firing critical alert for chicago
+	if w.Code != http.StatusOK {
+		t.Fatalf("GetAlerts() status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp struct {
+		Alerts []alerts.State `json:"alerts"`
+		Firing int            `json:"firing"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Firing != 1 || len(resp.Alerts) != 1 {
+		t.Fatalf("response = %+v, want one firing alert", resp)
+	}
+	got := resp.Alerts[0]
+	if got.Rule != "high-wind" || got.Location != "chicago" || got.Severity != alerts.SeverityCritical || got.Value != "22" {
+		t.Errorf("alert = %+v, want high-wind/chicago/critical value 22", got)
+	}
+}
+
+// TestHandler_GetAlerts_InvalidStatus verifies that an unknown status filter returns 400
+// with the standard error shape.
+func TestHandler_GetAlerts_InvalidStatus(t *testing.T) {
+	handler := NewHandler(nil, &mockWeatherClient{}, nil, zap.NewNop(), nil, 100, 1)
+
+	req := httptest.NewRequest("GET", "/alerts?status=pending", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "correlation_id", "test-correlation-id"))
+	w := httptest.NewRecorder()
+	handler.GetAlerts(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("GetAlerts() status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	var resp map[string]map[string]string
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp["error"]["code"] != "INVALID_STATUS" || resp["error"]["requestId"] != "test-correlation-id" {
+		t.Errorf("error = %v, want INVALID_STATUS with requestId", resp["error"])
+	}
+}
+
+// TestHandler_GetAlerts_NoEngine verifies that GET /alerts returns an empty list when no
+// engine is attached.
+func TestHandler_GetAlerts_NoEngine(t *testing.T) {
+	handler := NewHandler(nil, &mockWeatherClient{}, nil, zap.NewNop(), nil, 100, 1)
+
+	w := httptest.NewRecorder()
+	handler.GetAlerts(w, httptest.NewRequest("GET", "/alerts", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("GetAlerts() status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp map[string]interface{}
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if list, ok := resp["alerts"].([]interface{}); !ok || len(list) != 0 {
+		t.Errorf("alerts = %v, want empty list", resp["alerts"])
+	}
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/client"
+	"github.com/kjstillabower/weather-alert-service/internal/degraded"
+	"github.com/kjstillabower/weather-alert-service/internal/idle"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+const (
+	// defaultBatchMaxLocations is the batch size limit when SetBatchLimits is not called.
+	defaultBatchMaxLocations = 50
+	// defaultBatchConcurrency is the number of locations fetched at once when SetBatchLimits is
+	// not called.
+	defaultBatchConcurrency = 8
+	// maxBatchBodyBytes caps POST /weather/batch bodies, leaving room for a few hundred
+	// locations of maximum default length.
+	maxBatchBodyBytes = 64 << 10
+)
+
+// SetBatchLimits sets the maximum locations per POST /weather/batch request and how many are
+// fetched concurrently. Values <= 0 keep the defaults (50 and 8).
+func (h *Handler) SetBatchLimits(maxLocations, concurrency int) {
+	h.batchMaxLocations = maxLocations
+	h.batchConcurrency = concurrency
+}
+
+// batchResult is one entry of a batch response: Weather on success, Error otherwise.
+type batchResult struct {
+	Location string              `json:"location"`
+	Weather  *models.WeatherData `json:"weather,omitempty"`
+	Error    *batchError         `json:"error,omitempty"`
+	fetchErr bool                // Error came from the weather service rather than validation
+}
+
+// batchError describes why one location failed. Category is a client.ErrorCategory value
+// ("validation" for locations rejected before any fetch).
+type batchError struct {
+	Category string `json:"category"`
+	Message  string `json:"message"`
+}
+
+// GetWeatherBatch handles POST /weather/batch. The body is {"locations": ["...", ...]}; each
+// location is validated and fetched through the weather service with bounded concurrency, and
+// the response lists a result or error per location in request order. The request consumes
+// one rate-limit token per location (the middleware takes the first). Accepts units and lang
+// as GetWeather. Honors Accept like GetWeather; CSV and NDJSON have one record per location.
+func (h *Handler) GetWeatherBatch(w http.ResponseWriter, r *http.Request) {
+	maxLocations := h.batchMaxLocations
+	if maxLocations <= 0 {
+		maxLocations = defaultBatchMaxLocations
+	}
+	var body struct {
+		Locations []string `json:"locations"`
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&body); err != nil || len(body.Locations) == 0 {
+		writeError(w, r, http.StatusBadRequest, "INVALID_BODY", `request body must be {"locations": ["...", ...]} with at least one location`)
+		return
+	}
+	if len(body.Locations) > maxLocations {
+		writeError(w, r, http.StatusBadRequest, "TOO_MANY_LOCATIONS", fmt.Sprintf("batch is limited to %d locations", maxLocations))
+		return
+	}
+	units, lang, ok := weatherFormatParams(w, r)
+	if !ok {
+		return
+	}
+	if h.rateLimiter != nil && len(body.Locations) > 1 && !h.rateLimiter.AllowN(time.Now(), len(body.Locations)-1) {
+		rejectRateLimited(w, r)
+		return
+	}
+
+	idle.RecordRequest()
+	results := h.fetchLocations(r, body.Locations, units, lang)
+	writeNegotiated(w, r, http.StatusOK, representation{
+		body:    map[string]interface{}{"results": results},
+		xmlRoot: "batch",
+		rows:    rowsOf(results),
+		columns: locationResultCSVColumns,
+	})
+}
+
+// fetchLocations validates each location and fetches it through the weather service with
+// bounded concurrency (see SetBatchLimits), returning a result per location in order. Fetch
+// failures are counted in the HTTP error metrics, and degraded mode records an error only when
+// every fetch failed.
+func (h *Handler) fetchLocations(r *http.Request, locations []string, units, lang string) []batchResult {
+	results := make([]batchResult, len(locations))
+	concurrency := h.batchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, raw := range locations {
+		results[i].Location = raw
+		location, err := validation.ValidateLocation(raw, h.locationMinLength, h.locationMaxLength)
+		if err != nil {
+			results[i].Error = &batchError{Category: string(client.ErrorCategoryValidation), Message: validationErrorMessage(err)}
+			continue
+		}
+		wg.Add(1)
+		go func(res *batchResult) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			data, err := h.weatherService.GetLocalizedWeather(r.Context(), location, lang)
+			if err != nil {
+				res.Error = &batchError{Category: string(client.CategorizeError(err)), Message: "Unable to fetch weather data"}
+				res.fetchErr = true
+				return
+			}
+			data = data.InUnits(units)
+			res.Weather = &data
+		}(&results[i])
+	}
+	wg.Wait()
+
+	var fetched, failed int
+	for _, res := range results {
+		switch {
+		case res.Weather != nil:
+			fetched++
+		case res.fetchErr:
+			failed++
+			observability.HTTPErrorsTotal.WithLabelValues(r.Method, getRoute(r), res.Error.Category).Inc()
+		}
+	}
+	if failed > 0 && fetched == 0 {
+		degraded.RecordError()
+	} else {
+		degraded.RecordSuccess()
+	}
+	return results
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/kjstillabower/weather-alert-service/internal/cache"
+	"github.com/kjstillabower/weather-alert-service/internal/client"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/service"
+)
+
+// perLocationWeatherClient returns weather named after the location, or ErrLocationNotFound for
+// locations in missing, after an optional delay.
+type perLocationWeatherClient struct {
+	mockWeatherClient
+	missing map[string]bool
+	delay   time.Duration
+}
+
+func (m *perLocationWeatherClient) GetCurrentWeather(ctx context.Context, location string) (models.WeatherData, error) {
+	time.Sleep(m.delay)
+	if m.missing[location] {
+		return models.WeatherData{}, fmt.Errorf("%w", client.ErrLocationNotFound)
+	}
+	return models.WeatherData{Location: location, Temperature: 20}, nil
+}
+
+func newBatchRouter(limiter *rate.Limiter, maxLocations int) *mux.Router {
+	handler := newTestHandler(&perLocationWeatherClient{missing: map[string]bool{"atlantis": true}}, limiter)
+	handler.SetBatchLimits(maxLocations, 2)
+	return newTestRouter(handler, testRoute{"POST", "/weather/batch", (*Handler).GetWeatherBatch})
+}
+
+// TestHandler_GetWeatherBatch_InMemoryCache verifies a batch fetched concurrently through the
+// real in-memory cache returns every location; run with -race to check the cache's locking.
+func TestHandler_GetWeatherBatch_InMemoryCache(t *testing.T) {
+	// Arrange: more locations than workers, with repeats so workers hit the same keys while
+	// others are mid-fetch
+	mockClient := &perLocationWeatherClient{delay: time.Millisecond}
+	weatherService := service.NewWeatherService(mockClient, cache.NewInMemoryCache(), 5*time.Minute, time.Hour, false, 0)
+	handler := NewHandler(weatherService, mockClient, nil, zap.NewNop(), nil, 100, 1)
+	handler.SetBatchLimits(50, 8)
+	router := newTestRouter(handler, testRoute{"POST", "/weather/batch", (*Handler).GetWeatherBatch})
+	locations := make([]string, 0, 48)
+	for i := 0; i < 48; i++ {
+		locations = append(locations, fmt.Sprintf("%q", fmt.Sprintf("city %d", i%12)))
+	}
+	body := `{"locations": [` + strings.Join(locations, ", ") + `]}`
+
+	for round := 0; round < 3; round++ {
+		// Act
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/weather/batch", strings.NewReader(body)))
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("round %d: status = %d, want %d", round, w.Code, http.StatusOK)
+		}
+		var resp struct {
+			Results []batchResult `json:"results"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("round %d: decode: %v", round, err)
+		}
+		if len(resp.Results) != 48 {
+			t.Fatalf("round %d: results = %d, want 48", round, len(resp.Results))
+		}
+		for i, res := range resp.Results {
+			if res.Weather == nil || res.Weather.Location != fmt.Sprintf("city %d", i%12) {
+				t.Errorf("round %d: result %d = %+v", round, i, res)
+			}
+		}
+	}
+}
+
+// TestHandler_GetWeatherBatch verifies results and per-location errors are returned in request
+// order, with units applied to each result.
+func TestHandler_GetWeatherBatch(t *testing.T) {
+	// Arrange
+	router := newBatchRouter(nil, 10)
+	body := `{"locations": ["Chicago", "chicago;", "atlantis", "denver"]}`
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/weather/batch?units=imperial", strings.NewReader(body)))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var resp struct {
+		Results []struct {
+			Location string              `json:"location"`
+			Weather  *models.WeatherData `json:"weather"`
+			Error    *batchError         `json:"error"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Results) != 4 {
+		t.Fatalf("results = %d, want 4", len(resp.Results))
+	}
+	wantErrors := []string{"", "validation", "location_not_found", ""}
+	for i, res := range resp.Results {
+		switch {
+		case wantErrors[i] == "":
+			if res.Weather == nil || res.Weather.Temperature != 68 || res.Weather.Units != "imperial" {
+				t.Errorf("results[%d] = %+v, want imperial weather", i, res)
+			}
+		case res.Error == nil || res.Error.Category != wantErrors[i]:
+			t.Errorf("results[%d].error = %+v, want category %q", i, res.Error, wantErrors[i])
+		}
+	}
+	if resp.Results[1].Location != "chicago;" {
+		t.Errorf("results[1].location = %q, want request value", resp.Results[1].Location)
+	}
+}
+
+// TestHandler_GetWeatherBatch_Errors verifies malformed bodies, oversized batches, and batches
+// that need more rate-limit tokens than are available.
+func TestHandler_GetWeatherBatch_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		limiter    *rate.Limiter
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "not json", body: `chicago`, wantStatus: http.StatusBadRequest, wantCode: "INVALID_BODY"},
+		{name: "empty list", body: `{"locations": []}`, wantStatus: http.StatusBadRequest, wantCode: "INVALID_BODY"},
+		{name: "unknown field", body: `{"cities": ["chicago"]}`, wantStatus: http.StatusBadRequest, wantCode: "INVALID_BODY"},
+		{name: "too many", body: `{"locations": ["a", "b", "c", "d"]}`, wantStatus: http.StatusBadRequest, wantCode: "TOO_MANY_LOCATIONS"},
+		{name: "rate limited", body: `{"locations": ["a", "b", "c"]}`, limiter: rate.NewLimiter(rate.Every(time.Hour), 1), wantStatus: http.StatusTooManyRequests, wantCode: "RATE_LIMITED"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newBatchRouter(tc.limiter, 3).ServeHTTP(w, httptest.NewRequest("POST", "/weather/batch", strings.NewReader(tc.body)))
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kjstillabower/weather-alert-service/internal/changes"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// SetChangeLog attaches the change-event log served by GET /weather/{location}/changes.
+// When unset, that endpoint returns 503.
+func (h *Handler) SetChangeLog(log *changes.Log) {
+	h.changeLog = log
+}
+
+// GetWeatherChanges handles GET /weather/{location}/changes. Returns material changes recorded
+// for the location, oldest first. Optional query parameter since (RFC 3339) returns only
+// changes detected after that time.
+func (h *Handler) GetWeatherChanges(w http.ResponseWriter, r *http.Request) {
+	if h.changeLog == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "CHANGES_DISABLED", "change log is not enabled")
+		return
+	}
+	location, err := validation.ValidateLocation(mux.Vars(r)["location"], h.locationMinLength, h.locationMaxLength)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", validationErrorMessage(err))
+		return
+	}
+	since, ok := queryTime(w, r, "since")
+	if !ok {
+		return
+	}
+
+	events := h.changeLog.Since(location, since)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"location": location,
+		"changes":  events,
+		"count":    len(events),
+	})
+}
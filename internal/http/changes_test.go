@@ -0,0 +1,100 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/changes"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/service"
+)
+
+// TestHandler_GetWeatherChanges_FromFreshFetches verifies that two fresh fetches with different
+// conditions produce one change event served by GET /weather/{location}/changes.
+func TestHandler_GetWeatherChanges_FromFreshFetches(t *testing.T) {
+	// Arrange: change log wired into the service as in main
+	changeLog := changes.NewLog(changes.Config{})
+	mockClient := &mockWeatherClient{weather: models.WeatherData{Location: "chicago", Temperature: 1, Conditions: "Clouds", Timestamp: time.Now()}}
+	mc := &mockCache{}
+	weatherService := service.NewWeatherService(mockClient, mc, 5*time.Minute, 0, false, 0)
+	weatherService.AddFetchHook(changeLog.Observe)
+	handler := NewHandler(weatherService, mockClient, nil, zap.NewNop(), nil, 100, 1)
+	handler.SetChangeLog(changeLog)
+	router := newTestRouter(handler, getWeatherRoute, testRoute{"GET", "/weather/{location}/changes", (*Handler).GetWeatherChanges})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/weather/chicago", nil))
+	mc.data = nil // expire the cache so the next request fetches upstream
+	mockClient.weather.Conditions = "Snow"
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/weather/chicago", nil))
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/Chicago/changes", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp struct {
+		Changes []changes.Event `json:"changes"`
+		Count   int             `json:"count"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Count != 1 || len(resp.Changes) != 1 {
+		t.Fatalf("response = %+v, want one change", resp)
+	}
+	if ev := resp.Changes[0]; ev.Before.Conditions != "Clouds" || ev.After.Conditions != "Snow" || ev.Reasons[0] != changes.ReasonConditions {
+		t.Errorf("change = %+v, want Clouds -> Snow (conditions)", ev)
+	}
+
+	// A since after the change filters it out
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/chicago/changes?since="+time.Now().Add(time.Minute).UTC().Format(time.RFC3339), nil))
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Count != 0 {
+		t.Errorf("count with future since = %d, want 0", resp.Count)
+	}
+}
+
+// TestHandler_GetWeatherChanges_Errors verifies disabled, invalid location and invalid since.
+func TestHandler_GetWeatherChanges_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		path       string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "disabled", path: "/weather/chicago/changes", wantStatus: http.StatusServiceUnavailable, wantCode: "CHANGES_DISABLED"},
+		{name: "invalid location", enabled: true, path: "/weather/%3Cscript%3E/changes", wantStatus: http.StatusBadRequest, wantCode: "INVALID_LOCATION"},
+		{name: "invalid since", enabled: true, path: "/weather/chicago/changes?since=yesterday", wantStatus: http.StatusBadRequest, wantCode: "INVALID_SINCE"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newTestHandler(&mockWeatherClient{}, nil)
+			if tc.enabled {
+				handler.SetChangeLog(changes.NewLog(changes.Config{}))
+			}
+			router := newTestRouter(handler, testRoute{"GET", "/weather/{location}/changes", (*Handler).GetWeatherChanges})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			_ = json.NewDecoder(w.Body).Decode(&resp)
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
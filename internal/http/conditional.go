@@ -0,0 +1,84 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// writeWeather writes current weather in the format negotiated from Accept (JSON, CSV, NDJSON
+// or XML) with validators and caching headers: a strong ETag over the body, Last-Modified
+// from the observation time, and Cache-Control max-age for the time the data has left in the
+// service cache. Stale data gets max-age=0 plus Warning and X-Data-Stale headers. Conditional
+// requests (If-None-Match, then If-Modified-Since) that match get 304 Not Modified with no
+// body.
+func (h *Handler) writeWeather(w http.ResponseWriter, r *http.Request, data models.WeatherData) {
+	format := negotiateFormat(r)
+	body, err := representation{body: data, xmlRoot: "weather", rows: []interface{}{data}, columns: weatherCSVColumns}.encode(format)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "Unable to encode weather data")
+		return
+	}
+	w.Header().Add("Vary", "Accept")
+	h.writeCacheable(w, r, data, body, format.contentType(), entityTag(format.contentType(), body))
+}
+
+// writeCacheable writes body, of contentType and derived from data, with the headers and
+// conditional handling described on writeWeather, using etag as the response's entity tag.
+func (h *Handler) writeCacheable(w http.ResponseWriter, r *http.Request, data models.WeatherData, body []byte, contentType, etag string) {
+	header := w.Header()
+	header.Set("ETag", etag)
+	if !data.Timestamp.IsZero() {
+		header.Set("Last-Modified", data.Timestamp.UTC().Format(http.TimeFormat))
+	}
+	maxAge := h.weatherService.CacheMaxAge(data)
+	header.Set("Cache-Control", "public, max-age="+strconv.Itoa(int(maxAge/time.Second)))
+	if data.Stale {
+		header.Set("Warning", `110 - "Response is Stale"`)
+		header.Set("X-Data-Stale", "true")
+	}
+
+	if notModified(r, etag, data.Timestamp) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	header.Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// entityTag returns a strong entity tag for body of contentType: the first 8 bytes of their
+// SHA-256, quoted. Including the content type keeps tags distinct across representations
+// whose bytes coincide (JSON and single-line NDJSON).
+func entityTag(contentType string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(contentType))
+	h.Write([]byte{0})
+	h.Write(body)
+	return `"` + hex.EncodeToString(h.Sum(nil)[:8]) + `"`
+}
+
+// notModified reports whether the request's validators match. If-None-Match takes precedence
+// over If-Modified-Since (RFC 9110 13.2.2) and uses weak comparison.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		etag = strings.TrimPrefix(etag, "W/")
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ims)
+		return err == nil && !lastModified.Truncate(time.Second).After(since)
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/service"
+)
+
+// TestHandler_GetWeather_CacheHeaders verifies validators and max-age on a fresh response.
+func TestHandler_GetWeather_CacheHeaders(t *testing.T) {
+	// Arrange
+	observed := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	router := newTestRouter(newTestHandler(&mockWeatherClient{weather: models.WeatherData{Location: "chicago", Timestamp: observed, FetchedAt: time.Now()}}, nil), getWeatherRoute)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/chicago", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if etag := w.Header().Get("ETag"); !strings.HasPrefix(etag, `"`) || len(etag) != 18 {
+		t.Errorf("ETag = %q, want quoted 16-hex-digit tag", etag)
+	}
+	if got := w.Header().Get("Last-Modified"); got != "Wed, 10 Jan 2024 12:00:00 GMT" {
+		t.Errorf("Last-Modified = %q", got)
+	}
+	if got := w.Header().Get("Cache-Control"); got != "public, max-age=299" && got != "public, max-age=300" {
+		t.Errorf("Cache-Control = %q, want max-age of about the 5m TTL", got)
+	}
+	if w.Header().Get("X-Data-Stale") != "" {
+		t.Error("X-Data-Stale set on fresh data")
+	}
+}
+
+// TestHandler_GetWeather_ConditionalRequests verifies If-None-Match and If-Modified-Since.
+func TestHandler_GetWeather_ConditionalRequests(t *testing.T) {
+	observed := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	router := newTestRouter(newTestHandler(&mockWeatherClient{weather: models.WeatherData{Location: "chicago", Timestamp: observed, FetchedAt: time.Now()}}, nil), getWeatherRoute)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/chicago", nil))
+	etag := w.Header().Get("ETag")
+
+	tests := []struct {
+		name       string
+		header     string
+		value      string
+		wantStatus int
+	}{
+		{name: "etag match", header: "If-None-Match", value: etag, wantStatus: http.StatusNotModified},
+		{name: "weak etag in list", header: "If-None-Match", value: `"other", W/` + etag, wantStatus: http.StatusNotModified},
+		{name: "etag mismatch", header: "If-None-Match", value: `"other"`, wantStatus: http.StatusOK},
+		{name: "not modified since", header: "If-Modified-Since", value: "Wed, 10 Jan 2024 12:00:00 GMT", wantStatus: http.StatusNotModified},
+		{name: "modified since", header: "If-Modified-Since", value: "Wed, 10 Jan 2024 11:59:59 GMT", wantStatus: http.StatusOK},
+		{name: "invalid date", header: "If-Modified-Since", value: "yesterday", wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/weather/chicago", nil)
+			req.Header.Set(tc.header, tc.value)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			if tc.wantStatus == http.StatusNotModified && (w.Body.Len() != 0 || w.Header().Get("ETag") != etag) {
+				t.Errorf("304 body = %q, ETag = %q", w.Body.String(), w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+// TestHandler_GetWeather_StaleHeaders verifies stale data is marked and not cacheable.
+func TestHandler_GetWeather_StaleHeaders(t *testing.T) {
+	// Arrange: upstream down, stale entry available
+	mockClient := &mockWeatherClient{err: errors.New("upstream down")}
+	cache := &staleOnlyCache{stale: models.WeatherData{Location: "chicago", Timestamp: time.Now(), FetchedAt: time.Now().Add(-10 * time.Minute)}}
+	weatherService := service.NewWeatherService(mockClient, cache, 5*time.Minute, time.Hour, false, 0)
+	handler := NewHandler(weatherService, mockClient, nil, zap.NewNop(), nil, 100, 1)
+	router := newTestRouter(handler, getWeatherRoute)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/chicago", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if w.Header().Get("X-Data-Stale") != "true" || !strings.HasPrefix(w.Header().Get("Warning"), "110") {
+		t.Errorf("X-Data-Stale = %q, Warning = %q", w.Header().Get("X-Data-Stale"), w.Header().Get("Warning"))
+	}
+	if got := w.Header().Get("Cache-Control"); got != "public, max-age=0" {
+		t.Errorf("Cache-Control = %q, want max-age=0", got)
+	}
+}
+
+// staleOnlyCache misses on Get and returns stale from GetStale.
+type staleOnlyCache struct {
+	mockCache
+	stale models.WeatherData
+}
+
+func (c *staleOnlyCache) Get(ctx context.Context, key string) (models.WeatherData, bool, error) {
+	return models.WeatherData{}, false, nil
+}
+
+func (c *staleOnlyCache) GetStale(ctx context.Context, key string, maxStaleAge time.Duration) (models.WeatherData, bool, error) {
+	return c.stale, true, nil
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// GetWeatherByCoordinates handles GET /weather?lat=..&lon=... Coordinates are quantized with
+// validation.CoordinateKey, so nearby requests share cache entries and upstream calls, and the
+// response location is the upstream's nearest place name. Accepts units and lang as GetWeather.
+func (h *Handler) GetWeatherByCoordinates(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	lat, lon, err := validation.ValidateCoordinates(query.Get("lat"), query.Get("lon"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_COORDINATES", err.Error())
+		return
+	}
+	h.serveWeather(w, r, validation.CoordinateKey(lat, lon), h.writeWeather)
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// recordingWeatherClient records the locations passed to GetCurrentWeather.
+type recordingWeatherClient struct {
+	mockWeatherClient
+	mu        sync.Mutex
+	locations []string
+}
+
+func (m *recordingWeatherClient) GetCurrentWeather(ctx context.Context, location string) (models.WeatherData, error) {
+	m.mu.Lock()
+	m.locations = append(m.locations, location)
+	m.mu.Unlock()
+	return m.mockWeatherClient.GetCurrentWeather(ctx, location)
+}
+
+var coordinatesRoute = testRoute{"GET", "/weather", (*Handler).GetWeatherByCoordinates}
+
+// TestHandler_GetWeatherByCoordinates verifies coordinates are quantized to the cache key passed
+// upstream and the weather is returned as JSON.
+func TestHandler_GetWeatherByCoordinates(t *testing.T) {
+	// Arrange
+	client := &recordingWeatherClient{mockWeatherClient: mockWeatherClient{weather: models.WeatherData{Location: "chicago", Temperature: 12.5}}}
+	router := newTestRouter(newTestHandler(client, nil), coordinatesRoute)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather?lat=41.8781&lon=-87.6298", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp models.WeatherData
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Location != "chicago" || resp.Temperature != 12.5 {
+		t.Errorf("response = %+v", resp)
+	}
+	if len(client.locations) != 1 || client.locations[0] != "41.88,-87.63" {
+		t.Errorf("upstream locations = %v, want [41.88,-87.63]", client.locations)
+	}
+}
+
+// TestHandler_GetWeatherByCoordinates_Errors verifies invalid coordinates and upstream failures.
+func TestHandler_GetWeatherByCoordinates_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		client     *mockWeatherClient
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "missing lon", path: "/weather?lat=41.88", client: &mockWeatherClient{}, wantStatus: http.StatusBadRequest, wantCode: "INVALID_COORDINATES"},
+		{name: "latitude out of range", path: "/weather?lat=91&lon=0", client: &mockWeatherClient{}, wantStatus: http.StatusBadRequest, wantCode: "INVALID_COORDINATES"},
+		{name: "longitude not a number", path: "/weather?lat=0&lon=east", client: &mockWeatherClient{}, wantStatus: http.StatusBadRequest, wantCode: "INVALID_COORDINATES"},
+		{name: "upstream failure", path: "/weather?lat=0&lon=0", client: &mockWeatherClient{err: errors.New("upstream down")}, wantStatus: http.StatusServiceUnavailable, wantCode: "UPSTREAM_UNAVAILABLE"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newTestRouter(newTestHandler(tc.client, nil), coordinatesRoute).ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// Cache statuses reported in /v2 response metadata.
+const (
+	cacheStatusHit   = "hit"   // served from the service cache
+	cacheStatusMiss  = "miss"  // fetched upstream for this request
+	cacheStatusStale = "stale" // upstream failed; served from stale cache
+)
+
+// weatherEnvelope is the /v2 current-weather response.
+type weatherEnvelope struct {
+	Data     models.WeatherData `json:"data"`
+	Metadata weatherMetadata    `json:"metadata"`
+	Links    weatherLinks       `json:"links"`
+}
+
+// weatherMetadata describes where the data in a weatherEnvelope came from.
+type weatherMetadata struct {
+	Cache  string `json:"cache"`            // hit, miss or stale
+	Age    int    `json:"age"`              // seconds since the data was fetched upstream
+	Source string `json:"source,omitempty"` // upstream provider
+	Units  string `json:"units"`
+}
+
+// weatherLinks are related /v2 resources for the location.
+type weatherLinks struct {
+	Self     string `json:"self"`
+	Forecast string `json:"forecast"`
+	Alerts   string `json:"alerts"`
+}
+
+// GetWeatherV2 handles GET /v2/weather/{location}. Accepts the same parameters as GetWeather
+// and returns the weather wrapped in a weatherEnvelope with cache metadata and links.
+func (h *Handler) GetWeatherV2(w http.ResponseWriter, r *http.Request) {
+	location, err := validation.ValidateLocation(mux.Vars(r)["location"], h.locationMinLength, h.locationMaxLength)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", validationErrorMessage(err))
+		return
+	}
+	h.serveWeather(w, r, location, h.envelopeWriter(location, time.Now()))
+}
+
+// GetWeatherByCoordinatesV2 handles GET /v2/weather?lat=..&lon=.., returning a weatherEnvelope.
+// Links address the location by its coordinate key.
+func (h *Handler) GetWeatherByCoordinatesV2(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	lat, lon, err := validation.ValidateCoordinates(query.Get("lat"), query.Get("lon"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_COORDINATES", err.Error())
+		return
+	}
+	key := validation.CoordinateKey(lat, lon)
+	h.serveWeather(w, r, key, h.envelopeWriter(key, time.Now()))
+}
+
+// envelopeWriter returns a serveWeather writer that wraps data for location in a
+// weatherEnvelope. Data fetched before requested counts as a cache hit. Caching headers are
+// as for writeWeather, except the ETag is weak and covers only data, since metadata age
+// changes every second.
+func (h *Handler) envelopeWriter(location string, requested time.Time) func(http.ResponseWriter, *http.Request, models.WeatherData) {
+	return func(w http.ResponseWriter, r *http.Request, data models.WeatherData) {
+		cacheStatus := cacheStatusMiss
+		switch {
+		case data.Stale:
+			cacheStatus = cacheStatusStale
+		case data.FetchedAt.Before(requested):
+			cacheStatus = cacheStatusHit
+		}
+		var age int
+		if !data.FetchedAt.IsZero() {
+			age = int(max(time.Since(data.FetchedAt), 0) / time.Second)
+		}
+		base := (&url.URL{Path: "/v2/weather/" + location}).EscapedPath()
+		envelope := weatherEnvelope{
+			Data: data,
+			Metadata: weatherMetadata{
+				Cache:  cacheStatus,
+				Age:    age,
+				Source: h.weatherService.Source(),
+				Units:  data.Units,
+			},
+			Links: weatherLinks{
+				Self:     r.URL.RequestURI(),
+				Forecast: base + "/forecast",
+				Alerts:   base + "/alerts",
+			},
+		}
+
+		dataBody, err := json.Marshal(data)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "Unable to encode weather data")
+			return
+		}
+		body, err := json.Marshal(envelope)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "Unable to encode weather data")
+			return
+		}
+		h.writeCacheable(w, r, data, append(body, '\n'), "application/json", "W/"+entityTag("application/json", dataBody))
+	}
+}
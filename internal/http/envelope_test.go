@@ -0,0 +1,139 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/service"
+)
+
+// fetchStampingWeatherClient returns weather fetched at call time, as the real client does.
+type fetchStampingWeatherClient struct {
+	mockWeatherClient
+}
+
+func (m *fetchStampingWeatherClient) GetCurrentWeather(ctx context.Context, location string) (models.WeatherData, error) {
+	data, err := m.mockWeatherClient.GetCurrentWeather(ctx, location)
+	data.FetchedAt = time.Now()
+	return data, err
+}
+
+var envelopeRoutes = []testRoute{
+	{"GET", "/v2/weather", (*Handler).GetWeatherByCoordinatesV2},
+	{"GET", "/v2/weather/{location}", (*Handler).GetWeatherV2},
+}
+
+// TestHandler_GetWeatherV2_Envelope verifies the data, metadata and links of a /v2 response,
+// and that a second request for the same location reports a cache hit.
+func TestHandler_GetWeatherV2_Envelope(t *testing.T) {
+	// Arrange
+	weatherClient := &fetchStampingWeatherClient{mockWeatherClient{weather: models.WeatherData{Location: "New York", Temperature: 20, Timestamp: time.Now()}}}
+	router := newTestRouter(newTestHandler(weatherClient, nil), envelopeRoutes...)
+
+	for _, wantCache := range []string{cacheStatusMiss, cacheStatusHit} {
+		// Act
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/v2/weather/new%20york?units=imperial", nil))
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		var resp weatherEnvelope
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.Data.Location != "New York" || resp.Data.Temperature != 68 {
+			t.Errorf("data = %+v, want New York at 68", resp.Data)
+		}
+		if resp.Metadata.Cache != wantCache || resp.Metadata.Units != "imperial" || resp.Metadata.Age > 1 {
+			t.Errorf("metadata = %+v, want cache %q, imperial units, age ~0", resp.Metadata, wantCache)
+		}
+		want := weatherLinks{
+			Self:     "/v2/weather/new%20york?units=imperial",
+			Forecast: "/v2/weather/new%20york/forecast",
+			Alerts:   "/v2/weather/new%20york/alerts",
+		}
+		if resp.Links != want {
+			t.Errorf("links = %+v, want %+v", resp.Links, want)
+		}
+		if etag := w.Header().Get("ETag"); !strings.HasPrefix(etag, `W/"`) {
+			t.Errorf("ETag = %q, want weak tag", etag)
+		}
+	}
+}
+
+// TestHandler_GetWeatherV2_Stale verifies stale data is reported in metadata.
+func TestHandler_GetWeatherV2_Stale(t *testing.T) {
+	// Arrange: upstream down, stale entry available
+	weatherClient := &mockWeatherClient{err: errors.New("upstream down")}
+	cache := &staleOnlyCache{stale: models.WeatherData{Location: "chicago", FetchedAt: time.Now().Add(-10 * time.Minute)}}
+	weatherService := service.NewWeatherService(weatherClient, cache, 5*time.Minute, time.Hour, false, 0)
+	handler := NewHandler(weatherService, weatherClient, nil, zap.NewNop(), nil, 100, 1)
+	router := newTestRouter(handler, envelopeRoutes...)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v2/weather/chicago", nil))
+
+	// Assert
+	var resp weatherEnvelope
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Metadata.Cache != cacheStatusStale || resp.Metadata.Age < 599 || resp.Metadata.Units != "metric" {
+		t.Errorf("metadata = %+v, want stale, age ~600, metric", resp.Metadata)
+	}
+}
+
+// TestHandler_GetWeatherByCoordinatesV2 verifies coordinate requests link by coordinate key.
+func TestHandler_GetWeatherByCoordinatesV2(t *testing.T) {
+	// Arrange
+	router := newTestRouter(newTestHandler(&mockWeatherClient{weather: models.WeatherData{Location: "Chicago", FetchedAt: time.Now()}}, nil), envelopeRoutes...)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v2/weather?lat=41.8781&lon=-87.6298", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var resp weatherEnvelope
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Links.Forecast != "/v2/weather/41.88,-87.63/forecast" {
+		t.Errorf("links.forecast = %q", resp.Links.Forecast)
+	}
+}
+
+// TestHandler_GetWeatherV2_ConditionalRequest verifies the weak ETag survives metadata changes
+// and matches If-None-Match.
+func TestHandler_GetWeatherV2_ConditionalRequest(t *testing.T) {
+	// Arrange
+	router := newTestRouter(newTestHandler(&mockWeatherClient{weather: models.WeatherData{Location: "chicago", FetchedAt: time.Now()}}, nil), envelopeRoutes...)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v2/weather/chicago", nil))
+	etag := w.Header().Get("ETag")
+
+	// Act: second request is a cache hit, so its metadata differs
+	req := httptest.NewRequest("GET", "/v2/weather/chicago", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotModified)
+	}
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kjstillabower/weather-alert-service/internal/degraded"
+	"github.com/kjstillabower/weather-alert-service/internal/expr"
+	"github.com/kjstillabower/weather-alert-service/internal/idle"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// maxEvaluateBodyBytes caps POST /weather/{location}/evaluate bodies; expressions are limited
+// to expr.MaxLength characters, so anything larger is not a valid request.
+const maxEvaluateBodyBytes = 8 << 10
+
+// EvaluateWeather handles POST /weather/{location}/evaluate. The body is
+// {"expression": "..."}; the expression is compiled before any weather is fetched, so invalid
+// expressions cost no upstream call. Returns the boolean result, the fields it read, and the
+// weather data it was evaluated against.
+func (h *Handler) EvaluateWeather(w http.ResponseWriter, r *http.Request) {
+	location, err := validation.ValidateLocation(mux.Vars(r)["location"], h.locationMinLength, h.locationMaxLength)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", validationErrorMessage(err))
+		return
+	}
+	var body struct {
+		Expression string `json:"expression"`
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxEvaluateBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&body); err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_BODY", `request body must be {"expression": "..."}`)
+		return
+	}
+	program, err := expr.Compile(body.Expression)
+	if err != nil {
+		// expr.Error messages start with "position N:" so clients can point at the problem.
+		writeError(w, r, http.StatusBadRequest, "INVALID_EXPRESSION", err.Error())
+		return
+	}
+
+	idle.RecordRequest()
+	data, err := h.weatherService.GetWeather(r.Context(), location)
+	if err != nil {
+		degraded.RecordError()
+		writeServiceError(w, r, err)
+		return
+	}
+	degraded.RecordSuccess()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"expression": body.Expression,
+		"result":     program.Eval(data),
+		"fields":     program.Fields(),
+		"weather":    data,
+	})
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+var evaluateRoute = testRoute{"POST", "/weather/{location}/evaluate", (*Handler).EvaluateWeather}
+
+// TestHandler_EvaluateWeather_Success verifies the result, referenced fields and the weather
+// data used are returned.
+func TestHandler_EvaluateWeather_Success(t *testing.T) {
+	// Arrange
+	mockClient := &mockWeatherClient{weather: models.WeatherData{Location: "chicago", Temperature: -2, Conditions: "Snow", Humidity: 90, Timestamp: time.Now()}}
+	router := newTestRouter(newTestHandler(mockClient, nil), evaluateRoute)
+	body := `{"expression": "temperature < 0 && humidity > 80 || conditions ~ \"snow\""}`
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/weather/chicago/evaluate", strings.NewReader(body)))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var resp struct {
+		Result  bool               `json:"result"`
+		Fields  []string           `json:"fields"`
+		Weather models.WeatherData `json:"weather"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !resp.Result || len(resp.Fields) != 3 || resp.Weather.Temperature != -2 {
+		t.Errorf("response = %+v, want true over 3 fields with temperature -2", resp)
+	}
+}
+
+// TestHandler_EvaluateWeather_Errors verifies request, compile and upstream errors use the
+// standard error envelope, and that invalid expressions never reach upstream.
+func TestHandler_EvaluateWeather_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		body        string
+		upstreamErr error
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+	}{
+		{name: "invalid location", path: "/weather/%3Cx%3E/evaluate", body: `{"expression": "true"}`, wantStatus: http.StatusBadRequest, wantCode: "INVALID_LOCATION"},
+		{name: "invalid body", path: "/weather/chicago/evaluate", body: `{"expr": "true"}`, wantStatus: http.StatusBadRequest, wantCode: "INVALID_BODY"},
+		{name: "compile error", path: "/weather/chicago/evaluate", body: `{"expression": "humidity > \"high\""}`, upstreamErr: errors.New("must not be called"), wantStatus: http.StatusBadRequest, wantCode: "INVALID_EXPRESSION", wantMessage: "position 10:"},
+		{name: "upstream failure", path: "/weather/chicago/evaluate", body: `{"expression": "true"}`, upstreamErr: errors.New("boom"), wantStatus: http.StatusServiceUnavailable, wantCode: "UPSTREAM_UNAVAILABLE"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newTestRouter(newTestHandler(&mockWeatherClient{err: tc.upstreamErr}, nil), evaluateRoute)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", tc.path, strings.NewReader(tc.body)))
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			_ = json.NewDecoder(w.Body).Decode(&resp)
+			if resp["error"]["code"] != tc.wantCode || !strings.HasPrefix(resp["error"]["message"], tc.wantMessage) {
+				t.Errorf("error = %v, want code %q message prefix %q", resp["error"], tc.wantCode, tc.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kjstillabower/weather-alert-service/internal/degraded"
+	"github.com/kjstillabower/weather-alert-service/internal/idle"
+	"github.com/kjstillabower/weather-alert-service/internal/service"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// GetForecast handles GET /weather/{location}/forecast. Returns 3-hour forecast periods from now
+// up to the hours query parameter (1-120, default 120).
+func (h *Handler) GetForecast(w http.ResponseWriter, r *http.Request) {
+	location, err := validation.ValidateLocation(mux.Vars(r)["location"], h.locationMinLength, h.locationMaxLength)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", validationErrorMessage(err))
+		return
+	}
+	hours := service.MaxForecastHours
+	if raw := strings.TrimSpace(r.URL.Query().Get("hours")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > service.MaxForecastHours {
+			writeError(w, r, http.StatusBadRequest, "INVALID_HOURS", "hours must be an integer from 1 to 120")
+			return
+		}
+		hours = n
+	}
+
+	idle.RecordRequest()
+	forecast, err := h.weatherService.GetForecast(r.Context(), location, hours)
+	if err != nil {
+		degraded.RecordError()
+		writeServiceError(w, r, err)
+		return
+	}
+	degraded.RecordSuccess()
+	writeJSON(w, http.StatusOK, forecast)
+}
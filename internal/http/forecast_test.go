@@ -0,0 +1,75 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+var forecastRoute = testRoute{"GET", "/weather/{location}/forecast", (*Handler).GetForecast}
+
+// TestHandler_GetForecast verifies the forecast is returned as JSON and limited by hours.
+func TestHandler_GetForecast(t *testing.T) {
+	// Arrange: periods 1h and 30h ahead
+	now := time.Now()
+	router := newTestRouter(newTestHandler(&mockWeatherClient{forecast: models.Forecast{Location: "chicago", Periods: []models.ForecastPeriod{
+		{Time: now.Add(time.Hour), Temperature: 4, Conditions: "light rain", PrecipitationProbability: 0.6},
+		{Time: now.Add(30 * time.Hour), Temperature: 9},
+	}}}, nil), forecastRoute)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/chicago/forecast?hours=24", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp models.Forecast
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Location != "chicago" || len(resp.Periods) != 1 || resp.Periods[0].Conditions != "light rain" {
+		t.Errorf("response = %+v, want one light rain period for chicago", resp)
+	}
+}
+
+// TestHandler_GetForecast_Errors verifies invalid input and upstream failures.
+func TestHandler_GetForecast_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		client     *mockWeatherClient
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "hours zero", path: "/weather/chicago/forecast?hours=0", client: &mockWeatherClient{}, wantStatus: http.StatusBadRequest, wantCode: "INVALID_HOURS"},
+		{name: "hours beyond horizon", path: "/weather/chicago/forecast?hours=121", client: &mockWeatherClient{}, wantStatus: http.StatusBadRequest, wantCode: "INVALID_HOURS"},
+		{name: "hours not a number", path: "/weather/chicago/forecast?hours=day", client: &mockWeatherClient{}, wantStatus: http.StatusBadRequest, wantCode: "INVALID_HOURS"},
+		{name: "invalid location", path: "/weather/chicago%3B/forecast", client: &mockWeatherClient{}, wantStatus: http.StatusBadRequest, wantCode: "INVALID_LOCATION"},
+		{name: "upstream failure", path: "/weather/chicago/forecast", client: &mockWeatherClient{err: errors.New("upstream down")}, wantStatus: http.StatusServiceUnavailable, wantCode: "UPSTREAM_UNAVAILABLE"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newTestRouter(newTestHandler(tc.client, nil), forecastRoute).ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("error code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/groups"
+	"github.com/kjstillabower/weather-alert-service/internal/idle"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// SetGroups attaches the location groups served by /groups/{name}/weather and /admin/groups.
+// When unset, those endpoints return 503.
+func (h *Handler) SetGroups(store *groups.Store) {
+	h.groups = store
+}
+
+// groupWeather is the GET /groups/{name}/weather response.
+type groupWeather struct {
+	Group     string           `json:"group"`
+	Members   []batchResult    `json:"members"`
+	Aggregate groups.Aggregate `json:"aggregate"`
+}
+
+// GetGroupWeather handles GET /groups/{name}/weather. Members are fetched like a batch
+// request (one rate-limit token each, bounded concurrency, a result or error per member in
+// group order), and the aggregate covers the members that returned weather, in the requested
+// units. Accepts units as GetWeather. lang is rejected with 400 INVALID_LANG: the worst
+// conditions are ranked on the English descriptions, which a translation would break. Honors
+// Accept; CSV and NDJSON have one record per member and leave out the aggregate.
+func (h *Handler) GetGroupWeather(w http.ResponseWriter, r *http.Request) {
+	if h.groups == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "GROUPS_DISABLED", "location groups are not enabled")
+		return
+	}
+	group, err := h.groups.Get(mux.Vars(r)["name"])
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, "GROUP_NOT_FOUND", "location group not found")
+		return
+	}
+	units, err := validation.ValidateUnits(r.URL.Query().Get("units"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_UNITS", err.Error())
+		return
+	}
+	if r.URL.Query().Has("lang") {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LANG", "lang is not supported on group weather")
+		return
+	}
+	if h.rateLimiter != nil && len(group.Locations) > 1 && !h.rateLimiter.AllowN(time.Now(), len(group.Locations)-1) {
+		rejectRateLimited(w, r)
+		return
+	}
+
+	idle.RecordRequest()
+	results := h.fetchLocations(r, group.Locations, units, "")
+	var readings []groups.Reading
+	for _, res := range results {
+		if res.Weather != nil {
+			readings = append(readings, groups.Reading{Location: res.Location, Weather: *res.Weather})
+		}
+	}
+	writeNegotiated(w, r, http.StatusOK, representation{
+		body: groupWeather{
+			Group:     group.Name,
+			Members:   results,
+			Aggregate: groups.Summarize(readings),
+		},
+		xmlRoot: "group",
+		rows:    rowsOf(results),
+		columns: locationResultCSVColumns,
+	})
+}
+
+// ListGroups handles GET /admin/groups.
+func (h *Handler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	if h.groups == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "GROUPS_DISABLED", "location groups are not enabled")
+		return
+	}
+	list := h.groups.List()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"groups": list,
+		"count":  len(list),
+	})
+}
+
+// GetGroup handles GET /admin/groups/{name}. Returns 404 GROUP_NOT_FOUND for unknown groups.
+func (h *Handler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	if h.groups == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "GROUPS_DISABLED", "location groups are not enabled")
+		return
+	}
+	group, err := h.groups.Get(mux.Vars(r)["name"])
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, "GROUP_NOT_FOUND", "location group not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, group)
+}
+
+// PutGroup handles PUT /admin/groups/{name} with body {"locations": ["...", ...]}, creating or
+// replacing the group. Returns 201 when created and 200 when replaced. Locations are validated
+// like /weather/{location}.
+func (h *Handler) PutGroup(w http.ResponseWriter, r *http.Request) {
+	if h.groups == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "GROUPS_DISABLED", "location groups are not enabled")
+		return
+	}
+	var body struct {
+		Locations []string `json:"locations"`
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&body); err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_BODY", `request body must be {"locations": ["...", ...]}`)
+		return
+	}
+	for _, raw := range body.Locations {
+		if _, err := validation.ValidateLocation(raw, h.locationMinLength, h.locationMaxLength); err != nil {
+			writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", fmt.Sprintf("%q: %s", raw, validationErrorMessage(err)))
+			return
+		}
+	}
+	group, created, err := h.groups.Put(mux.Vars(r)["name"], body.Locations)
+	switch {
+	case errors.Is(err, groups.ErrInvalidName), errors.Is(err, groups.ErrNoMembers):
+		writeError(w, r, http.StatusBadRequest, "INVALID_GROUP", err.Error())
+		return
+	case errors.Is(err, groups.ErrTooManyMembers):
+		writeError(w, r, http.StatusBadRequest, "TOO_MANY_LOCATIONS", err.Error())
+		return
+	case err != nil:
+		h.logGroupsError(err)
+		writeError(w, r, http.StatusInternalServerError, "PERSIST_FAILED", "groups could not be saved")
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	if h.logger != nil {
+		h.logger.Info("location group saved", zap.String("group", group.Name), zap.Int("locations", len(group.Locations)), zap.Bool("created", created))
+	}
+	writeJSON(w, status, group)
+}
+
+// DeleteGroup handles DELETE /admin/groups/{name}. Returns 204, or 404 GROUP_NOT_FOUND.
+func (h *Handler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	if h.groups == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "GROUPS_DISABLED", "location groups are not enabled")
+		return
+	}
+	name := mux.Vars(r)["name"]
+	if err := h.groups.Delete(name); err != nil {
+		if errors.Is(err, groups.ErrNotFound) {
+			writeError(w, r, http.StatusNotFound, "GROUP_NOT_FOUND", "location group not found")
+			return
+		}
+		h.logGroupsError(err)
+		writeError(w, r, http.StatusInternalServerError, "PERSIST_FAILED", "groups could not be saved")
+		return
+	}
+	if h.logger != nil {
+		h.logger.Info("location group deleted", zap.String("group", name))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) logGroupsError(err error) {
+	if h.logger != nil {
+		h.logger.Error("groups update failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/time/rate"
+
+	"github.com/kjstillabower/weather-alert-service/internal/client"
+	"github.com/kjstillabower/weather-alert-service/internal/groups"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// regionWeatherClient returns fixed weather per location, or ErrLocationNotFound for others.
+type regionWeatherClient struct {
+	mockWeatherClient
+	weather map[string]models.WeatherData
+}
+
+func (m *regionWeatherClient) GetCurrentWeather(ctx context.Context, location string) (models.WeatherData, error) {
+	data, ok := m.weather[location]
+	if !ok {
+		return models.WeatherData{}, fmt.Errorf("%w", client.ErrLocationNotFound)
+	}
+	return data, nil
+}
+
+func newGroupsRouter(t *testing.T, store *groups.Store, limiter *rate.Limiter) *mux.Router {
+	t.Helper()
+	mockClient := &regionWeatherClient{weather: map[string]models.WeatherData{
+		"boston":   {Location: "Boston", Temperature: 5, WindSpeed: 8, Conditions: "light rain"},
+		"new york": {Location: "New York", Temperature: 10, WindSpeed: 4, Conditions: "few clouds"},
+		"hartford": {Location: "Hartford", Temperature: 0, WindSpeed: 3, Conditions: "snow"},
+	}}
+	handler := newTestHandler(mockClient, limiter)
+	handler.SetGroups(store)
+	return newTestRouter(handler,
+		testRoute{"GET", "/groups/{name}/weather", (*Handler).GetGroupWeather},
+		testRoute{"GET", "/admin/groups", (*Handler).ListGroups},
+		testRoute{"GET", "/admin/groups/{name}", (*Handler).GetGroup},
+		testRoute{"PUT", "/admin/groups/{name}", (*Handler).PutGroup},
+		testRoute{"DELETE", "/admin/groups/{name}", (*Handler).DeleteGroup},
+	)
+}
+
+// TestHandler_GetGroupWeather verifies members are returned in group order with per-member
+// errors, and the aggregate covers the members with weather in the requested units.
+func TestHandler_GetGroupWeather(t *testing.T) {
+	// Arrange
+	store, _ := groups.Open("", map[string][]string{"northeast": {"boston", "atlantis", "new york", "hartford"}}, 0)
+	router := newGroupsRouter(t, store, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/groups/NorthEast/weather?units=imperial", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var resp groupWeather
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	var order []string
+	for _, m := range resp.Members {
+		order = append(order, m.Location)
+	}
+	if resp.Group != "northeast" || strings.Join(order, ",") != "boston,atlantis,new york,hartford" {
+		t.Errorf("group = %q, members = %v", resp.Group, order)
+	}
+	if resp.Members[1].Error == nil || resp.Members[1].Error.Category != string(client.ErrorCategoryLocationNotFound) {
+		t.Errorf("atlantis = %+v, want location_not_found error", resp.Members[1])
+	}
+	agg := resp.Aggregate
+	if agg.Reporting != 3 {
+		t.Errorf("reporting = %d, want 3", agg.Reporting)
+	}
+	if *agg.TemperatureMin != (groups.Extreme{Location: "hartford", Value: 32}) || *agg.TemperatureMax != (groups.Extreme{Location: "new york", Value: 50}) {
+		t.Errorf("temperature min, max = %+v, %+v; want hartford 32°F, new york 50°F", *agg.TemperatureMin, *agg.TemperatureMax)
+	}
+	if agg.HighestWind.Location != "boston" || agg.WorstConditions.Location != "hartford" {
+		t.Errorf("highest wind = %+v, worst = %+v", *agg.HighestWind, *agg.WorstConditions)
+	}
+}
+
+// TestHandler_GetGroupWeather_Errors verifies unknown groups, rejected parameters and the
+// per-member rate-limit charge.
+func TestHandler_GetGroupWeather_Errors(t *testing.T) {
+	store, _ := groups.Open("", map[string][]string{"northeast": {"boston", "new york", "hartford"}}, 0)
+	tests := []struct {
+		name       string
+		path       string
+		limiter    *rate.Limiter
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "unknown group", path: "/groups/south/weather", wantStatus: http.StatusNotFound, wantCode: "GROUP_NOT_FOUND"},
+		{name: "invalid units", path: "/groups/northeast/weather?units=kelvin", wantStatus: http.StatusBadRequest, wantCode: "INVALID_UNITS"},
+		{name: "lang rejected", path: "/groups/northeast/weather?lang=fr", wantStatus: http.StatusBadRequest, wantCode: "INVALID_LANG"},
+		{name: "rate limited", path: "/groups/northeast/weather", limiter: rate.NewLimiter(0, 1), wantStatus: http.StatusTooManyRequests, wantCode: "RATE_LIMITED"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newGroupsRouter(t, store, tc.limiter).ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			_ = json.NewDecoder(w.Body).Decode(&resp)
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
+
+// TestHandler_Groups_Lifecycle verifies PUT creates then replaces a group, and GET, list and
+// DELETE see the change.
+func TestHandler_Groups_Lifecycle(t *testing.T) {
+	// Arrange
+	store, err := groups.Open(filepath.Join(t.TempDir(), "groups.json"), nil, 0)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	router := newGroupsRouter(t, store, nil)
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PUT", "/admin/groups/pacific", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret-token")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act + Assert: create, then replace
+	if w := put(`{"locations": ["Seattle", "portland"]}`); w.Code != http.StatusCreated {
+		t.Fatalf("PUT status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+	w := put(`{"locations": ["seattle", "vancouver", "Seattle"]}`)
+	var group groups.Group
+	_ = json.NewDecoder(w.Body).Decode(&group)
+	if w.Code != http.StatusOK || !reflect.DeepEqual(group.Locations, []string{"seattle", "vancouver"}) {
+		t.Errorf("replace PUT = %d %+v, want 200 [seattle vancouver]", w.Code, group)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, adminRequest("GET", "/admin/groups"))
+	var list struct {
+		Groups []groups.Group `json:"groups"`
+		Count  int            `json:"count"`
+	}
+	_ = json.NewDecoder(w.Body).Decode(&list)
+	if list.Count != 1 || list.Groups[0].Name != "pacific" {
+		t.Errorf("list = %+v, want pacific only", list)
+	}
+
+	// Delete
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, adminRequest("DELETE", "/admin/groups/pacific"))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("DELETE status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, adminRequest("GET", "/admin/groups/pacific"))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GET deleted status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+// TestHandler_Groups_Errors verifies auth, body, name, location and size errors.
+func TestHandler_Groups_Errors(t *testing.T) {
+	store, _ := groups.Open("", nil, 2)
+	router := newGroupsRouter(t, store, nil)
+	putRequest := func(path, body string) *http.Request {
+		req := httptest.NewRequest("PUT", path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret-token")
+		return req
+	}
+
+	tests := []struct {
+		name       string
+		req        *http.Request
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "missing token", req: httptest.NewRequest("GET", "/admin/groups", nil), wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{name: "invalid body", req: putRequest("/admin/groups/west", `["seattle"]`), wantStatus: http.StatusBadRequest, wantCode: "INVALID_BODY"},
+		{name: "invalid name", req: putRequest("/admin/groups/west%20coast", `{"locations": ["seattle"]}`), wantStatus: http.StatusBadRequest, wantCode: "INVALID_GROUP"},
+		{name: "no locations", req: putRequest("/admin/groups/west", `{"locations": []}`), wantStatus: http.StatusBadRequest, wantCode: "INVALID_GROUP"},
+		{name: "invalid location", req: putRequest("/admin/groups/west", `{"locations": ["<script>"]}`), wantStatus: http.StatusBadRequest, wantCode: "INVALID_LOCATION"},
+		{name: "too many", req: putRequest("/admin/groups/west", `{"locations": ["a", "b", "c"]}`), wantStatus: http.StatusBadRequest, wantCode: "TOO_MANY_LOCATIONS"},
+		{name: "delete unknown", req: adminRequest("DELETE", "/admin/groups/west"), wantStatus: http.StatusNotFound, wantCode: "GROUP_NOT_FOUND"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tc.req)
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			_ = json.NewDecoder(w.Body).Decode(&resp)
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
@@ -13,15 +13,24 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 
+	"github.com/kjstillabower/weather-alert-service/internal/alerts"
+	"github.com/kjstillabower/weather-alert-service/internal/changes"
 	"github.com/kjstillabower/weather-alert-service/internal/client"
 	"github.com/kjstillabower/weather-alert-service/internal/degraded"
+	"github.com/kjstillabower/weather-alert-service/internal/groups"
+	"github.com/kjstillabower/weather-alert-service/internal/history"
 	"github.com/kjstillabower/weather-alert-service/internal/idle"
 	"github.com/kjstillabower/weather-alert-service/internal/lifecycle"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/notify"
 	"github.com/kjstillabower/weather-alert-service/internal/observability"
 	"github.com/kjstillabower/weather-alert-service/internal/overload"
 	"github.com/kjstillabower/weather-alert-service/internal/service"
+	"github.com/kjstillabower/weather-alert-service/internal/stream"
+	"github.com/kjstillabower/weather-alert-service/internal/subscriptions"
 	"github.com/kjstillabower/weather-alert-service/internal/traffic"
 	"github.com/kjstillabower/weather-alert-service/internal/validation"
+	"github.com/kjstillabower/weather-alert-service/internal/watchlist"
 )
 
 // HealthConfig holds lifecycle thresholds for the health handler.
@@ -53,6 +62,18 @@ type Handler struct {
 	locationMinLength int
 	healthStatusMu    sync.Mutex // guards healthStatusPrev when logging health status transitions
 	healthStatusPrev  string    // previous health status for transition logging
+	alertEngine       *alerts.Engine
+	subscriptions     *subscriptions.Store
+	webhookDispatcher *subscriptions.Dispatcher
+	streamHub         *stream.Hub
+	streamConfig      StreamConfig
+	watchlist         *watchlist.Store
+	groups            *groups.Store
+	changeLog         *changes.Log
+	history           *history.Store
+	trendWindow       time.Duration
+	batchMaxLocations int
+	batchConcurrency  int
 }
 
 // NewHandler returns a new Handler. locationMaxLength and locationMinLength are used
@@ -84,7 +105,10 @@ func NewHandler(
 	}
 }
 
-// GetWeather handles GET /weather/{location}.
+// GetWeather handles GET /weather/{location}. Optional query parameters: units (metric,
+// imperial or standard; default metric) and lang (upstream language code for conditions).
+// Responses carry ETag, Last-Modified and Cache-Control, and conditional requests get 304.
+// Accept selects JSON (default), text/csv, application/x-ndjson or application/xml.
 func (h *Handler) GetWeather(w http.ResponseWriter, r *http.Request) {
 	raw := mux.Vars(r)["location"]
 	location, err := validation.ValidateLocation(raw, h.locationMinLength, h.locationMaxLength)
@@ -93,16 +117,43 @@ func (h *Handler) GetWeather(w http.ResponseWriter, r *http.Request) {
 		writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", msg)
 		return
 	}
+	h.serveWeather(w, r, location, h.writeWeather)
+}
+
+// serveWeather fetches current weather for a validated location (or coordinate key) in the
+// units and lang requested by query parameter and passes it to write.
+func (h *Handler) serveWeather(w http.ResponseWriter, r *http.Request, location string, write func(http.ResponseWriter, *http.Request, models.WeatherData)) {
+	units, lang, ok := weatherFormatParams(w, r)
+	if !ok {
+		return
+	}
 
 	idle.RecordRequest()
-	result, err := h.weatherService.GetWeather(r.Context(), location)
+	result, err := h.weatherService.GetLocalizedWeather(r.Context(), location, lang)
 	if err != nil {
 		degraded.RecordError()
 		writeServiceError(w, r, err)
 		return
 	}
 	degraded.RecordSuccess()
-	writeJSON(w, http.StatusOK, result)
+	write(w, r, result.InUnits(units))
+}
+
+// weatherFormatParams validates the units and lang query parameters. Writes a 400 response
+// (INVALID_UNITS or INVALID_LANG) and returns ok false when either is invalid.
+func weatherFormatParams(w http.ResponseWriter, r *http.Request) (units, lang string, ok bool) {
+	query := r.URL.Query()
+	units, err := validation.ValidateUnits(query.Get("units"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_UNITS", err.Error())
+		return "", "", false
+	}
+	lang, err = validation.ValidateLanguage(query.Get("lang"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LANG", err.Error())
+		return "", "", false
+	}
+	return units, lang, true
 }
 
 // validationErrorMessage returns a stable, human-readable message for validation errors.
@@ -215,6 +266,34 @@ func (h *Handler) computeHealthStatus(ctx context.Context) healthResult {
 	return healthResult{"healthy", http.StatusOK, ""}
 }
 
+// HealthSnapshot evaluates health as GET /health does and adds the lifecycle window counts.
+// Used by the background health monitor; does not record a transition for /health logging.
+func (h *Handler) HealthSnapshot(ctx context.Context) notify.Snapshot {
+	result := h.computeHealthStatus(ctx)
+	snap := notify.Snapshot{Status: result.status, Reason: result.reason}
+	if h.healthConfig == nil {
+		return snap
+	}
+	cfg := h.healthConfig
+	if cfg.OverloadWindow > 0 {
+		snap.Stats.OverloadWindow = cfg.OverloadWindow.String()
+		snap.Stats.Requests = overload.RequestCount(cfg.OverloadWindow)
+		snap.Stats.Denials = overload.DenialCount(cfg.OverloadWindow)
+	}
+	if cfg.DegradedWindow > 0 {
+		snap.Stats.DegradedWindow = cfg.DegradedWindow.String()
+		snap.Stats.Errors, snap.Stats.Total = degraded.ErrorRate(cfg.DegradedWindow)
+		if snap.Stats.Total > 0 {
+			snap.Stats.ErrorRatePercent = float64(snap.Stats.Errors) * 100 / float64(snap.Stats.Total)
+		}
+	}
+	if cfg.IdleWindow > 0 {
+		snap.Stats.IdleWindow = cfg.IdleWindow.String()
+		snap.Stats.IdleRequests = idle.RequestCount(cfg.IdleWindow)
+	}
+	return snap
+}
+
 // writeJSON writes a JSON response with the specified HTTP status code.
 // Sets Content-Type header to application/json and encodes the provided value.
 func writeJSON(w http.ResponseWriter, status int, v interface{}) {
@@ -224,18 +303,25 @@ func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 }
 
 // writeError writes an error response in the standard error format with code, message,
-// and requestId (correlation ID) if available in request context.
+// and requestId (correlation ID) if available in request context. The error is encoded in the
+// format negotiated from Accept (see negotiateFormat), whatever the endpoint returns on success.
 func writeError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
 	corrID := ""
 	if v := r.Context().Value("correlation_id"); v != nil {
 		corrID = v.(string)
 	}
-	writeJSON(w, status, map[string]interface{}{
-		"error": map[string]string{
-			"code":      code,
-			"message":   message,
-			"requestId": corrID,
-		},
+	detail := map[string]string{
+		"code":      code,
+		"message":   message,
+		"requestId": corrID,
+	}
+	envelope := map[string]interface{}{"error": detail}
+	writeNegotiated(w, r, status, representation{
+		body:    envelope,
+		xmlRoot: "error",
+		xmlBody: detail,
+		rows:    []interface{}{envelope},
+		columns: errorCSVColumns,
 	})
 }
 
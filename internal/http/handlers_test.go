@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/kjstillabower/weather-alert-service/internal/client"
 	"github.com/kjstillabower/weather-alert-service/internal/degraded"
 	"github.com/kjstillabower/weather-alert-service/internal/idle"
 	"github.com/kjstillabower/weather-alert-service/internal/lifecycle"
@@ -20,6 +22,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/time/rate"
 )
 
 type mockWeatherClient struct {
@@ -27,6 +30,9 @@ type mockWeatherClient struct {
 	err          error
 	validateErr  error
 	block        chan struct{} // if set, GetCurrentWeather blocks until ctx.Done()
+	alerts       []models.OfficialAlert
+	forecast     models.Forecast
+	places       []models.Place
 }
 
 func (m *mockWeatherClient) GetCurrentWeather(ctx context.Context, location string) (models.WeatherData, error) {
@@ -41,13 +47,26 @@ func (m *mockWeatherClient) GetCurrentWeather(ctx context.Context, location stri
 	return m.weather, m.err
 }
 
+func (m *mockWeatherClient) GetForecast(ctx context.Context, location string) (models.Forecast, error) {
+	return m.forecast, m.err
+}
+
+func (m *mockWeatherClient) SearchLocations(ctx context.Context, query string) ([]models.Place, error) {
+	return m.places, m.err
+}
+
+func (m *mockWeatherClient) GetOfficialAlerts(ctx context.Context, location string) ([]models.OfficialAlert, error) {
+	return m.alerts, m.err
+}
+
 func (m *mockWeatherClient) ValidateAPIKey(ctx context.Context) error {
 	return m.validateErr
 }
 
 type mockCache struct {
-	data map[string]models.WeatherData
-	err  error
+	data  map[string]models.WeatherData
+	bytes map[string][]byte
+	err   error
 }
 
 func (m *mockCache) Get(ctx context.Context, key string) (models.WeatherData, bool, error) {
@@ -74,6 +93,64 @@ func (m *mockCache) Set(ctx context.Context, key string, value models.WeatherDat
 	return nil
 }
 
+func (m *mockCache) GetBytes(ctx context.Context, key string) ([]byte, bool, error) {
+	if m.err != nil {
+		return nil, false, m.err
+	}
+	val, ok := m.bytes[key]
+	return val, ok, nil
+}
+
+func (m *mockCache) SetBytes(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.bytes == nil {
+		m.bytes = make(map[string][]byte)
+	}
+	m.bytes[key] = value
+	return nil
+}
+
+// testRoute is a route registered by newTestRouter: method ("" for any), path template and
+// handler method, e.g. {"GET", "/weather/{location}", (*Handler).GetWeather}.
+type testRoute struct {
+	method  string
+	path    string
+	handler func(*Handler, http.ResponseWriter, *http.Request)
+}
+
+// getWeatherRoute serves GET /weather/{location}.
+var getWeatherRoute = testRoute{"GET", "/weather/{location}", (*Handler).GetWeather}
+
+// newTestHandler returns a Handler over weatherClient with an empty mockCache, a 5m TTL, the
+// given limiter (nil for none) and locations of 1 to 100 characters.
+func newTestHandler(weatherClient client.WeatherClient, limiter *rate.Limiter) *Handler {
+	weatherService := service.NewWeatherService(weatherClient, &mockCache{}, 5*time.Minute, 0, false, 0)
+	return NewHandler(weatherService, weatherClient, nil, zap.NewNop(), limiter, 100, 1)
+}
+
+// newTestRouter returns a router serving routes from h. Routes under /admin are behind
+// AdminAuthMiddleware with the token "secret-token", as in the service router.
+func newTestRouter(h *Handler, routes ...testRoute) *mux.Router {
+	router := mux.NewRouter()
+	admin := router.PathPrefix("/admin").Subrouter()
+	admin.Use(AdminAuthMiddleware("secret-token"))
+	for _, rt := range routes {
+		target := router
+		path := rt.path
+		if rest, ok := strings.CutPrefix(rt.path, "/admin"); ok {
+			target, path = admin, rest
+		}
+		handler := rt.handler
+		route := target.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) { handler(h, w, r) })
+		if rt.method != "" {
+			route.Methods(rt.method)
+		}
+	}
+	return router
+}
+
 // TestHandler_GetWeather_Success verifies that GetWeather returns weather data
 // successfully with correct HTTP status and response schema when upstream fetch succeeds.
 func TestHandler_GetWeather_Success(t *testing.T) {
@@ -277,6 +354,62 @@ func TestHandler_GetWeather_ServiceError(t *testing.T) {
 	}
 }
 
+// TestHandler_GetWeather_UnitsAndLang verifies the units query parameter converts the response
+// and is echoed, and invalid units or lang values return 400.
+func TestHandler_GetWeather_UnitsAndLang(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		wantCode  int
+		wantError string
+		wantTemp  float64
+		wantUnits string
+	}{
+		{name: "default metric", path: "/weather/seattle", wantCode: http.StatusOK, wantTemp: 10, wantUnits: "metric"},
+		{name: "imperial", path: "/weather/seattle?units=imperial&lang=fr", wantCode: http.StatusOK, wantTemp: 50, wantUnits: "imperial"},
+		{name: "standard", path: "/weather/seattle?units=standard", wantCode: http.StatusOK, wantTemp: 283.15, wantUnits: "standard"},
+		{name: "invalid units", path: "/weather/seattle?units=kelvin", wantCode: http.StatusBadRequest, wantError: "INVALID_UNITS"},
+		{name: "invalid lang", path: "/weather/seattle?lang=french", wantCode: http.StatusBadRequest, wantError: "INVALID_LANG"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockClient := &mockWeatherClient{weather: models.WeatherData{Location: "seattle", Temperature: 10, WindSpeed: 1}}
+			weatherService := service.NewWeatherService(mockClient, &mockCache{}, 5*time.Minute, 0, false, 0)
+			handler := NewHandler(weatherService, mockClient, nil, zap.NewNop(), nil, 100, 1)
+			router := mux.NewRouter()
+			router.HandleFunc("/weather/{location}", handler.GetWeather)
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+
+			// Assert
+			if w.Code != tc.wantCode {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantCode)
+			}
+			if tc.wantError != "" {
+				var resp map[string]map[string]string
+				if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				if resp["error"]["code"] != tc.wantError {
+					t.Errorf("code = %q, want %q", resp["error"]["code"], tc.wantError)
+				}
+				return
+			}
+			var resp models.WeatherData
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if math.Abs(resp.Temperature-tc.wantTemp) > 0.001 || resp.Units != tc.wantUnits {
+				t.Errorf("temperature = %v, units = %q; want %v, %q", resp.Temperature, resp.Units, tc.wantTemp, tc.wantUnits)
+			}
+		})
+	}
+}
+
 // TestHandler_GetHealth verifies that GetHealth returns 200 OK with healthy status
 // and correct health check structure when all dependencies are operational.
 func TestHandler_GetHealth(t *testing.T) {
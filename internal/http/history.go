@@ -0,0 +1,127 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/history"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// maxTrendWindow caps the trend window query parameter.
+const maxTrendWindow = 30 * 24 * time.Hour
+
+// SetHistory attaches the observation history served by /weather/{location}/history and
+// /weather/{location}/trend. trendWindow is the default trend window. When unset, those
+// endpoints return 503.
+func (h *Handler) SetHistory(store *history.Store, trendWindow time.Duration) {
+	if trendWindow <= 0 {
+		trendWindow = 3 * time.Hour
+	}
+	h.history = store
+	h.trendWindow = trendWindow
+}
+
+// GetWeatherHistory handles GET /weather/{location}/history. Returns stored observations oldest
+// first. Optional query parameters since and until (RFC 3339) bound the observation time.
+func (h *Handler) GetWeatherHistory(w http.ResponseWriter, r *http.Request) {
+	location, ok := h.historyLocation(w, r)
+	if !ok {
+		return
+	}
+	since, ok := queryTime(w, r, "since")
+	if !ok {
+		return
+	}
+	until, ok := queryTime(w, r, "until")
+	if !ok {
+		return
+	}
+	if !since.IsZero() && !until.IsZero() && since.After(until) {
+		writeError(w, r, http.StatusBadRequest, "INVALID_RANGE", "since must not be after until")
+		return
+	}
+
+	observations, err := h.history.Range(location, since, until)
+	if err != nil {
+		h.logHistoryError(err)
+		writeError(w, r, http.StatusInternalServerError, "HISTORY_UNAVAILABLE", "history could not be read")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"location":     location,
+		"observations": observations,
+		"count":        len(observations),
+	})
+}
+
+// GetWeatherTrend handles GET /weather/{location}/trend. Summarizes observations from the last
+// window (query parameter, Go duration such as "6h"; default from config): min/max/mean,
+// change and least-squares rate per hour for temperature, wind speed and humidity.
+func (h *Handler) GetWeatherTrend(w http.ResponseWriter, r *http.Request) {
+	location, ok := h.historyLocation(w, r)
+	if !ok {
+		return
+	}
+	window := h.trendWindow
+	if raw := strings.TrimSpace(r.URL.Query().Get("window")); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 || d > maxTrendWindow {
+			writeError(w, r, http.StatusBadRequest, "INVALID_WINDOW", "window must be a positive duration up to 720h, such as 6h")
+			return
+		}
+		window = d
+	}
+
+	observations, err := h.history.Range(location, time.Now().Add(-window), time.Time{})
+	if err != nil {
+		h.logHistoryError(err)
+		writeError(w, r, http.StatusInternalServerError, "HISTORY_UNAVAILABLE", "history could not be read")
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Location string `json:"location"`
+		Window   string `json:"window"`
+		history.Trend
+	}{location, window.String(), history.Summarize(observations)})
+}
+
+// historyLocation checks the history store is attached and validates the location path
+// parameter, writing the error response when either fails.
+func (h *Handler) historyLocation(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if h.history == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "HISTORY_DISABLED", "observation history is not enabled")
+		return "", false
+	}
+	location, err := validation.ValidateLocation(mux.Vars(r)["location"], h.locationMinLength, h.locationMaxLength)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", validationErrorMessage(err))
+		return "", false
+	}
+	return location, true
+}
+
+// queryTime parses an optional RFC 3339 query parameter. A missing parameter returns the zero
+// time; an invalid one writes 400 INVALID_<NAME> and returns false.
+func queryTime(w http.ResponseWriter, r *http.Request, name string) (time.Time, bool) {
+	raw := strings.TrimSpace(r.URL.Query().Get(name))
+	if raw == "" {
+		return time.Time{}, true
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_"+strings.ToUpper(name), name+" must be an RFC 3339 timestamp")
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func (h *Handler) logHistoryError(err error) {
+	if h.logger != nil {
+		h.logger.Error("history read failed", zap.Error(err))
+	}
+}
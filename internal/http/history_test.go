@@ -0,0 +1,143 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kjstillabower/weather-alert-service/internal/history"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// newHistoryRouter returns a router over a history store holding a two-hour cooling series for
+// chicago, one reading every 30 minutes ending now.
+func newHistoryRouter(t *testing.T) (*mux.Router, time.Time) {
+	t.Helper()
+	store, err := history.Open(history.Config{Path: filepath.Join(t.TempDir(), "history.db")}, nil)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	now := time.Now().Truncate(time.Second)
+	for i := 0; i <= 4; i++ {
+		at := now.Add(time.Duration(i-4) * 30 * time.Minute)
+		store.Record(context.Background(), "chicago", models.WeatherData{Location: "chicago", Temperature: float64(4 - i), WindSpeed: float64(i), Timestamp: at})
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	store.Run(ctx, 0) // flush
+
+	handler := newTestHandler(&mockWeatherClient{}, nil)
+	handler.SetHistory(store, 75*time.Minute)
+	return newTestRouter(handler,
+		testRoute{"", "/weather/{location}/history", (*Handler).GetWeatherHistory},
+		testRoute{"", "/weather/{location}/trend", (*Handler).GetWeatherTrend},
+	), now
+}
+
+// TestHandler_GetWeatherHistory verifies observations are returned oldest first within since/until.
+func TestHandler_GetWeatherHistory(t *testing.T) {
+	// Arrange
+	router, now := newHistoryRouter(t)
+	since := now.Add(-90 * time.Minute).UTC().Format(time.RFC3339)
+	until := now.Add(-30 * time.Minute).UTC().Format(time.RFC3339)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/Chicago/history?since="+since+"&until="+until, nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp struct {
+		Observations []models.WeatherData `json:"observations"`
+		Count        int                  `json:"count"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Count != 3 || resp.Observations[0].Temperature != 3 || resp.Observations[2].Temperature != 1 {
+		t.Errorf("response = %+v, want temperatures 3, 2, 1", resp)
+	}
+}
+
+// TestHandler_GetWeatherTrend verifies the default window and the window parameter.
+func TestHandler_GetWeatherTrend(t *testing.T) {
+	router, _ := newHistoryRouter(t)
+	tests := []struct {
+		name        string
+		query       string
+		wantSamples int
+		wantWindow  string
+	}{
+		{name: "default window", query: "", wantSamples: 3, wantWindow: "1h15m0s"},
+		{name: "explicit window", query: "?window=3h", wantSamples: 5, wantWindow: "3h0m0s"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/chicago/trend"+tc.query, nil))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+			var resp struct {
+				Window      string          `json:"window"`
+				Samples     int             `json:"samples"`
+				Temperature *history.Series `json:"temperature"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.Samples != tc.wantSamples || resp.Window != tc.wantWindow {
+				t.Errorf("samples/window = %d/%s, want %d/%s", resp.Samples, resp.Window, tc.wantSamples, tc.wantWindow)
+			}
+			if resp.Temperature == nil || resp.Temperature.RatePerHour == nil || *resp.Temperature.RatePerHour > -1.99 || *resp.Temperature.RatePerHour < -2.01 {
+				t.Errorf("temperature = %+v, want rate -2/hour", resp.Temperature)
+			}
+		})
+	}
+}
+
+// TestHandler_History_Errors verifies disabled history and invalid query parameters.
+func TestHandler_History_Errors(t *testing.T) {
+	router, _ := newHistoryRouter(t)
+	disabled := newTestRouter(newTestHandler(&mockWeatherClient{}, nil), testRoute{"", "/weather/{location}/history", (*Handler).GetWeatherHistory})
+
+	tests := []struct {
+		name       string
+		router     *mux.Router
+		path       string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "disabled", router: disabled, path: "/weather/chicago/history", wantStatus: http.StatusServiceUnavailable, wantCode: "HISTORY_DISABLED"},
+		{name: "invalid since", router: router, path: "/weather/chicago/history?since=yesterday", wantStatus: http.StatusBadRequest, wantCode: "INVALID_SINCE"},
+		{name: "invalid until", router: router, path: "/weather/chicago/history?until=1700000000", wantStatus: http.StatusBadRequest, wantCode: "INVALID_UNTIL"},
+		{name: "inverted range", router: router, path: "/weather/chicago/history?since=2024-01-02T00:00:00Z&until=2024-01-01T00:00:00Z", wantStatus: http.StatusBadRequest, wantCode: "INVALID_RANGE"},
+		{name: "invalid window", router: router, path: "/weather/chicago/trend?window=-1h", wantStatus: http.StatusBadRequest, wantCode: "INVALID_WINDOW"},
+		{name: "window too long", router: router, path: "/weather/chicago/trend?window=1000h", wantStatus: http.StatusBadRequest, wantCode: "INVALID_WINDOW"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tc.router.ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			_ = json.NewDecoder(w.Body).Decode(&resp)
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/kjstillabower/weather-alert-service/internal/degraded"
+	"github.com/kjstillabower/weather-alert-service/internal/idle"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// SearchLocations handles GET /locations/search?q=. Returns candidate places for an ambiguous
+// name such as "springfield", each with an id that /weather/{location} accepts in place of the
+// name. The query is validated like the location path parameter. Honors Accept like
+// GetWeather; CSV and NDJSON have one record per place.
+func (h *Handler) SearchLocations(w http.ResponseWriter, r *http.Request) {
+	query, err := validation.ValidateLocation(r.URL.Query().Get("q"), h.locationMinLength, h.locationMaxLength)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_QUERY", "q: "+validationErrorMessage(err))
+		return
+	}
+
+	idle.RecordRequest()
+	places, err := h.weatherService.SearchLocations(r.Context(), query)
+	if err != nil {
+		degraded.RecordError()
+		writeServiceError(w, r, err)
+		return
+	}
+	degraded.RecordSuccess()
+	writeNegotiated(w, r, http.StatusOK, representation{
+		body: map[string]interface{}{
+			"query":   query,
+			"results": places,
+			"count":   len(places),
+		},
+		xmlRoot: "search",
+		rows:    rowsOf(places),
+		columns: placeCSVColumns,
+	})
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+var searchLocationsRoute = testRoute{"GET", "/locations/search", (*Handler).SearchLocations}
+
+// TestHandler_SearchLocations verifies candidates are returned and a result id is accepted by
+// GET /weather/{location}.
+func TestHandler_SearchLocations(t *testing.T) {
+	// Arrange
+	router := newTestRouter(newTestHandler(&mockWeatherClient{
+		places:  []models.Place{{ID: "39.80,-89.64", Name: "Springfield", State: "Illinois", Country: "US", Coordinates: models.Coordinates{Lat: 39.799, Lon: -89.644}}},
+		weather: models.WeatherData{Location: "springfield"},
+	}, nil), searchLocationsRoute, getWeatherRoute)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/locations/search?q=springfield", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp struct {
+		Query   string         `json:"query"`
+		Results []models.Place `json:"results"`
+		Count   int            `json:"count"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Query != "springfield" || resp.Count != 1 || resp.Results[0].State != "Illinois" {
+		t.Fatalf("response = %+v", resp)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/"+resp.Results[0].ID, nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("GET /weather/{id} status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+// TestHandler_SearchLocations_Errors verifies invalid queries and upstream failures.
+func TestHandler_SearchLocations_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		client     *mockWeatherClient
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "missing q", path: "/locations/search", client: &mockWeatherClient{}, wantStatus: http.StatusBadRequest, wantCode: "INVALID_QUERY"},
+		{name: "invalid characters", path: "/locations/search?q=spring%3Bfield", client: &mockWeatherClient{}, wantStatus: http.StatusBadRequest, wantCode: "INVALID_QUERY"},
+		{name: "upstream failure", path: "/locations/search?q=springfield", client: &mockWeatherClient{err: errors.New("upstream down")}, wantStatus: http.StatusServiceUnavailable, wantCode: "UPSTREAM_UNAVAILABLE"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newTestRouter(newTestHandler(tc.client, nil), searchLocationsRoute, getWeatherRoute).ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
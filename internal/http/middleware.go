@@ -3,10 +3,11 @@ package http
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/subtle"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -90,6 +91,12 @@ func (s *sizeRecorder) WriteHeader(code int) {
 	s.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap returns the underlying ResponseWriter so http.ResponseController can reach
+// Flush and SetWriteDeadline (needed by SSE streams).
+func (s *sizeRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
 // SizeMetricsMiddleware records request and response body sizes in Prometheus histograms.
 // Request body is read and restored so handlers still receive it. GET requests typically have zero body size.
 func SizeMetricsMiddleware(next http.Handler) http.Handler {
@@ -115,19 +122,70 @@ func SizeMetricsMiddleware(next http.Handler) http.Handler {
 // getRoute returns the route template for the request path to avoid cardinality
 // in metrics. Maps specific paths to templates (e.g., /weather/seattle -> /weather/{location}).
 func getRoute(r *http.Request) string {
-	path := r.URL.Path
+	for _, version := range apiVersions {
+		if rest, ok := strings.CutPrefix(r.URL.Path, version); ok && strings.HasPrefix(rest, "/") {
+			return version + routeTemplate(rest)
+		}
+	}
+	return routeTemplate(r.URL.Path)
+}
+
+// apiVersions are the path prefixes of versioned API routes. getRoute keeps the prefix in
+// the route label.
+var apiVersions = []string{"/v1", "/v2"}
+
+// routeTemplate maps an unversioned path to its route template.
+func routeTemplate(path string) string {
 	switch {
 	case path == "/health":
 		return "/health"
 	case path == "/metrics":
 		return "/metrics"
 	case strings.HasPrefix(path, "/weather/"):
-		return "/weather/{location}"
+		return weatherRoute(path)
+	case strings.HasPrefix(path, "/alerts/subscriptions/"):
+		return "/alerts/subscriptions/{id}"
+	case strings.HasPrefix(path, "/admin/watchlist/"):
+		return "/admin/watchlist/{location}"
+	case strings.HasPrefix(path, "/admin/groups/"):
+		return "/admin/groups/{name}"
+	case strings.HasPrefix(path, "/groups/"):
+		return "/groups/{name}/weather"
 	default:
 		return path
 	}
 }
 
+// weatherSubroutes are the per-location endpoints below /weather/{location}. Other suffixes
+// collapse to /weather/{location} to keep the route label bounded.
+var weatherSubroutes = map[string]struct{}{
+	"stream":      {},
+	"changes":     {},
+	"evaluate":    {},
+	"history":     {},
+	"trend":       {},
+	"alerts":      {},
+	"forecast":    {},
+	"air-quality": {},
+}
+
+// weatherRoute maps /weather/<location>[/<sub>] to its route template.
+func weatherRoute(path string) string {
+	rest := strings.TrimPrefix(path, "/weather/")
+	if rest == "batch" {
+		return "/weather/batch"
+	}
+	if i := strings.LastIndex(rest, "/"); i >= 0 {
+		if strings.HasSuffix(rest[:i], "/alerts") {
+			return "/weather/{location}/alerts/{id}"
+		}
+		if _, ok := weatherSubroutes[rest[i+1:]]; ok {
+			return "/weather/{location}/" + rest[i+1:]
+		}
+	}
+	return "/weather/{location}"
+}
+
 // statusRecorder wraps http.ResponseWriter to capture the HTTP status code
 // written by handlers for metrics recording.
 type statusRecorder struct {
@@ -140,6 +198,11 @@ func (r *statusRecorder) WriteHeader(code int) {
 	r.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // statusCodeString converts HTTP status code to status class string (e.g., 200 -> "2xx", 404 -> "4xx").
 // Used for metrics labeling to group status codes by class.
 func statusCodeString(code int) string {
@@ -166,12 +229,34 @@ func RateLimitMiddleware(limiter *rate.Limiter) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if !limiter.Allow() {
-				if logger, ok := r.Context().Value("logger").(*zap.Logger); ok && logger != nil {
-					logger.Debug("rate limit denied")
-				}
-				overload.RecordDenial()
-				observability.RateLimitDeniedTotal.Inc()
-				writeRateLimitError(w, r)
+				rejectRateLimited(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rejectRateLimited records a rate-limit denial and writes the 429 response. Used by the
+// middleware and by handlers that take additional tokens, such as the batch endpoint.
+func rejectRateLimited(w http.ResponseWriter, r *http.Request) {
+	if logger, ok := r.Context().Value("logger").(*zap.Logger); ok && logger != nil {
+		logger.Debug("rate limit denied")
+	}
+	overload.RecordDenial()
+	observability.RateLimitDeniedTotal.Inc()
+	writeRateLimitError(w, r)
+}
+
+// AdminAuthMiddleware requires "Authorization: Bearer <token>" on admin routes. The token is
+// compared in constant time. Returns 401 UNAUTHORIZED in the standard error format otherwise.
+func AdminAuthMiddleware(token string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "valid admin bearer token required")
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -179,20 +264,22 @@ func RateLimitMiddleware(limiter *rate.Limiter) mux.MiddlewareFunc {
 	}
 }
 
+// DeprecationMiddleware marks unversioned API routes as deprecated in favor of /v1: it sets
+// Deprecation to since (RFC 9745) and a Link to the same path under /v1 with rel
+// "successor-version". Responses are otherwise unchanged.
+func DeprecationMiddleware(since time.Time) mux.MiddlewareFunc {
+	deprecation := "@" + strconv.FormatInt(since.Unix(), 10)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", deprecation)
+			w.Header().Add("Link", "</v1"+r.URL.EscapedPath()+`>; rel="successor-version"`)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // writeRateLimitError writes a 429 Too Many Requests error response in the standard error format.
 // Includes correlation ID from request context if available.
 func writeRateLimitError(w http.ResponseWriter, r *http.Request) {
-	corrID := ""
-	if v := r.Context().Value("correlation_id"); v != nil {
-		corrID = v.(string)
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusTooManyRequests)
-	_ = json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":      "RATE_LIMITED",
-			"message":   "Too many requests",
-			"requestId": corrID,
-		},
-	})
+	writeError(w, r, http.StatusTooManyRequests, "RATE_LIMITED", "Too many requests")
 }
@@ -419,3 +419,51 @@ func TestMiddleware_SizeMetricsMiddleware_RecordsSizes(t *testing.T) {
 		t.Errorf("echo body = %q, want hello", got)
 	}
 }
+
+// TestDeprecationMiddleware verifies the Deprecation date and successor Link headers.
+func TestDeprecationMiddleware(t *testing.T) {
+	// Arrange
+	router := mux.NewRouter()
+	router.Use(DeprecationMiddleware(time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)))
+	router.HandleFunc("/weather/{location}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/new%20york", nil))
+
+	// Assert
+	if got := w.Header().Get("Deprecation"); got != "@1792108800" {
+		t.Errorf("Deprecation = %q, want @1792108800", got)
+	}
+	if got := w.Header().Get("Link"); got != `</v1/weather/new%20york>; rel="successor-version"` {
+		t.Errorf("Link = %q", got)
+	}
+}
+
+// TestGetRoute_Versioned verifies versioned paths keep their prefix in the route label.
+func TestGetRoute_Versioned(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/weather/chicago", want: "/weather/{location}"},
+		{path: "/v1/weather/chicago", want: "/v1/weather/{location}"},
+		{path: "/v2/weather/chicago/forecast", want: "/v2/weather/{location}/forecast"},
+		{path: "/v1/weather/beijing/air-quality", want: "/v1/weather/{location}/air-quality"},
+		{path: "/v1/weather/chicago/alerts/5c1f", want: "/v1/weather/{location}/alerts/{id}"},
+		{path: "/v2/locations/search", want: "/v2/locations/search"},
+		{path: "/v1/groups/northeast/weather", want: "/v1/groups/{name}/weather"},
+		{path: "/admin/groups/northeast", want: "/admin/groups/{name}"},
+		{path: "/v10/weather/chicago", want: "/v10/weather/chicago"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			if got := getRoute(httptest.NewRequest("GET", tc.path, nil)); got != tc.want {
+				t.Errorf("getRoute(%q) = %q, want %q", tc.path, got, tc.want)
+			}
+		})
+	}
+}
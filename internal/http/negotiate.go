@@ -0,0 +1,346 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// responseFormat is a response encoding chosen from the Accept header.
+type responseFormat int
+
+const (
+	formatJSON responseFormat = iota
+	formatCSV
+	formatNDJSON
+	formatXML
+)
+
+// formatMediaTypes maps Accept media types to the formats they select. JSON is the default
+// and what wildcards select.
+var formatMediaTypes = map[string]responseFormat{
+	"application/json":     formatJSON,
+	"text/csv":             formatCSV,
+	"application/x-ndjson": formatNDJSON,
+	"application/xml":      formatXML,
+	"text/xml":             formatXML,
+}
+
+// contentType returns the Content-Type header value for f.
+func (f responseFormat) contentType() string {
+	switch f {
+	case formatCSV:
+		return "text/csv; charset=utf-8"
+	case formatNDJSON:
+		return "application/x-ndjson"
+	case formatXML:
+		return "application/xml; charset=utf-8"
+	default:
+		return "application/json"
+	}
+}
+
+// acceptRange is one media range of an Accept header.
+type acceptRange struct {
+	mediaType string  // lowercased, without parameters
+	q         float64 // quality, 0 to 1
+}
+
+// parseAccept returns the media ranges of the request's Accept header in order. Ranges with
+// a malformed q are treated as q=1.
+func parseAccept(r *http.Request) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		if mediaType == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(param, "=")
+			if strings.TrimSpace(name) == "q" {
+				if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && v >= 0 && v <= 1 {
+					q = v
+				}
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, q: q})
+	}
+	return ranges
+}
+
+// negotiateFormat picks the response format for the request's Accept header: the supported
+// media type with the highest q, preferring exact types over wildcards (which select JSON) at
+// equal q. Falls back to JSON when nothing supported is acceptable.
+func negotiateFormat(r *http.Request) responseFormat {
+	best, bestQ, bestExact := formatJSON, 0.0, false
+	for _, ar := range parseAccept(r) {
+		format, exact := formatMediaTypes[ar.mediaType]
+		if !exact && ar.mediaType != "*/*" && ar.mediaType != "application/*" {
+			continue
+		}
+		if ar.q > bestQ || (ar.q == bestQ && exact && !bestExact) {
+			best, bestQ, bestExact = format, ar.q, exact
+		}
+	}
+	if bestQ == 0 {
+		return formatJSON
+	}
+	return best
+}
+
+// representation is a response body in the shapes the formats need: JSON and XML encode a
+// document, CSV and NDJSON a list of records.
+type representation struct {
+	body    interface{}   // JSON document; also the XML document unless xmlBody is set
+	xmlRoot string        // XML root element name
+	xmlBody interface{}   // XML document when it differs from body
+	rows    []interface{} // CSV rows and NDJSON lines
+	columns []string      // CSV header, fixed per resource type
+}
+
+// CSV columns per resource type. Fields the JSON omits for a row (windGust, country, heatIndex
+// and other optional values) keep their column and get an empty cell, so the header does not
+// depend on the data.
+var (
+	weatherCSVColumns = []string{
+		"location", "country", "coordinates.lat", "coordinates.lon", "temperature", "feelsLike",
+		"temperatureMin", "temperatureMax", "conditions", "humidity", "pressure", "visibility",
+		"cloudiness", "windSpeed", "windDirection", "windGust", "rain1h", "snow1h", "sunrise",
+		"sunset", "timestamp", "fetchedAt", "stale", "units", "dewPoint", "heatIndex", "windChill",
+		"apparentTemperature",
+	}
+	locationResultCSVColumns = append(append([]string{"location"}, prefixColumns("weather.", weatherCSVColumns)...), "error.category", "error.message")
+	placeCSVColumns          = []string{"id", "name", "state", "country", "coordinates.lat", "coordinates.lon"}
+	errorCSVColumns          = []string{"error.code", "error.message", "error.requestId"}
+)
+
+// prefixColumns returns columns with prefix prepended to each.
+func prefixColumns(prefix string, columns []string) []string {
+	prefixed := make([]string, len(columns))
+	for i, c := range columns {
+		prefixed[i] = prefix + c
+	}
+	return prefixed
+}
+
+// rowsOf converts items to representation rows.
+func rowsOf[T any](items []T) []interface{} {
+	rows := make([]interface{}, len(items))
+	for i := range items {
+		rows[i] = items[i]
+	}
+	return rows
+}
+
+// writeNegotiated writes rep with status in the format negotiated from the Accept header.
+func writeNegotiated(w http.ResponseWriter, r *http.Request, status int, rep representation) {
+	format := negotiateFormat(r)
+	body, err := rep.encode(format)
+	if err != nil {
+		format, body = formatJSON, []byte(`{"error":{"code":"INTERNAL_ERROR","message":"Unable to encode response"}}`+"\n")
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", format.contentType())
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// encode returns rep in format. Every format ends with a newline.
+func (rep representation) encode(format responseFormat) ([]byte, error) {
+	var buf bytes.Buffer
+	switch format {
+	case formatNDJSON:
+		enc := json.NewEncoder(&buf)
+		for _, row := range rep.rows {
+			if err := enc.Encode(row); err != nil {
+				return nil, err
+			}
+		}
+	case formatCSV:
+		if err := writeCSV(&buf, rep.columns, rep.rows); err != nil {
+			return nil, err
+		}
+	case formatXML:
+		doc := rep.body
+		if rep.xmlBody != nil {
+			doc = rep.xmlBody
+		}
+		tree, err := orderedTree(doc)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(xml.Header)
+		enc := xml.NewEncoder(&buf)
+		if err := writeXMLElement(enc, rep.xmlRoot, tree); err != nil {
+			return nil, err
+		}
+		if err := enc.Flush(); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+	default:
+		if err := json.NewEncoder(&buf).Encode(rep.body); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// orderedField is a member of a JSON object decoded by orderedTree.
+type orderedField struct {
+	key   string
+	value interface{}
+}
+
+// orderedTree returns v's JSON form as a tree that keeps object member order: objects are
+// []orderedField, arrays []interface{}, numbers json.Number, and other scalars as decoded by
+// encoding/json. XML elements therefore follow the JSON field order and names.
+func orderedTree(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return decodeOrdered(dec)
+}
+
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		fields := []orderedField{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, orderedField{key: key.(string), value: value})
+		}
+		_, err = dec.Token()
+		return fields, err
+	case json.Delim('['):
+		items := []interface{}{}
+		for dec.More() {
+			item, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err = dec.Token()
+		return items, err
+	default:
+		return tok, nil
+	}
+}
+
+// writeXMLElement writes node as element name: object members become child elements, array
+// items become <item> children, and null an empty element.
+func writeXMLElement(enc *xml.Encoder, name string, node interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	switch node := node.(type) {
+	case []orderedField:
+		for _, f := range node {
+			if err := writeXMLElement(enc, f.key, f.value); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range node {
+			if err := writeXMLElement(enc, "item", item); err != nil {
+				return err
+			}
+		}
+	case nil:
+	default:
+		if err := enc.EncodeToken(xml.CharData(scalarText(node))); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// writeCSV writes rows as CSV under a header of columns. Nested fields are flattened to
+// dotted column names (coordinates.lat, error.code); cells a row lacks are empty, and fields
+// outside columns are left out.
+func writeCSV(buf *bytes.Buffer, columns []string, rows []interface{}) error {
+	cw := csv.NewWriter(buf)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	line := make([]string, len(columns))
+	for _, row := range rows {
+		tree, err := orderedTree(row)
+		if err != nil {
+			return err
+		}
+		record := map[string]string{}
+		flattenCSV("", tree, func(column, value string) {
+			record[column] = value
+		})
+		for i, column := range columns {
+			line[i] = record[column]
+		}
+		if err := cw.Write(line); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// flattenCSV calls emit for each scalar under node with its dotted path from prefix. Array
+// items are keyed by index.
+func flattenCSV(prefix string, node interface{}, emit func(column, value string)) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+	switch node := node.(type) {
+	case []orderedField:
+		for _, f := range node {
+			flattenCSV(join(f.key), f.value, emit)
+		}
+	case []interface{}:
+		for i, item := range node {
+			flattenCSV(join(strconv.Itoa(i)), item, emit)
+		}
+	default:
+		emit(prefix, scalarText(node))
+	}
+}
+
+// scalarText formats a decoded JSON scalar; null is empty.
+func scalarText(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,227 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// TestNegotiateFormat verifies q-values, wildcards and the JSON fallback.
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   responseFormat
+	}{
+		{accept: "", want: formatJSON},
+		{accept: "text/csv", want: formatCSV},
+		{accept: "application/x-ndjson", want: formatNDJSON},
+		{accept: "application/xml", want: formatXML},
+		{accept: "TEXT/XML; charset=utf-8", want: formatXML},
+		{accept: "text/html", want: formatJSON},
+		{accept: "*/*", want: formatJSON},
+		{accept: "text/csv, */*", want: formatCSV},
+		{accept: "*/*, text/csv", want: formatCSV},
+		{accept: "text/csv;q=0.5, application/json", want: formatJSON},
+		{accept: "application/json;q=0.5, application/xml;q=0.9", want: formatXML},
+		{accept: "text/csv;q=0", want: formatJSON},
+		{accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", want: formatXML},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.accept, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Accept", tc.accept)
+			if got := negotiateFormat(r); got != tc.want {
+				t.Errorf("negotiateFormat(%q) = %v, want %v", tc.accept, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestHandler_GetWeather_Formats verifies each negotiated format's content type and body, and
+// that ETags differ by representation.
+func TestHandler_GetWeather_Formats(t *testing.T) {
+	// Arrange
+	router := newTestRouter(newTestHandler(&mockWeatherClient{weather: models.WeatherData{
+		Location:    "Chicago",
+		Coordinates: &models.Coordinates{Lat: 41.88, Lon: -87.63},
+		Temperature: 21.5,
+		Conditions:  "light rain, mist",
+		Timestamp:   time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+	}}, nil), getWeatherRoute)
+
+	etags := map[string]bool{}
+	for _, accept := range []string{"application/json", "text/csv", "application/x-ndjson", "application/xml"} {
+		t.Run(accept, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/weather/chicago", nil)
+			req.Header.Set("Accept", accept)
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, accept) {
+				t.Errorf("Content-Type = %q, want %s", ct, accept)
+			}
+			if w.Header().Get("Vary") != "Accept" {
+				t.Errorf("Vary = %q, want Accept", w.Header().Get("Vary"))
+			}
+			etags[w.Header().Get("ETag")] = true
+
+			body := w.Body.String()
+			switch accept {
+			case "text/csv":
+				records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+				if err != nil || len(records) != 2 {
+					t.Fatalf("csv records = %v, err %v; want header and one row", records, err)
+				}
+				row := map[string]string{}
+				for i, column := range records[0] {
+					row[column] = records[1][i]
+				}
+				if strings.Join(records[0], ",") != strings.Join(weatherCSVColumns, ",") {
+					t.Errorf("csv header = %v, want the fixed weather columns", records[0])
+				}
+				if row["location"] != "Chicago" || row["coordinates.lat"] != "41.88" || row["conditions"] != "light rain, mist" || row["units"] != "metric" || row["windGust"] != "" {
+					t.Errorf("csv row = %v", row)
+				}
+			case "application/x-ndjson":
+				if strings.Count(body, "\n") != 1 || !strings.Contains(body, `"location":"Chicago"`) {
+					t.Errorf("ndjson body = %q, want one line", body)
+				}
+			case "application/xml":
+				var doc struct {
+					XMLName     xml.Name `xml:"weather"`
+					Location    string   `xml:"location"`
+					Temperature float64  `xml:"temperature"`
+					Lat         float64  `xml:"coordinates>lat"`
+				}
+				if err := xml.Unmarshal([]byte(body), &doc); err != nil {
+					t.Fatalf("xml: %v\n%s", err, body)
+				}
+				if doc.Location != "Chicago" || doc.Temperature != 21.5 || doc.Lat != 41.88 {
+					t.Errorf("xml = %+v", doc)
+				}
+			}
+		})
+	}
+	if len(etags) != 4 {
+		t.Errorf("ETags = %v, want one per format", etags)
+	}
+}
+
+// TestWriteError_Negotiated verifies error responses use the negotiated format.
+func TestWriteError_Negotiated(t *testing.T) {
+	router := newTestRouter(newTestHandler(&mockWeatherClient{weather: models.WeatherData{}}, nil), getWeatherRoute)
+	tests := []struct {
+		accept string
+		check  func(t *testing.T, body string)
+	}{
+		{accept: "text/csv", check: func(t *testing.T, body string) {
+			if !strings.HasPrefix(body, "error.code,error.message,error.requestId\nINVALID_LOCATION,") {
+				t.Errorf("csv error = %q", body)
+			}
+		}},
+		{accept: "application/x-ndjson", check: func(t *testing.T, body string) {
+			var resp map[string]map[string]string
+			if err := json.Unmarshal([]byte(body), &resp); err != nil || resp["error"]["code"] != "INVALID_LOCATION" {
+				t.Errorf("ndjson error = %q (%v)", body, err)
+			}
+		}},
+		{accept: "application/xml", check: func(t *testing.T, body string) {
+			var doc struct {
+				XMLName xml.Name `xml:"error"`
+				Code    string   `xml:"code"`
+			}
+			if err := xml.Unmarshal([]byte(body), &doc); err != nil || doc.Code != "INVALID_LOCATION" {
+				t.Errorf("xml error = %q (%v)", body, err)
+			}
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.accept, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/weather/chicago;", nil)
+			req.Header.Set("Accept", tc.accept)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+			tc.check(t, w.Body.String())
+		})
+	}
+}
+
+// TestHandler_GetWeatherBatch_CSV verifies batch CSV has one row per location with weather
+// and error columns.
+func TestHandler_GetWeatherBatch_CSV(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest("POST", "/weather/batch", strings.NewReader(`{"locations": ["chicago", "atlantis"]}`))
+	req.Header.Set("Accept", "text/csv")
+
+	// Act
+	w := httptest.NewRecorder()
+	newBatchRouter(nil, 10).ServeHTTP(w, req)
+
+	// Assert
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil || len(records) != 3 {
+		t.Fatalf("records = %v, err %v; want header and two rows", records, err)
+	}
+	if strings.Join(records[0], ",") != strings.Join(locationResultCSVColumns, ",") {
+		t.Errorf("header = %v, want the fixed location result columns", records[0])
+	}
+	column := map[string]int{}
+	for i, name := range records[0] {
+		column[name] = i
+	}
+	if records[1][column["weather.temperature"]] != "20" || records[2][column["error.category"]] != "location_not_found" || records[2][column["weather.location"]] != "" {
+		t.Errorf("records = %v", records)
+	}
+}
+
+// TestWeatherCSVColumns_EveryField verifies the fixed weather columns cover every field of
+// fully populated weather data, so no field is dropped from CSV.
+func TestWeatherCSVColumns_EveryField(t *testing.T) {
+	// Arrange
+	v := 1.5
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	data := models.WeatherData{
+		Location: "Chicago", Country: "US", Coordinates: &models.Coordinates{Lat: 41.88, Lon: -87.63},
+		Temperature: 1, FeelsLike: 1, TemperatureMin: 1, TemperatureMax: 1, Conditions: "snow",
+		Humidity: 1, Pressure: 1, Visibility: 1, Cloudiness: 1, WindSpeed: 1, WindDirection: 1,
+		WindGust: 1, Rain1h: 1, Snow1h: 1, Sunrise: now, Sunset: now, Timestamp: now, FetchedAt: now,
+		Stale: true, Units: "metric", DewPoint: &v, HeatIndex: &v, WindChill: &v, ApparentTemperature: &v,
+	}
+	known := map[string]bool{}
+	for _, c := range weatherCSVColumns {
+		known[c] = true
+	}
+
+	// Act
+	tree, err := orderedTree(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("orderedTree() error = %v", err)
+	}
+	flattenCSV("", tree, func(column, _ string) {
+		if !known[column] {
+			t.Errorf("field %q has no CSV column", column)
+		}
+	})
+}
@@ -0,0 +1,293 @@
+package http
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kjstillabower/weather-alert-service/internal/client"
+	"github.com/kjstillabower/weather-alert-service/internal/degraded"
+	"github.com/kjstillabower/weather-alert-service/internal/idle"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// capMediaType is the CAP 1.2 media type clients send in Accept to get a single alert as a CAP
+// document. CAP defines one alert per document, so lists are served as an Atom feed instead.
+const capMediaType = "application/cap+xml"
+
+// atomMediaType is the Atom media type clients send in Accept to get the alert list as a feed
+// of CAP alerts, the form the US National Weather Service publishes.
+const atomMediaType = "application/atom+xml"
+
+// GetOfficialAlerts handles GET /weather/{location}/alerts. Returns government-issued alerts
+// from the upstream provider as JSON, or as an Atom feed with one CAP 1.2 alert per entry when
+// the Accept header lists application/atom+xml. Each alert carries an id that addresses it at
+// /weather/{location}/alerts/{id}. Returns 501 ALERTS_UNSUPPORTED when the provider (or API
+// key plan) offers no alerts.
+func (h *Handler) GetOfficialAlerts(w http.ResponseWriter, r *http.Request) {
+	location, alerts, ok := h.officialAlerts(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Vary", "Accept")
+	if acceptsMediaType(r, atomMediaType) {
+		writeAtom(w, r.URL.Path, location, alerts)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"location": location,
+		"alerts":   alerts,
+		"count":    len(alerts),
+	})
+}
+
+// GetOfficialAlert handles GET /weather/{location}/alerts/{id}. Returns one alert from the
+// current list as JSON, or as a CAP 1.2 document when the Accept header lists
+// application/cap+xml. Returns 404 ALERT_NOT_FOUND when no current alert has the id, including
+// after the alert has expired.
+func (h *Handler) GetOfficialAlert(w http.ResponseWriter, r *http.Request) {
+	location, alerts, ok := h.officialAlerts(w, r)
+	if !ok {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	for _, a := range alerts {
+		if a.ID != id {
+			continue
+		}
+		w.Header().Set("Vary", "Accept")
+		if acceptsMediaType(r, capMediaType) {
+			w.Header().Set("Content-Type", capMediaType+"; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(xml.Header))
+			_ = xml.NewEncoder(w).Encode(newCAPAlert(location, a))
+			return
+		}
+		writeJSON(w, http.StatusOK, a)
+		return
+	}
+	writeError(w, r, http.StatusNotFound, "ALERT_NOT_FOUND", "no current alert with this id")
+}
+
+// officialAlerts validates the location and fetches its alerts with ids set, writing the error
+// response and returning false on failure.
+func (h *Handler) officialAlerts(w http.ResponseWriter, r *http.Request) (string, []models.OfficialAlert, bool) {
+	location, err := validation.ValidateLocation(mux.Vars(r)["location"], h.locationMinLength, h.locationMaxLength)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", validationErrorMessage(err))
+		return "", nil, false
+	}
+
+	idle.RecordRequest()
+	alerts, err := h.weatherService.GetOfficialAlerts(r.Context(), location)
+	if errors.Is(err, client.ErrAlertsUnsupported) {
+		observability.HTTPErrorsTotal.WithLabelValues(r.Method, getRoute(r), string(client.ErrorCategoryAlertsUnsupported)).Inc()
+		writeError(w, r, http.StatusNotImplemented, "ALERTS_UNSUPPORTED", "official alerts are not available from the configured weather provider")
+		return "", nil, false
+	}
+	if err != nil {
+		degraded.RecordError()
+		writeServiceError(w, r, err)
+		return "", nil, false
+	}
+	degraded.RecordSuccess()
+	for i := range alerts {
+		alerts[i].ID = alertIdentifier(alerts[i])
+	}
+	return location, alerts, true
+}
+
+// acceptsMediaType reports whether the request's Accept header lists mediaType with a
+// non-zero q. Other parameters are ignored.
+func acceptsMediaType(r *http.Request, mediaType string) bool {
+	for _, ar := range parseAccept(r) {
+		if ar.q > 0 && strings.EqualFold(ar.mediaType, mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+// atomFeed is an Atom feed of official alerts. Each entry links to the alert's CAP document
+// and embeds the same CAP alert as its content.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Summary string      `xml:"summary,omitempty"`
+	Link    atomLink    `xml:"link"`
+	Content atomContent `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+// atomContent holds an XML media type, so its single child element is the content.
+type atomContent struct {
+	Type  string   `xml:"type,attr"`
+	Alert capAlert `xml:"urn:oasis:names:tc:emergency:cap:1.2 alert"`
+}
+
+// capAlert is the subset of a CAP 1.2 alert message the upstream data can fill.
+type capAlert struct {
+	XMLName    xml.Name `xml:"urn:oasis:names:tc:emergency:cap:1.2 alert"`
+	Identifier string   `xml:"identifier"`
+	Sender     string   `xml:"sender"`
+	Sent       string   `xml:"sent"`
+	Status     string   `xml:"status"`
+	MsgType    string   `xml:"msgType"`
+	Scope      string   `xml:"scope"`
+	Info       capInfo  `xml:"info"`
+}
+
+type capInfo struct {
+	Category    string  `xml:"category"`
+	Event       string  `xml:"event"`
+	Urgency     string  `xml:"urgency"`
+	Severity    string  `xml:"severity"`
+	Certainty   string  `xml:"certainty"`
+	Onset       string  `xml:"onset,omitempty"`
+	Expires     string  `xml:"expires,omitempty"`
+	SenderName  string  `xml:"senderName,omitempty"`
+	Description string  `xml:"description,omitempty"`
+	Area        capArea `xml:"area"`
+}
+
+type capArea struct {
+	AreaDesc string `xml:"areaDesc"`
+}
+
+// writeAtom writes alerts as an Atom feed of CAP 1.2 alerts. path is the request path of the
+// list, so entry links keep the API version. The feed is updated as of its newest alert, or now
+// when it has none.
+func writeAtom(w http.ResponseWriter, path, location string, alerts []models.OfficialAlert) {
+	feed := atomFeed{
+		ID:      "urn:weather-alert-service:alerts:" + url.PathEscape(location),
+		Title:   "Official alerts for " + location,
+		Author:  atomAuthor{Name: "weather-alert-service"},
+		Link:    atomLink{Rel: "self", Type: atomMediaType, Href: path},
+		Entries: make([]atomEntry, 0, len(alerts)),
+	}
+	var updated time.Time
+	for _, a := range alerts {
+		alert := newCAPAlert(location, a)
+		sent := capSent(a)
+		if sent.After(updated) {
+			updated = sent
+		}
+		author := a.Sender
+		if author == "" {
+			author = "unknown"
+		}
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:      "urn:weather-alert-service:alert:" + a.ID,
+			Title:   a.Event,
+			Updated: sent.UTC().Format(time.RFC3339),
+			Author:  atomAuthor{Name: author},
+			Summary: a.Description,
+			Link:    atomLink{Rel: "alternate", Type: capMediaType, Href: path + "/" + a.ID},
+			Content: atomContent{Type: capMediaType, Alert: alert},
+		})
+	}
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	feed.Updated = updated.UTC().Format(time.RFC3339)
+
+	w.Header().Set("Content-Type", atomMediaType+"; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(feed)
+}
+
+// newCAPAlert converts an official alert to CAP 1.2. The upstream provider reports no urgency,
+// severity or certainty, so those are "Unknown".
+func newCAPAlert(location string, a models.OfficialAlert) capAlert {
+	return capAlert{
+		Identifier: a.ID,
+		Sender:     capToken(a.Sender),
+		Sent:       capTime(capSent(a)),
+		Status:     "Actual",
+		MsgType:    "Alert",
+		Scope:      "Public",
+		Info: capInfo{
+			Category:    "Met",
+			Event:       a.Event,
+			Urgency:     "Unknown",
+			Severity:    "Unknown",
+			Certainty:   "Unknown",
+			Onset:       capTime(a.Start),
+			Expires:     capTime(a.End),
+			SenderName:  a.Sender,
+			Description: a.Description,
+			Area:        capArea{AreaDesc: location},
+		},
+	}
+}
+
+// capSent is the CAP sent time: the alert start, or now when it has none.
+func capSent(a models.OfficialAlert) time.Time {
+	if a.Start.IsZero() {
+		return time.Now()
+	}
+	return a.Start
+}
+
+// alertIdentifier derives a stable identifier from sender, event and start so repeated fetches
+// of the same alert keep the same identifier.
+func alertIdentifier(a models.OfficialAlert) string {
+	sum := sha1.Sum([]byte(a.Sender + "\x00" + a.Event + "\x00" + a.Start.UTC().Format(time.RFC3339)))
+	return hex.EncodeToString(sum[:])
+}
+
+// capToken replaces the characters CAP forbids in sender values (whitespace, commas, < and &)
+// with underscores.
+func capToken(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\n', '\r', ',', '<', '&':
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(s))
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// capTime formats t as a CAP dateTime: explicit offset, with UTC written as -00:00.
+func capTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02T15:04:05") + "-00:00"
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/client"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+var officialAlertsRoutes = []testRoute{
+	{"GET", "/weather/{location}/alerts", (*Handler).GetOfficialAlerts},
+	{"GET", "/weather/{location}/alerts/{id}", (*Handler).GetOfficialAlert},
+}
+
+var windAdvisory = models.OfficialAlert{
+	Sender:      "NWS Chicago, IL",
+	Event:       "Wind Advisory",
+	Start:       time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	End:         time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC),
+	Description: "Gusts to 50 mph.",
+}
+
+// TestHandler_GetOfficialAlerts_JSON verifies alerts are returned as JSON by default.
+func TestHandler_GetOfficialAlerts_JSON(t *testing.T) {
+	// Arrange
+	router := newTestRouter(newTestHandler(&mockWeatherClient{alerts: []models.OfficialAlert{windAdvisory}}, nil), officialAlertsRoutes...)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/weather/chicago/alerts", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var resp struct {
+		Location string                 `json:"location"`
+		Alerts   []models.OfficialAlert `json:"alerts"`
+		Count    int                    `json:"count"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Location != "chicago" || resp.Count != 1 || resp.Alerts[0].Event != "Wind Advisory" || resp.Alerts[0].ID != alertIdentifier(windAdvisory) {
+		t.Errorf("response = %+v, want one Wind Advisory for chicago with its id", resp)
+	}
+}
+
+// TestHandler_GetOfficialAlerts_Atom verifies Accept: application/atom+xml returns an Atom feed
+// whose entry links to the alert's CAP document and embeds the CAP 1.2 alert.
+func TestHandler_GetOfficialAlerts_Atom(t *testing.T) {
+	// Arrange
+	router := newTestRouter(newTestHandler(&mockWeatherClient{alerts: []models.OfficialAlert{windAdvisory}}, nil), officialAlertsRoutes...)
+	req := httptest.NewRequest("GET", "/weather/chicago/alerts", nil)
+	req.Header.Set("Accept", "text/html;q=0.9, application/atom+xml")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/atom+xml") {
+		t.Errorf("Content-Type = %q, want application/atom+xml", ct)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `<feed xmlns="http://www.w3.org/2005/Atom">`) || !strings.Contains(body, `<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2">`) {
+		t.Errorf("body missing Atom or CAP 1.2 namespace: %s", body)
+	}
+	var feed atomFeed
+	if err := xml.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(feed.Entries) != 1 || feed.Updated != "2024-01-01T12:00:00Z" {
+		t.Fatalf("feed = %+v, want 1 entry updated at the alert start", feed)
+	}
+	id := alertIdentifier(windAdvisory)
+	entry := feed.Entries[0]
+	if entry.Title != "Wind Advisory" || entry.Link.Href != "/weather/chicago/alerts/"+id || entry.Link.Type != "application/cap+xml" || entry.Content.Type != "application/cap+xml" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if entry.Content.Alert.Identifier != id || entry.Content.Alert.Info.Event != "Wind Advisory" {
+		t.Errorf("entry alert = %+v", entry.Content.Alert)
+	}
+}
+
+// TestHandler_GetOfficialAlert_CAP verifies Accept: application/cap+xml on a single alert returns
+// a CAP 1.2 document with sender restricted characters replaced and UTC written as -00:00.
+func TestHandler_GetOfficialAlert_CAP(t *testing.T) {
+	// Arrange
+	router := newTestRouter(newTestHandler(&mockWeatherClient{alerts: []models.OfficialAlert{windAdvisory}}, nil), officialAlertsRoutes...)
+	req := httptest.NewRequest("GET", "/weather/chicago/alerts/"+alertIdentifier(windAdvisory), nil)
+	req.Header.Set("Accept", "application/cap+xml")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/cap+xml") {
+		t.Errorf("Content-Type = %q, want application/cap+xml", ct)
+	}
+	if body := w.Body.String(); !strings.Contains(body, `<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2">`) {
+		t.Errorf("body is not a CAP 1.2 alert document: %s", body)
+	}
+	var got capAlert
+	if err := xml.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Sender != "NWS_Chicago__IL" || got.Sent != "2024-01-01T12:00:00-00:00" || got.Info.Expires != "2024-01-01T18:00:00-00:00" {
+		t.Errorf("sender/sent/expires = %q %q %q", got.Sender, got.Sent, got.Info.Expires)
+	}
+	if got.Info.Event != "Wind Advisory" || got.Info.SenderName != "NWS Chicago, IL" || got.Info.Area.AreaDesc != "chicago" || got.Identifier != alertIdentifier(windAdvisory) {
+		t.Errorf("info = %+v, identifier = %q", got.Info, got.Identifier)
+	}
+}
+
+// TestHandler_GetOfficialAlerts_Errors verifies invalid locations, upstream failures, providers
+// without official alerts, and unknown alert ids.
+func TestHandler_GetOfficialAlerts_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		client     *mockWeatherClient
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "invalid location", path: "/weather/chicago%3B/alerts", client: &mockWeatherClient{}, wantStatus: http.StatusBadRequest, wantCode: "INVALID_LOCATION"},
+		{name: "upstream failure", path: "/weather/chicago/alerts", client: &mockWeatherClient{err: errors.New("upstream down")}, wantStatus: http.StatusServiceUnavailable, wantCode: "UPSTREAM_UNAVAILABLE"},
+		{name: "provider unsupported", path: "/weather/chicago/alerts", client: &mockWeatherClient{err: client.ErrAlertsUnsupported}, wantStatus: http.StatusNotImplemented, wantCode: "ALERTS_UNSUPPORTED"},
+		{name: "unknown alert", path: "/weather/chicago/alerts/0000", client: &mockWeatherClient{alerts: []models.OfficialAlert{windAdvisory}}, wantStatus: http.StatusNotFound, wantCode: "ALERT_NOT_FOUND"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newTestRouter(newTestHandler(tc.client, nil), officialAlertsRoutes...).ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			_ = json.NewDecoder(w.Body).Decode(&resp)
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
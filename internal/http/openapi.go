@@ -0,0 +1,170 @@
+package http
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPISource is the hand-maintained part of the OpenAPI document; see OpenAPISpec.
+//
+//go:embed openapi.yaml
+var openAPISource []byte
+
+// openAPIMethods are the path item keys that are operations.
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// OpenAPISpec returns the OpenAPI 3.1 document for the routes registered on router, as JSON.
+// Operations documented under /v1 are also published at the unversioned path (marked
+// deprecated) and under /v2 unless /v2 documents its own; operations whose route is not
+// registered, such as those of disabled features, are dropped. undocumented lists registered
+// routes ("METHOD /template") that have no operation in the document.
+func OpenAPISpec(router *mux.Router) (spec []byte, undocumented []string, err error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(openAPISource, &doc); err != nil {
+		return nil, nil, fmt.Errorf("parse openapi.yaml: %w", err)
+	}
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("openapi.yaml has no paths")
+	}
+	expandVersionedPaths(paths)
+
+	registered := map[string]map[string]bool{} // template -> lowercase method (or "" for any)
+	err = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if route.GetHandler() == nil {
+			return nil // subrouter prefix
+		}
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			methods = []string{""}
+		}
+		if registered[template] == nil {
+			registered[template] = map[string]bool{}
+		}
+		for _, method := range methods {
+			method = strings.ToLower(method)
+			registered[template][method] = true
+			item, _ := paths[template].(map[string]interface{})
+			if _, documented := item[method]; !documented && (method != "" || item == nil) {
+				undocumented = append(undocumented, strings.TrimSpace(strings.ToUpper(method)+" "+template))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for path, v := range paths {
+		item, _ := v.(map[string]interface{})
+		methods := registered[path]
+		operations := 0
+		for _, method := range openAPIMethods {
+			if _, ok := item[method]; !ok {
+				continue
+			}
+			if methods[method] || methods[""] {
+				operations++
+			} else {
+				delete(item, method)
+			}
+		}
+		if operations == 0 {
+			delete(paths, path)
+		}
+	}
+
+	sort.Strings(undocumented)
+	spec, err = json.MarshalIndent(doc, "", "  ")
+	return spec, undocumented, err
+}
+
+// expandVersionedPaths copies each /v1 path item to the unversioned path, with operations
+// marked deprecated, and to /v2 unless /v2 already has that path. Copied operation IDs get an
+// "Unversioned" or "V2" suffix so they stay unique.
+func expandVersionedPaths(paths map[string]interface{}) {
+	for path, item := range paths {
+		rest, ok := strings.CutPrefix(path, "/v1/")
+		if !ok {
+			continue
+		}
+		unversioned := copyOpenAPIValue(item).(map[string]interface{})
+		tagOperations(unversioned, "Unversioned", true)
+		paths["/"+rest] = unversioned
+		if _, exists := paths["/v2/"+rest]; !exists {
+			v2 := copyOpenAPIValue(item).(map[string]interface{})
+			tagOperations(v2, "V2", false)
+			paths["/v2/"+rest] = v2
+		}
+	}
+}
+
+// tagOperations appends suffix to each operation's operationId and optionally marks the
+// operations deprecated.
+func tagOperations(item map[string]interface{}, suffix string, deprecated bool) {
+	for _, method := range openAPIMethods {
+		op, ok := item[method].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := op["operationId"].(string); ok {
+			op["operationId"] = id + suffix
+		}
+		if deprecated {
+			op["deprecated"] = true
+		}
+	}
+}
+
+// copyOpenAPIValue deep-copies a decoded YAML value.
+func copyOpenAPIValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			out[k] = copyOpenAPIValue(e)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = copyOpenAPIValue(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// OpenAPIHandler serves OpenAPISpec(router) as application/json. The document is built on the
+// first request, so the handler may be registered on router before the remaining routes.
+func OpenAPIHandler(router *mux.Router) http.Handler {
+	var (
+		once sync.Once
+		spec []byte
+		err  error
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			spec, _, err = OpenAPISpec(router)
+		})
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "OpenAPI document unavailable")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(spec)
+	})
+}
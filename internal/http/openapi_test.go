@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestOpenAPISpec_VersionExpansionAndFiltering verifies /v1 operations are copied to the
+// deprecated unversioned path and to /v2 (unless /v2 overrides them), and that only
+// registered routes are kept and unknown routes reported.
+func TestOpenAPISpec_VersionExpansionAndFiltering(t *testing.T) {
+	// Arrange
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+	router := mux.NewRouter()
+	for _, prefix := range []string{"", "/v1", "/v2"} {
+		router.HandleFunc(prefix+"/weather/{location}", noop).Methods("GET")
+		router.HandleFunc(prefix+"/weather/{location}/forecast", noop).Methods("GET")
+	}
+	router.HandleFunc("/undocumented", noop).Methods("POST")
+
+	// Act
+	spec, undocumented, err := OpenAPISpec(router)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("OpenAPISpec: %v", err)
+	}
+	if want := []string{"POST /undocumented"}; !reflect.DeepEqual(undocumented, want) {
+		t.Errorf("undocumented = %v, want %v", undocumented, want)
+	}
+	var doc struct {
+		Paths map[string]struct {
+			Get struct {
+				OperationID string `json:"operationId"`
+				Deprecated  bool   `json:"deprecated"`
+			} `json:"get"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	tests := []struct {
+		path       string
+		wantID     string
+		deprecated bool
+	}{
+		{path: "/v1/weather/{location}", wantID: "getWeather"},
+		{path: "/weather/{location}", wantID: "getWeatherUnversioned", deprecated: true},
+		{path: "/v2/weather/{location}", wantID: "getWeatherV2"},
+		{path: "/v2/weather/{location}/forecast", wantID: "getForecastV2"},
+		{path: "/weather/{location}/forecast", wantID: "getForecastUnversioned", deprecated: true},
+	}
+	for _, tc := range tests {
+		op := doc.Paths[tc.path].Get
+		if op.OperationID != tc.wantID || op.Deprecated != tc.deprecated {
+			t.Errorf("GET %s = %+v, want operationId %q, deprecated %v", tc.path, op, tc.wantID, tc.deprecated)
+		}
+	}
+	if _, ok := doc.Paths["/health"]; ok {
+		t.Error("unregistered /health kept in spec")
+	}
+}
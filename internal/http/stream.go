@@ -0,0 +1,148 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kjstillabower/weather-alert-service/internal/idle"
+	"github.com/kjstillabower/weather-alert-service/internal/stream"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// StreamConfig holds SSE stream timing for the stream handler.
+type StreamConfig struct {
+	KeepAlive    time.Duration // interval between keepalive comments
+	FetchTimeout time.Duration // bound on the initial snapshot fetch
+	RetryHint    time.Duration // reconnect delay suggested to clients via the retry field
+}
+
+// streamWriteSlack is added to the keepalive interval for each write deadline. Streams outlive
+// the server's WriteTimeout, so every write pushes the deadline forward.
+const streamWriteSlack = 10 * time.Second
+
+// SetStreamHub attaches the hub served by GET /weather/{location}/stream. When unset, the
+// endpoint returns 503.
+func (h *Handler) SetStreamHub(hub *stream.Hub, cfg StreamConfig) {
+	if cfg.KeepAlive <= 0 {
+		cfg.KeepAlive = 15 * time.Second
+	}
+	if cfg.FetchTimeout <= 0 {
+		cfg.FetchTimeout = 5 * time.Second
+	}
+	if cfg.RetryHint <= 0 {
+		cfg.RetryHint = 3 * time.Second
+	}
+	h.streamHub = hub
+	h.streamConfig = cfg
+}
+
+// StreamWeather handles GET /weather/{location}/stream as Server-Sent Events.
+// Without Last-Event-ID the stream starts with a "snapshot" event of current (possibly cached)
+// data; with it, buffered "weather" events after that ID are replayed instead. A reconnect the
+// buffer cannot resume (nothing newer buffered, events evicted, or the service restarted) gets
+// a snapshot like a new connection. Each upstream
+// fetch that repopulates the cache for the location is pushed as a "weather" event with an id.
+// Keepalive comments are sent every KeepAlive. Returns 503 STREAM_LIMIT at the stream cap.
+func (h *Handler) StreamWeather(w http.ResponseWriter, r *http.Request) {
+	if h.streamHub == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "STREAMING_DISABLED", "streaming is not enabled")
+		return
+	}
+	location, err := validation.ValidateLocation(mux.Vars(r)["location"], h.locationMinLength, h.locationMaxLength)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", validationErrorMessage(err))
+		return
+	}
+	lastEventID, _ := strconv.ParseUint(strings.TrimSpace(r.Header.Get("Last-Event-ID")), 10, 64)
+
+	sub, replay, err := h.streamHub.Subscribe(location, lastEventID)
+	if err != nil {
+		if errors.Is(err, stream.ErrTooManyStreams) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(h.streamConfig.RetryHint.Seconds())))
+			writeError(w, r, http.StatusServiceUnavailable, "STREAM_LIMIT", "too many concurrent streams")
+			return
+		}
+		writeError(w, r, http.StatusServiceUnavailable, "STREAMING_DISABLED", "streaming is shutting down")
+		return
+	}
+	defer sub.Close()
+	idle.RecordRequest()
+
+	// Subscribe before the snapshot fetch so an update that lands in between is not lost.
+	var snapshot []byte
+	if len(replay) == 0 {
+		ctx, cancel := context.WithTimeout(r.Context(), h.streamConfig.FetchTimeout)
+		data, err := h.weatherService.GetWeather(ctx, location)
+		cancel()
+		if err != nil {
+			writeServiceError(w, r, err)
+			return
+		}
+		snapshot, _ = json.Marshal(data)
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(format string, args ...interface{}) bool {
+		_ = rc.SetWriteDeadline(time.Now().Add(h.streamConfig.KeepAlive + streamWriteSlack))
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	if !write("retry: %d\n\n", h.streamConfig.RetryHint.Milliseconds()) {
+		return
+	}
+	if snapshot != nil && !write("event: snapshot\ndata: %s\n\n", snapshot) {
+		return
+	}
+	for _, ev := range replay {
+		if !writeStreamEvent(write, ev) {
+			return
+		}
+	}
+
+	keepalive := time.NewTicker(h.streamConfig.KeepAlive)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev, ok := <-sub.C:
+			if !ok {
+				// Dropped as a slow consumer or hub closed; client reconnects with Last-Event-ID.
+				return
+			}
+			if !writeStreamEvent(write, ev) {
+				return
+			}
+		case <-keepalive.C:
+			if !write(": keepalive\n\n") {
+				return
+			}
+		}
+	}
+}
+
+// writeStreamEvent writes ev as an SSE "weather" event with its ID.
+func writeStreamEvent(write func(string, ...interface{}) bool, ev stream.Event) bool {
+	data, err := json.Marshal(ev.Data)
+	if err != nil {
+		return false
+	}
+	return write("id: %d\nevent: weather\ndata: %s\n\n", ev.ID, data)
+}
@@ -0,0 +1,181 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/service"
+	"github.com/kjstillabower/weather-alert-service/internal/stream"
+)
+
+// newStreamServer wires a service, hub and router the way main does and returns a test server.
+func newStreamServer(t *testing.T, hub *stream.Hub, mockClient *mockWeatherClient) (*httptest.Server, *service.WeatherService) {
+	t.Helper()
+	weatherService := service.NewWeatherService(mockClient, &mockCache{}, 5*time.Minute, 0, false, 0)
+	weatherService.AddFetchHook(hub.Publish)
+	handler := NewHandler(weatherService, mockClient, nil, zap.NewNop(), nil, 100, 1)
+	handler.SetStreamHub(hub, StreamConfig{KeepAlive: 50 * time.Millisecond})
+
+	router := mux.NewRouter()
+	router.Use(MetricsMiddleware)
+	router.Use(SizeMetricsMiddleware)
+	router.HandleFunc("/weather/{location}/stream", handler.StreamWeather).Methods("GET")
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv, weatherService
+}
+
+// readUntil reads SSE lines until one contains want or the deadline passes.
+func readUntil(t *testing.T, r *bufio.Reader, want string) string {
+	t.Helper()
+	var seen strings.Builder
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		line, err := r.ReadString('\n')
+		seen.WriteString(line)
+		if strings.Contains(line, want) {
+			return seen.String()
+		}
+		if err != nil {
+			break
+		}
+	}
+	t.Fatalf("did not see %q; got:\n%s", want, seen.String())
+	return ""
+}
+
+// TestHandler_StreamWeather_SnapshotUpdatesKeepalive verifies the stream sends a snapshot,
+// pushes fresh fetches as weather events with IDs, and emits keepalives.
+func TestHandler_StreamWeather_SnapshotUpdatesKeepalive(t *testing.T) {
+	// Arrange
+	hub := stream.NewHub(stream.Config{})
+	mockClient := &mockWeatherClient{weather: models.WeatherData{Location: "chicago", Temperature: 10}}
+	srv, weatherService := newStreamServer(t, hub, mockClient)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL+"/weather/chicago/stream", nil)
+
+	// Act
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Assert
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("status = %d, content-type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	body := bufio.NewReader(resp.Body)
+	readUntil(t, body, "event: snapshot")
+
+	// A fresh fetch (mockCache never hits) publishes an event with an id
+	if _, err := weatherService.GetWeather(context.Background(), "Chicago"); err != nil {
+		t.Fatalf("GetWeather: %v", err)
+	}
+	got := readUntil(t, body, "event: weather")
+	if !strings.Contains(got, "id: ") {
+		t.Errorf("weather event missing id:\n%s", got)
+	}
+	readUntil(t, body, ": keepalive")
+}
+
+// TestHandler_StreamWeather_LastEventIDReplay verifies a reconnect with Last-Event-ID replays
+// buffered events instead of a snapshot.
+func TestHandler_StreamWeather_LastEventIDReplay(t *testing.T) {
+	// Arrange: buffer two events for paris
+	hub := stream.NewHub(stream.Config{})
+	holder, _, _ := hub.Subscribe("paris", 0)
+	hub.Publish(context.Background(), "paris", models.WeatherData{Temperature: 1})
+	hub.Publish(context.Background(), "paris", models.WeatherData{Temperature: 2})
+	holder.Close()
+	srv, _ := newStreamServer(t, hub, &mockWeatherClient{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL+"/weather/paris/stream", nil)
+	req.Header.Set("Last-Event-ID", "1")
+
+	// Act
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Assert: event 2 replayed, no snapshot
+	got := readUntil(t, bufio.NewReader(resp.Body), "id: 2")
+	if strings.Contains(got, "snapshot") || strings.Contains(got, "id: 1\n") {
+		t.Errorf("replay output:\n%s\nwant only event 2 and no snapshot", got)
+	}
+}
+
+// TestHandler_StreamWeather_LastEventIDSnapshot verifies a reconnect the buffer cannot resume,
+// such as after a restart or an eviction, starts with a snapshot.
+func TestHandler_StreamWeather_LastEventIDSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		lastEventID string
+	}{
+		{name: "unknown id after restart", lastEventID: "42"},
+		{name: "evicted id", lastEventID: "1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange: events 1-3 for paris, with 1 and 2 evicted from a one-event buffer
+			hub := stream.NewHub(stream.Config{ReplayBuffer: 1})
+			holder, _, _ := hub.Subscribe("paris", 0)
+			for i := 1; i <= 3; i++ {
+				hub.Publish(context.Background(), "paris", models.WeatherData{Temperature: float64(i)})
+			}
+			holder.Close()
+			srv, _ := newStreamServer(t, hub, &mockWeatherClient{weather: models.WeatherData{Location: "paris", Temperature: 7}})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL+"/weather/paris/stream", nil)
+			req.Header.Set("Last-Event-ID", tc.lastEventID)
+
+			// Act
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("GET stream: %v", err)
+			}
+			defer resp.Body.Close()
+
+			// Assert
+			got := readUntil(t, bufio.NewReader(resp.Body), "event: snapshot")
+			if strings.Contains(got, "event: weather") {
+				t.Errorf("output before snapshot:\n%s\nwant no replayed events", got)
+			}
+		})
+	}
+}
+
+// TestHandler_StreamWeather_Limit verifies 503 STREAM_LIMIT with Retry-After at the cap.
+func TestHandler_StreamWeather_Limit(t *testing.T) {
+	hub := stream.NewHub(stream.Config{MaxStreams: 1})
+	holder, _, _ := hub.Subscribe("x", 0)
+	defer holder.Close()
+	handler := NewHandler(nil, &mockWeatherClient{}, nil, zap.NewNop(), nil, 100, 1)
+	handler.SetStreamHub(hub, StreamConfig{})
+
+	req := mux.SetURLVars(httptest.NewRequest("GET", "/weather/rome/stream", nil), map[string]string{"location": "rome"})
+	w := httptest.NewRecorder()
+	handler.StreamWeather(w, req)
+
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "STREAM_LIMIT") {
+		t.Errorf("status = %d body = %s, want 503 STREAM_LIMIT", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Retry-After header missing")
+	}
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/subscriptions"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// maxSubscriptionBodyBytes bounds POST /alerts/subscriptions request bodies.
+const maxSubscriptionBodyBytes = 64 << 10
+
+// SetSubscriptions attaches the subscription store and webhook dispatcher served by
+// /alerts/subscriptions. When unset, those endpoints return 503.
+func (h *Handler) SetSubscriptions(store *subscriptions.Store, dispatcher *subscriptions.Dispatcher) {
+	h.subscriptions = store
+	h.webhookDispatcher = dispatcher
+}
+
+// CreateSubscription handles POST /alerts/subscriptions. Body:
+// {"location": "...", "webhookUrl": "https://...", "thresholds": [{"name", "type", ...}]}.
+// Threshold fields match alert rules. Returns 201 with the created subscription, including the
+// management token that GetSubscription and DeleteSubscription require. The token is not
+// shown again.
+func (h *Handler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	if h.subscriptions == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "SUBSCRIPTIONS_DISABLED", "subscriptions are not enabled")
+		return
+	}
+	var body struct {
+		Location   string               `json:"location"`
+		WebhookURL string               `json:"webhookUrl"`
+		Thresholds []subscriptions.Rule `json:"thresholds"`
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSubscriptionBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&body); err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_BODY", "request body must be a JSON subscription")
+		return
+	}
+	location, err := validation.ValidateLocation(body.Location, h.locationMinLength, h.locationMaxLength)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", validationErrorMessage(err))
+		return
+	}
+
+	sub, err := h.subscriptions.Create(location, body.WebhookURL, body.Thresholds)
+	switch {
+	case err == nil:
+	case errors.Is(err, subscriptions.ErrInvalidWebhookURL):
+		writeError(w, r, http.StatusBadRequest, "INVALID_WEBHOOK_URL", err.Error())
+		return
+	case errors.Is(err, subscriptions.ErrLimitReached):
+		writeError(w, r, http.StatusConflict, "SUBSCRIPTION_LIMIT", err.Error())
+		return
+	default:
+		writeError(w, r, http.StatusBadRequest, "INVALID_THRESHOLD", err.Error())
+		return
+	}
+	if h.logger != nil {
+		h.logger.Info("subscription created", zap.String("subscription_id", sub.ID), zap.String("location", location))
+	}
+	w.Header().Set("Location", "/alerts/subscriptions/"+sub.ID)
+	writeJSON(w, http.StatusCreated, sub)
+}
+
+// ListSubscriptions handles GET /admin/subscriptions: every subscription, without tokens.
+func (h *Handler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	if h.subscriptions == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "SUBSCRIPTIONS_DISABLED", "subscriptions are not enabled")
+		return
+	}
+	subs := h.subscriptions.List()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"subscriptions": subs,
+		"count":         len(subs),
+	})
+}
+
+// GetSubscription handles GET /alerts/subscriptions/{id}. Requires the subscription's
+// management token as a bearer token; a wrong token returns 404 like an unknown ID.
+func (h *Handler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	if h.subscriptions == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "SUBSCRIPTIONS_DISABLED", "subscriptions are not enabled")
+		return
+	}
+	token, ok := subscriptionToken(w, r)
+	if !ok {
+		return
+	}
+	sub, err := h.subscriptions.Get(mux.Vars(r)["id"], token)
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, "SUBSCRIPTION_NOT_FOUND", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, sub)
+}
+
+// DeleteSubscription handles DELETE /alerts/subscriptions/{id}. Requires the management token
+// like GetSubscription. Returns 204 on success.
+func (h *Handler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	if h.subscriptions == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "SUBSCRIPTIONS_DISABLED", "subscriptions are not enabled")
+		return
+	}
+	token, ok := subscriptionToken(w, r)
+	if !ok {
+		return
+	}
+	if err := h.subscriptions.Delete(mux.Vars(r)["id"], token); err != nil {
+		writeError(w, r, http.StatusNotFound, "SUBSCRIPTION_NOT_FOUND", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeadLetters handles GET /admin/subscriptions/dead-letters. Lists recent webhook
+// deliveries that failed after all retries, oldest first.
+func (h *Handler) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if h.webhookDispatcher == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "SUBSCRIPTIONS_DISABLED", "subscriptions are not enabled")
+		return
+	}
+	letters := h.webhookDispatcher.DeadLetters()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deadLetters": letters,
+		"count":       len(letters),
+	})
+}
+
+// subscriptionToken returns the bearer token from the Authorization header. When there is none
+// it writes 401 and returns false.
+func subscriptionToken(w http.ResponseWriter, r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "subscription token required")
+		return "", false
+	}
+	return token, true
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/subscriptions"
+)
+
+func newSubscriptionRouter(t *testing.T) *mux.Router {
+	t.Helper()
+	dispatcher := subscriptions.NewDispatcher(subscriptions.DeliveryConfig{}, nil)
+	handler := newTestHandler(&mockWeatherClient{}, nil)
+	handler.SetSubscriptions(subscriptions.NewStore(0, nil), dispatcher)
+	return newTestRouter(handler,
+		testRoute{"POST", "/alerts/subscriptions", (*Handler).CreateSubscription},
+		testRoute{"GET", "/admin/subscriptions", (*Handler).ListSubscriptions},
+		testRoute{"GET", "/admin/subscriptions/dead-letters", (*Handler).GetDeadLetters},
+		testRoute{"GET", "/alerts/subscriptions/{id}", (*Handler).GetSubscription},
+		testRoute{"DELETE", "/alerts/subscriptions/{id}", (*Handler).DeleteSubscription},
+	)
+}
+
+// subscriptionRequest builds a request carrying token as the subscription bearer token.
+func subscriptionRequest(method, path, token string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+// TestHandler_Subscriptions_Lifecycle verifies create, get, list and delete round-trip with
+// the management token returned at creation.
+func TestHandler_Subscriptions_Lifecycle(t *testing.T) {
+	// Arrange
+	router := newSubscriptionRouter(t)
+	body := `{"location":"Chicago","webhookUrl":"https://example.com/hook","thresholds":[{"name":"cold","type":"temperature_below","threshold":0}]}`
+
+	// Act: create
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/alerts/subscriptions", strings.NewReader(body)))
+
+	// Assert
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST status = %d, want %d (body %s)", w.Code, http.StatusCreated, w.Body.String())
+	}
+	var created subscriptions.Subscription
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if created.ID == "" || created.Token == "" || created.Secret == "" || created.Location != "Chicago" || w.Header().Get("Location") != "/alerts/subscriptions/"+created.ID {
+		t.Fatalf("created = %+v, Location = %q", created, w.Header().Get("Location"))
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, subscriptionRequest("GET", "/alerts/subscriptions/"+created.ID, created.Token))
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), created.Token) || strings.Contains(w.Body.String(), created.Secret) {
+		t.Errorf("GET by id status = %d, want %d without token or secret (body %s)", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, subscriptionRequest("GET", "/admin/subscriptions", "secret-token"))
+	var list struct {
+		Count int `json:"count"`
+	}
+	_ = json.NewDecoder(w.Body).Decode(&list)
+	if list.Count != 1 {
+		t.Errorf("list count = %d, want 1", list.Count)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, subscriptionRequest("DELETE", "/alerts/subscriptions/"+created.ID, created.Token))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("DELETE status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, subscriptionRequest("DELETE", "/alerts/subscriptions/"+created.ID, created.Token))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("second DELETE status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+// TestHandler_Subscriptions_Ownership verifies get and delete require the subscription's own
+// token: none is 401, another subscription's token is 404 and leaves it in place.
+func TestHandler_Subscriptions_Ownership(t *testing.T) {
+	// Arrange: two subscriptions
+	router := newSubscriptionRouter(t)
+	body := `{"location":"Chicago","webhookUrl":"https://example.com/hook","thresholds":[{"name":"cold","type":"temperature_below","threshold":0}]}`
+	var subs [2]subscriptions.Subscription
+	for i := range subs {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/alerts/subscriptions", strings.NewReader(body)))
+		if err := json.NewDecoder(w.Body).Decode(&subs[i]); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	}
+	path := "/alerts/subscriptions/" + subs[0].ID
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{name: "get without token", req: httptest.NewRequest("GET", path, nil), want: http.StatusUnauthorized},
+		{name: "delete without token", req: httptest.NewRequest("DELETE", path, nil), want: http.StatusUnauthorized},
+		{name: "get with other token", req: subscriptionRequest("GET", path, subs[1].Token), want: http.StatusNotFound},
+		{name: "delete with other token", req: subscriptionRequest("DELETE", path, subs[1].Token), want: http.StatusNotFound},
+		{name: "get with own token", req: subscriptionRequest("GET", path, subs[0].Token), want: http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tc.req)
+
+			// Assert
+			if w.Code != tc.want {
+				t.Errorf("status = %d, want %d", w.Code, tc.want)
+			}
+		})
+	}
+}
+
+// TestHandler_CreateSubscription_Validation verifies 400 error codes for bad input.
+func TestHandler_CreateSubscription_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode string
+	}{
+		{name: "malformed json", body: `{`, wantCode: "INVALID_BODY"},
+		{name: "unknown field", body: `{"location":"x","extra":1}`, wantCode: "INVALID_BODY"},
+		{name: "invalid location", body: `{"location":"<script>","webhookUrl":"https://example.com","thresholds":[{"name":"a","type":"wind_above"}]}`, wantCode: "INVALID_LOCATION"},
+		{name: "private webhook", body: `{"location":"paris","webhookUrl":"http://10.0.0.5/hook","thresholds":[{"name":"a","type":"wind_above"}]}`, wantCode: "INVALID_WEBHOOK_URL"},
+		{name: "bad threshold", body: `{"location":"paris","webhookUrl":"https://example.com","thresholds":[{"name":"a","type":"nope"}]}`, wantCode: "INVALID_THRESHOLD"},
+		{name: "no thresholds", body: `{"location":"paris","webhookUrl":"https://example.com"}`, wantCode: "INVALID_THRESHOLD"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newSubscriptionRouter(t)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", "/alerts/subscriptions", strings.NewReader(tc.body)))
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+			var resp map[string]map[string]string
+			_ = json.NewDecoder(w.Body).Decode(&resp)
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("error code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
+
+// TestHandler_Subscriptions_Disabled verifies 503 when no store is attached.
+func TestHandler_Subscriptions_Disabled(t *testing.T) {
+	handler := NewHandler(nil, &mockWeatherClient{}, nil, zap.NewNop(), nil, 100, 1)
+
+	w := httptest.NewRecorder()
+	handler.ListSubscriptions(w, httptest.NewRequest("GET", "/admin/subscriptions", nil))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+	"github.com/kjstillabower/weather-alert-service/internal/watchlist"
+)
+
+// SetWatchlist attaches the runtime watchlist served by /admin/watchlist.
+// When unset, those endpoints return 503.
+func (h *Handler) SetWatchlist(store *watchlist.Store) {
+	h.watchlist = store
+}
+
+// ListWatchlist handles GET /admin/watchlist.
+func (h *Handler) ListWatchlist(w http.ResponseWriter, r *http.Request) {
+	if h.watchlist == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "WATCHLIST_DISABLED", "watchlist is not enabled")
+		return
+	}
+	locations := h.watchlist.List()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"locations": locations,
+		"count":     len(locations),
+	})
+}
+
+// GetWatchlistLocation handles GET /admin/watchlist/{location}. Returns 404 NOT_WATCHED when
+// the location is not on the list.
+func (h *Handler) GetWatchlistLocation(w http.ResponseWriter, r *http.Request) {
+	location, ok := h.watchlistLocation(w, r)
+	if !ok {
+		return
+	}
+	if !h.watchlist.Contains(location) {
+		writeError(w, r, http.StatusNotFound, "NOT_WATCHED", "location is not on the watchlist")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"location": location, "watched": true})
+}
+
+// PutWatchlistLocation handles PUT /admin/watchlist/{location}. Returns 201 when added and
+// 200 when already present; either way the location is watched afterwards.
+func (h *Handler) PutWatchlistLocation(w http.ResponseWriter, r *http.Request) {
+	location, ok := h.watchlistLocation(w, r)
+	if !ok {
+		return
+	}
+	added, err := h.watchlist.Add(location)
+	if err != nil {
+		if errors.Is(err, watchlist.ErrFull) {
+			writeError(w, r, http.StatusConflict, "WATCHLIST_FULL", err.Error())
+			return
+		}
+		h.logWatchlistError(err)
+		writeError(w, r, http.StatusInternalServerError, "PERSIST_FAILED", "watchlist could not be saved")
+		return
+	}
+	status := http.StatusOK
+	if added {
+		status = http.StatusCreated
+		if h.logger != nil {
+			h.logger.Info("watchlist location added", zap.String("location", location))
+		}
+	}
+	writeJSON(w, status, map[string]interface{}{"location": location, "watched": true})
+}
+
+// DeleteWatchlistLocation handles DELETE /admin/watchlist/{location}. Returns 204, or 404
+// NOT_WATCHED when the location is not on the list.
+func (h *Handler) DeleteWatchlistLocation(w http.ResponseWriter, r *http.Request) {
+	location, ok := h.watchlistLocation(w, r)
+	if !ok {
+		return
+	}
+	if err := h.watchlist.Remove(location); err != nil {
+		if errors.Is(err, watchlist.ErrNotFound) {
+			writeError(w, r, http.StatusNotFound, "NOT_WATCHED", "location is not on the watchlist")
+			return
+		}
+		h.logWatchlistError(err)
+		writeError(w, r, http.StatusInternalServerError, "PERSIST_FAILED", "watchlist could not be saved")
+		return
+	}
+	if h.logger != nil {
+		h.logger.Info("watchlist location removed", zap.String("location", location))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// watchlistLocation checks the watchlist is attached and validates the location path
+// parameter, writing the error response when either fails.
+func (h *Handler) watchlistLocation(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if h.watchlist == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "WATCHLIST_DISABLED", "watchlist is not enabled")
+		return "", false
+	}
+	location, err := validation.ValidateLocation(mux.Vars(r)["location"], h.locationMinLength, h.locationMaxLength)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_LOCATION", validationErrorMessage(err))
+		return "", false
+	}
+	return location, true
+}
+
+func (h *Handler) logWatchlistError(err error) {
+	if h.logger != nil {
+		h.logger.Error("watchlist update failed", zap.Error(err))
+	}
+}
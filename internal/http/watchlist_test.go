@@ -0,0 +1,118 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/watchlist"
+)
+
+func newWatchlistRouter(t *testing.T, store *watchlist.Store) *mux.Router {
+	t.Helper()
+	handler := newTestHandler(&mockWeatherClient{}, nil)
+	handler.SetWatchlist(store)
+	return newTestRouter(handler,
+		testRoute{"GET", "/admin/watchlist", (*Handler).ListWatchlist},
+		testRoute{"GET", "/admin/watchlist/{location}", (*Handler).GetWatchlistLocation},
+		testRoute{"PUT", "/admin/watchlist/{location}", (*Handler).PutWatchlistLocation},
+		testRoute{"DELETE", "/admin/watchlist/{location}", (*Handler).DeleteWatchlistLocation},
+	)
+}
+
+func adminRequest(method, path string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	return req
+}
+
+// TestHandler_Watchlist_Lifecycle verifies PUT/GET/DELETE and that changes reach the metrics
+// allow-list through the store listener.
+func TestHandler_Watchlist_Lifecycle(t *testing.T) {
+	// Arrange: store wired to the metrics allow-list as in main
+	store, err := watchlist.Open(filepath.Join(t.TempDir(), "watchlist.json"), []string{"seattle"}, 0)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	store.OnChange(observability.SetTrackedLocations)
+	defer observability.SetTrackedLocations(nil)
+	router := newWatchlistRouter(t, store)
+
+	// Act + Assert: add
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, adminRequest("PUT", "/admin/watchlist/Reykjavik"))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("PUT status = %d, want %d", w.Code, http.StatusCreated)
+	}
+	if got := observability.MetricLocationLabel("reykjavik"); got != "reykjavik" {
+		t.Errorf("MetricLocationLabel after PUT = %q, want reykjavik", got)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, adminRequest("PUT", "/admin/watchlist/reykjavik"))
+	if w.Code != http.StatusOK {
+		t.Errorf("repeat PUT status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, adminRequest("GET", "/admin/watchlist"))
+	var list struct {
+		Locations []string `json:"locations"`
+	}
+	_ = json.NewDecoder(w.Body).Decode(&list)
+	if len(list.Locations) != 2 {
+		t.Errorf("list = %v, want 2 locations", list.Locations)
+	}
+
+	// Remove
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, adminRequest("DELETE", "/admin/watchlist/reykjavik"))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("DELETE status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if got := observability.MetricLocationLabel("reykjavik"); got != "other" {
+		t.Errorf("MetricLocationLabel after DELETE = %q, want other", got)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, adminRequest("GET", "/admin/watchlist/reykjavik"))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GET removed status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+// TestHandler_Watchlist_Errors verifies auth, validation and capacity errors.
+func TestHandler_Watchlist_Errors(t *testing.T) {
+	store, _ := watchlist.Open("", []string{"seattle"}, 1)
+	router := newWatchlistRouter(t, store)
+
+	tests := []struct {
+		name       string
+		req        *http.Request
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "missing token", req: httptest.NewRequest("GET", "/admin/watchlist", nil), wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{name: "invalid location", req: adminRequest("PUT", "/admin/watchlist/%3Cscript%3E"), wantStatus: http.StatusBadRequest, wantCode: "INVALID_LOCATION"},
+		{name: "full", req: adminRequest("PUT", "/admin/watchlist/paris"), wantStatus: http.StatusConflict, wantCode: "WATCHLIST_FULL"},
+		{name: "delete unknown", req: adminRequest("DELETE", "/admin/watchlist/paris"), wantStatus: http.StatusNotFound, wantCode: "NOT_WATCHED"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tc.req)
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			var resp map[string]map[string]string
+			_ = json.NewDecoder(w.Body).Decode(&resp)
+			if resp["error"]["code"] != tc.wantCode {
+				t.Errorf("code = %q, want %q", resp["error"]["code"], tc.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+// Unit systems for weather responses, named as in the OpenWeatherMap API. Weather is fetched and
+// cached in metric and converted per request.
+const (
+	UnitsMetric   = "metric"   // temperature °C, wind speed m/s
+	UnitsImperial = "imperial" // temperature °F, wind speed mph
+	UnitsStandard = "standard" // temperature K, wind speed m/s
+)
+
+// metersPerSecondToMPH converts wind speed from m/s to miles per hour.
+const metersPerSecondToMPH = 2.2369362920544
+
+// InUnits returns w with temperatures (including the comfort indices) and wind speeds
+// converted from metric to units and Units set. Unknown unit systems are treated as metric.
+func (w WeatherData) InUnits(units string) WeatherData {
+	// The indices are copied before conversion: w shares them with the caller.
+	for _, p := range []**float64{&w.DewPoint, &w.HeatIndex, &w.WindChill, &w.ApparentTemperature} {
+		if *p != nil {
+			v := **p
+			*p = &v
+		}
+	}
+	temperatures := []*float64{&w.Temperature, &w.FeelsLike, &w.TemperatureMin, &w.TemperatureMax}
+	for _, p := range []*float64{w.DewPoint, w.HeatIndex, w.WindChill, w.ApparentTemperature} {
+		if p != nil {
+			temperatures = append(temperatures, p)
+		}
+	}
+	switch units {
+	case UnitsImperial:
+		for _, t := range temperatures {
+			*t = *t*9/5 + 32
+		}
+		w.WindSpeed *= metersPerSecondToMPH
+		w.WindGust *= metersPerSecondToMPH
+	case UnitsStandard:
+		for _, t := range temperatures {
+			*t += 273.15
+		}
+	default:
+		units = UnitsMetric
+	}
+	w.Units = units
+	return w
+}
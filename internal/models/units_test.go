@@ -0,0 +1,58 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+// TestWeatherData_InUnits verifies metric readings are converted per unit system and the units
+// are recorded.
+func TestWeatherData_InUnits(t *testing.T) {
+	metric := WeatherData{Temperature: 20, WindSpeed: 10, Humidity: 50}
+	tests := []struct {
+		units     string
+		wantTemp  float64
+		wantWind  float64
+		wantUnits string
+	}{
+		{units: UnitsMetric, wantTemp: 20, wantWind: 10, wantUnits: "metric"},
+		{units: UnitsImperial, wantTemp: 68, wantWind: 22.369, wantUnits: "imperial"},
+		{units: UnitsStandard, wantTemp: 293.15, wantWind: 10, wantUnits: "standard"},
+		{units: "", wantTemp: 20, wantWind: 10, wantUnits: "metric"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.wantUnits+"/"+tc.units, func(t *testing.T) {
+			got := metric.InUnits(tc.units)
+
+			if math.Abs(got.Temperature-tc.wantTemp) > 0.001 || math.Abs(got.WindSpeed-tc.wantWind) > 0.001 {
+				t.Errorf("InUnits(%q) temperature = %v, wind = %v; want %v, %v", tc.units, got.Temperature, got.WindSpeed, tc.wantTemp, tc.wantWind)
+			}
+			if got.Units != tc.wantUnits || got.Humidity != 50 {
+				t.Errorf("InUnits(%q) = %+v", tc.units, got)
+			}
+		})
+	}
+	if metric.Units != "" || metric.Temperature != 20 {
+		t.Errorf("InUnits modified the receiver: %+v", metric)
+	}
+}
+
+// TestWeatherData_InUnits_ComfortIndices verifies the comfort indices are converted as
+// temperatures, missing ones stay nil, and the receiver's indices are not modified.
+func TestWeatherData_InUnits_ComfortIndices(t *testing.T) {
+	dewPoint, windChill := 10.0, -5.0
+	metric := WeatherData{DewPoint: &dewPoint, WindChill: &windChill}
+
+	got := metric.InUnits(UnitsImperial)
+
+	if got.DewPoint == nil || *got.DewPoint != 50 || got.WindChill == nil || *got.WindChill != 23 {
+		t.Errorf("InUnits(imperial) dewPoint = %v, windChill = %v; want 50, 23", got.DewPoint, got.WindChill)
+	}
+	if got.HeatIndex != nil || got.ApparentTemperature != nil {
+		t.Errorf("InUnits(imperial) set missing indices: %+v", got)
+	}
+	if dewPoint != 10 || windChill != -5 {
+		t.Errorf("InUnits modified the receiver's indices: dewPoint = %v, windChill = %v", dewPoint, windChill)
+	}
+}
@@ -1,13 +1,120 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
+// WeatherData is the current weather for a location. Temperatures are °C and wind speeds m/s
+// unless Units says otherwise; optional upstream fields are omitted when not reported.
 type WeatherData struct {
-	Location    string    `json:"location"`
-	Temperature float64   `json:"temperature"`
-	Conditions  string    `json:"conditions"`
-	Humidity    int       `json:"humidity"`
-	WindSpeed   float64   `json:"windSpeed"`
-	Timestamp   time.Time `json:"timestamp"`
-	Stale       bool      `json:"stale,omitempty"` // Indicates data served from stale cache
+	Location       string       `json:"location"`
+	Country        string       `json:"country,omitempty"`     // ISO 3166 country code
+	Coordinates    *Coordinates `json:"coordinates,omitempty"` // of the upstream station or city
+	Temperature    float64      `json:"temperature"`
+	FeelsLike      float64      `json:"feelsLike"`
+	TemperatureMin float64      `json:"temperatureMin"` // minimum currently observed across the area
+	TemperatureMax float64      `json:"temperatureMax"` // maximum currently observed across the area
+	Conditions     string       `json:"conditions"`
+	Humidity       int          `json:"humidity"`
+	Pressure       int          `json:"pressure"`             // sea-level pressure, hPa
+	Visibility     int          `json:"visibility,omitempty"` // meters, up to 10000
+	Cloudiness     int          `json:"cloudiness"`           // percent
+	WindSpeed      float64      `json:"windSpeed"`
+	WindDirection  int          `json:"windDirection"`      // degrees, meteorological
+	WindGust       float64      `json:"windGust,omitempty"` // same unit as WindSpeed
+	Rain1h         float64      `json:"rain1h,omitempty"`   // mm in the last hour
+	Snow1h         float64      `json:"snow1h,omitempty"`   // mm in the last hour
+	Sunrise        time.Time    `json:"sunrise,omitzero"`
+	Sunset         time.Time    `json:"sunset,omitzero"`
+	Timestamp      time.Time    `json:"timestamp"`          // upstream observation time
+	FetchedAt      time.Time    `json:"fetchedAt,omitzero"` // when the observation was fetched upstream; drives stale cache age
+	Stale          bool         `json:"stale,omitempty"`    // Indicates data served from stale cache
+	Units          string       `json:"units,omitempty"`    // Unit system of temperatures and wind speeds; set by InUnits (stored data is metric)
+
+	// Comfort indices derived by the service from Temperature, Humidity and WindSpeed, in the
+	// same unit as Temperature. HeatIndex and WindChill are omitted outside the conditions
+	// they are defined for.
+	DewPoint            *float64 `json:"dewPoint,omitempty"`
+	HeatIndex           *float64 `json:"heatIndex,omitempty"`           // from 26.7 °C (80 °F)
+	WindChill           *float64 `json:"windChill,omitempty"`           // up to 10 °C (50 °F) with wind above 4.8 km/h (3 mph)
+	ApparentTemperature *float64 `json:"apparentTemperature,omitempty"` // heat index, wind chill, or Temperature
+}
+
+// Coordinates is a position in decimal degrees.
+type Coordinates struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// UnmarshalJSON decodes WeatherData, upgrading entries written before FetchedAt existed (cache
+// and history entries), whose Timestamp was the fetch time.
+func (w *WeatherData) UnmarshalJSON(b []byte) error {
+	type plain WeatherData
+	if err := json.Unmarshal(b, (*plain)(w)); err != nil {
+		return err
+	}
+	if w.FetchedAt.IsZero() {
+		w.FetchedAt = w.Timestamp
+	}
+	return nil
+}
+
+// OfficialAlert is a government-issued severe-weather alert relayed by the upstream provider.
+// ID is derived by the API from sender, event and start; providers leave it empty.
+type OfficialAlert struct {
+	ID          string    `json:"id,omitempty"`
+	Sender      string    `json:"sender"`
+	Event       string    `json:"event"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Description string    `json:"description"`
+	Tags        []string  `json:"tags,omitempty"`
+}
+
+// Forecast is a multi-day forecast in fixed steps (3 hours for OpenWeatherMap), soonest first.
+type Forecast struct {
+	Location  string           `json:"location"`
+	Periods   []ForecastPeriod `json:"periods"`
+	Timestamp time.Time        `json:"timestamp"`       // when the forecast was fetched upstream
+	Stale     bool             `json:"stale,omitempty"` // Indicates data served from stale cache
+}
+
+// ForecastPeriod is the forecast for one step starting at Time.
+type ForecastPeriod struct {
+	Time                     time.Time `json:"time"`
+	Temperature              float64   `json:"temperature"`
+	Conditions               string    `json:"conditions"`
+	Humidity                 int       `json:"humidity"`
+	WindSpeed                float64   `json:"windSpeed"`
+	PrecipitationProbability float64   `json:"precipitationProbability"` // 0 to 1
+}
+
+// Place is a location search candidate. ID is unambiguous and accepted wherever a location
+// name is.
+type Place struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	State       string      `json:"state,omitempty"`
+	Country     string      `json:"country"`
+	Coordinates Coordinates `json:"coordinates"`
+}
+
+// AirQuality is the current air quality for a location. Pollutant concentrations are μg/m³.
+type AirQuality struct {
+	Location    string      `json:"location"`
+	Coordinates Coordinates `json:"coordinates"`
+	AQI         int         `json:"aqi"`      // OpenWeatherMap air quality index, 1 (good) to 5 (very poor)
+	Category    string      `json:"category"` // AQI name: good, fair, moderate, poor or very poor
+	Pollutants  Pollutants  `json:"pollutants"`
+	Timestamp   time.Time   `json:"timestamp"`       // upstream observation time
+	Stale       bool        `json:"stale,omitempty"` // Indicates data served from stale cache
+}
+
+// Pollutants are air pollutant concentrations in μg/m³.
+type Pollutants struct {
+	PM25 float64 `json:"pm25"` // fine particulate matter, 2.5 μm or smaller
+	PM10 float64 `json:"pm10"` // coarse particulate matter, 10 μm or smaller
+	O3   float64 `json:"o3"`   // ozone
+	NO2  float64 `json:"no2"`  // nitrogen dioxide
 }
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestWeatherData_UnmarshalJSON verifies entries written before FetchedAt existed take their
+// fetch time from Timestamp, and current entries keep both times.
+func TestWeatherData_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		wantTimestamp time.Time
+		wantFetchedAt time.Time
+	}{
+		{
+			name:          "legacy entry",
+			input:         `{"location":"chicago","temperature":3,"timestamp":"2024-01-10T12:00:00Z"}`,
+			wantTimestamp: time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+			wantFetchedAt: time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "current entry",
+			input:         `{"location":"chicago","temperature":3,"timestamp":"2024-01-10T11:50:00Z","fetchedAt":"2024-01-10T12:00:00Z"}`,
+			wantTimestamp: time.Date(2024, 1, 10, 11, 50, 0, 0, time.UTC),
+			wantFetchedAt: time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got WeatherData
+			if err := json.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !got.Timestamp.Equal(tc.wantTimestamp) || !got.FetchedAt.Equal(tc.wantFetchedAt) {
+				t.Errorf("timestamp = %v, fetchedAt = %v; want %v, %v", got.Timestamp, got.FetchedAt, tc.wantTimestamp, tc.wantFetchedAt)
+			}
+			if got.Location != "chicago" || got.Temperature != 3 {
+				t.Errorf("decoded = %+v", got)
+			}
+		})
+	}
+}
+
+// TestWeatherData_MarshalOmitsAbsentFields verifies optional fields the upstream did not report
+// are left out of the JSON.
+func TestWeatherData_MarshalOmitsAbsentFields(t *testing.T) {
+	raw, err := json.Marshal(WeatherData{Location: "chicago"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	for _, name := range []string{"country", "coordinates", "visibility", "windGust", "rain1h", "snow1h", "sunrise", "sunset", "fetchedAt"} {
+		if _, ok := fields[name]; ok {
+			t.Errorf("field %q present in %s", name, raw)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+)
+
+// StatusShuttingDown is terminal, so it is notified without waiting out the debounce.
+const StatusShuttingDown = "shutting-down"
+
+// WindowStats are the lifecycle window counters behind a health status.
+type WindowStats struct {
+	OverloadWindow   string  `json:"overloadWindow,omitempty"`
+	Requests         int     `json:"requests"` // rate-limited path requests in the overload window
+	Denials          int     `json:"denials"`  // rate limit rejections in the overload window
+	DegradedWindow   string  `json:"degradedWindow,omitempty"`
+	Errors           int     `json:"errors"`           // failed requests in the degraded window
+	Total            int     `json:"total"`            // requests in the degraded window
+	ErrorRatePercent float64 `json:"errorRatePercent"` // Errors / Total
+	IdleWindow       string  `json:"idleWindow,omitempty"`
+	IdleRequests     int     `json:"idleRequests"` // requests in the idle window
+}
+
+// Snapshot is one health evaluation: status and reason as served by GET /health, plus stats.
+type Snapshot struct {
+	Status string
+	Reason string
+	Stats  WindowStats
+}
+
+// Notification describes a health status transition that held for the debounce period.
+type Notification struct {
+	Service        string      `json:"service"`
+	PreviousStatus string      `json:"previousStatus"`
+	Status         string      `json:"status"`
+	Reason         string      `json:"reason,omitempty"`
+	Since          time.Time   `json:"since"`     // first evaluation that saw Status
+	Timestamp      time.Time   `json:"timestamp"` // when the notification was sent
+	Stats          WindowStats `json:"stats"`
+}
+
+// Notifier delivers a Notification to one target. Name labels metrics and logs.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, n Notification) error
+}
+
+// Config configures a Monitor.
+type Config struct {
+	Service  string        // service name in notifications; default "weather-alert-service"
+	Debounce time.Duration // how long a new status must hold before it is notified (0 = immediately)
+	Timeout  time.Duration // per-notifier send timeout; default 10s
+}
+
+// Monitor evaluates health in the background and notifies on status transitions. A new status
+// is notified only after it has held for Debounce; a status that reverts before then is a flap
+// and is dropped, so flapping between two states sends nothing.
+type Monitor struct {
+	cfg       Config
+	check     func(ctx context.Context) Snapshot
+	notifiers []Notifier
+	logger    *zap.Logger
+	now       func() time.Time
+
+	mu           sync.Mutex
+	notified     string // last notified status; the first evaluation sets the baseline
+	pending      string // status waiting out the debounce
+	pendingSince time.Time
+}
+
+// NewMonitor creates a Monitor that calls check on each evaluation.
+func NewMonitor(cfg Config, check func(ctx context.Context) Snapshot, notifiers []Notifier, logger *zap.Logger) *Monitor {
+	if cfg.Service == "" {
+		cfg.Service = "weather-alert-service"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Debounce < 0 {
+		cfg.Debounce = 0
+	}
+	return &Monitor{cfg: cfg, check: check, notifiers: notifiers, logger: logger, now: time.Now}
+}
+
+// Run evaluates health at the given interval until ctx is done. The first evaluation runs
+// immediately and only records the baseline status.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) error {
+	m.Check(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			m.Check(ctx)
+		}
+	}
+}
+
+// Check runs one evaluation and sends notifications for a transition that is due. Safe to call
+// concurrently with Run; main calls it once at shutdown so shutting-down is notified.
+func (m *Monitor) Check(ctx context.Context) {
+	snap := m.check(ctx)
+	now := m.now()
+
+	m.mu.Lock()
+	if m.notified == "" {
+		m.notified = snap.Status
+		m.mu.Unlock()
+		return
+	}
+	if snap.Status == m.notified {
+		if m.pending != "" {
+			observability.HealthFlapsSuppressedTotal.Inc()
+			if m.logger != nil {
+				m.logger.Info("health status flap suppressed", zap.String("status", m.notified), zap.String("flapped_to", m.pending))
+			}
+		}
+		m.pending = ""
+		m.mu.Unlock()
+		return
+	}
+	if snap.Status != m.pending {
+		m.pending = snap.Status
+		m.pendingSince = now
+	}
+	if snap.Status != StatusShuttingDown && now.Sub(m.pendingSince) < m.cfg.Debounce {
+		m.mu.Unlock()
+		return
+	}
+	n := Notification{
+		Service:        m.cfg.Service,
+		PreviousStatus: m.notified,
+		Status:         snap.Status,
+		Reason:         snap.Reason,
+		Since:          m.pendingSince,
+		Timestamp:      now,
+		Stats:          snap.Stats,
+	}
+	m.notified = snap.Status
+	m.pending = ""
+	m.mu.Unlock()
+
+	m.send(ctx, n)
+}
+
+// send delivers n to every notifier, each bounded by the configured timeout. Failures are
+// logged and counted; there is no retry, since the next transition supersedes this one.
+func (m *Monitor) send(ctx context.Context, n Notification) {
+	if m.logger != nil {
+		m.logger.Info("health status transition notified",
+			zap.String("previous_status", n.PreviousStatus),
+			zap.String("current_status", n.Status),
+			zap.String("reason", n.Reason))
+	}
+	for _, notifier := range m.notifiers {
+		sendCtx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+		err := notifier.Notify(sendCtx, n)
+		cancel()
+		if err != nil {
+			observability.HealthNotificationsTotal.WithLabelValues(notifier.Name(), "error").Inc()
+			if m.logger != nil {
+				m.logger.Warn("health notification failed", zap.String("notifier", notifier.Name()), zap.Error(err))
+			}
+			continue
+		}
+		observability.HealthNotificationsTotal.WithLabelValues(notifier.Name(), "success").Inc()
+	}
+}
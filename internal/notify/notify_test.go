@@ -0,0 +1,134 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// recordingNotifier records notifications and optionally fails.
+type recordingNotifier struct {
+	sent []Notification
+	err  error
+}
+
+func (r *recordingNotifier) Name() string { return "recording" }
+
+func (r *recordingNotifier) Notify(ctx context.Context, n Notification) error {
+	r.sent = append(r.sent, n)
+	return r.err
+}
+
+// newTestMonitor returns a Monitor whose health check reports *status and whose clock is *now.
+func newTestMonitor(debounce time.Duration, status *string, now *time.Time, notifiers ...Notifier) *Monitor {
+	m := NewMonitor(Config{Debounce: debounce}, func(ctx context.Context) Snapshot {
+		return Snapshot{Status: *status, Reason: "reason-" + *status}
+	}, notifiers, nil)
+	m.now = func() time.Time { return *now }
+	return m
+}
+
+// TestMonitor_NotifiesAfterDebounce verifies the first check only sets the baseline and a new
+// status is notified once it has held for the debounce period.
+func TestMonitor_NotifiesAfterDebounce(t *testing.T) {
+	// Arrange
+	rec := &recordingNotifier{}
+	status, now := "healthy", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := newTestMonitor(time.Minute, &status, &now, rec)
+	m.Check(context.Background())
+
+	// Act: degraded seen at 12:00:30, still degraded at 12:01:00 and 12:01:30
+	status = "degraded"
+	for _, offset := range []time.Duration{30 * time.Second, time.Minute, 90 * time.Second} {
+		now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).Add(offset)
+		m.Check(context.Background())
+	}
+
+	// Assert
+	if len(rec.sent) != 1 {
+		t.Fatalf("sent = %d notifications, want 1", len(rec.sent))
+	}
+	n := rec.sent[0]
+	if n.PreviousStatus != "healthy" || n.Status != "degraded" || n.Reason != "reason-degraded" || n.Service != "weather-alert-service" {
+		t.Errorf("notification = %+v", n)
+	}
+	if want := time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC); !n.Since.Equal(want) {
+		t.Errorf("Since = %v, want %v", n.Since, want)
+	}
+}
+
+// TestMonitor_SuppressesFlaps verifies statuses that revert within the debounce are not sent,
+// and that shutting-down bypasses the debounce.
+func TestMonitor_SuppressesFlaps(t *testing.T) {
+	rec := &recordingNotifier{}
+	status, now := "healthy", time.Now()
+	m := newTestMonitor(time.Minute, &status, &now, rec)
+	m.Check(context.Background())
+
+	for i := 0; i < 5; i++ {
+		now = now.Add(20 * time.Second)
+		if i%2 == 0 {
+			status = "overloaded"
+		} else {
+			status = "healthy"
+		}
+		m.Check(context.Background())
+	}
+	if len(rec.sent) != 0 {
+		t.Fatalf("sent = %+v, want none while flapping", rec.sent)
+	}
+
+	status = "shutting-down"
+	m.Check(context.Background())
+	if len(rec.sent) != 1 || rec.sent[0].Status != "shutting-down" || rec.sent[0].PreviousStatus != "healthy" {
+		t.Errorf("sent = %+v, want one healthy -> shutting-down", rec.sent)
+	}
+}
+
+// TestMonitor_NotifierFailureDoesNotBlockOthers verifies every notifier is tried.
+func TestMonitor_NotifierFailureDoesNotBlockOthers(t *testing.T) {
+	failing := &recordingNotifier{err: errors.New("down")}
+	ok := &recordingNotifier{}
+	status, now := "healthy", time.Now()
+	m := newTestMonitor(0, &status, &now, failing, ok)
+	m.Check(context.Background())
+
+	status = "idle"
+	m.Check(context.Background())
+
+	if len(failing.sent) != 1 || len(ok.sent) != 1 {
+		t.Errorf("sent = %d and %d, want 1 each", len(failing.sent), len(ok.sent))
+	}
+}
+
+// TestWebhookNotifier verifies the JSON payload and that non-2xx responses are errors.
+func TestWebhookNotifier(t *testing.T) {
+	var got Notification
+	statusCode := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request = %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(statusCode)
+	}))
+	defer srv.Close()
+	notifier := NewWebhookNotifier(srv.URL)
+	n := Notification{Service: "svc", PreviousStatus: "healthy", Status: "degraded", Reason: "error_rate_breach", Stats: WindowStats{Errors: 6, Total: 10, ErrorRatePercent: 60}}
+
+	if err := notifier.Notify(context.Background(), n); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if got.Status != "degraded" || got.Reason != "error_rate_breach" || got.Stats.Errors != 6 {
+		t.Errorf("payload = %+v", got)
+	}
+
+	statusCode = http.StatusBadGateway
+	if err := notifier.Notify(context.Background(), n); err == nil {
+		t.Error("Notify() on 502: error = nil, want error")
+	}
+}
@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPConfig configures an SMTPNotifier.
+type SMTPConfig struct {
+	Addr     string   // host:port of the SMTP server
+	From     string   // envelope and header sender
+	To       []string // recipients
+	Username string   // PLAIN auth user; empty disables auth
+	Password string
+}
+
+// SMTPNotifier emails each Notification as plain text. STARTTLS is used when the server offers
+// it; PLAIN auth is only sent over TLS or to localhost (net/smtp refuses otherwise).
+type SMTPNotifier struct {
+	cfg  SMTPConfig
+	host string
+}
+
+// NewSMTPNotifier validates cfg and creates an SMTPNotifier.
+func NewSMTPNotifier(cfg SMTPConfig) (*SMTPNotifier, error) {
+	host, _, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("smtp addr %q: %w", cfg.Addr, err)
+	}
+	if cfg.From == "" || len(cfg.To) == 0 {
+		return nil, errors.New("smtp from and to are required")
+	}
+	return &SMTPNotifier{cfg: cfg, host: host}, nil
+}
+
+// Name implements Notifier.
+func (s *SMTPNotifier) Name() string { return "smtp" }
+
+// Notify implements Notifier. The whole SMTP exchange is bounded by the ctx deadline.
+func (s *SMTPNotifier) Notify(ctx context.Context, n Notification) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("dial smtp: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp greeting: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+	if err := c.Mail(s.cfg.From); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	for _, to := range s.cfg.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("smtp rcpt %s: %w", to, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := w.Write(s.message(n)); err != nil {
+		return fmt.Errorf("smtp write: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	return c.Quit()
+}
+
+// message renders n as an RFC 5322 plain-text message with CRLF line endings.
+func (s *SMTPNotifier) message(n Notification) []byte {
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		fmt.Fprintf(&b, format, args...)
+		b.WriteString("\r\n")
+	}
+	line("From: %s", s.cfg.From)
+	line("To: %s", strings.Join(s.cfg.To, ", "))
+	line("Subject: [%s] health %s -> %s", n.Service, n.PreviousStatus, n.Status)
+	line("Date: %s", n.Timestamp.Format(time.RFC1123Z))
+	line("MIME-Version: 1.0")
+	line("Content-Type: text/plain; charset=UTF-8")
+	line("")
+	line("%s health changed from %s to %s.", n.Service, n.PreviousStatus, n.Status)
+	line("")
+	reason := n.Reason
+	if reason == "" {
+		reason = "none"
+	}
+	line("Reason: %s", reason)
+	line("Since:  %s", n.Since.UTC().Format(time.RFC3339))
+	line("")
+	st := n.Stats
+	if st.OverloadWindow != "" {
+		line("Overload window (%s): %d requests, %d rate limit denials", st.OverloadWindow, st.Requests, st.Denials)
+	}
+	if st.DegradedWindow != "" {
+		line("Degraded window (%s): %d errors of %d requests (%.1f%%)", st.DegradedWindow, st.Errors, st.Total, st.ErrorRatePercent)
+	}
+	if st.IdleWindow != "" {
+		line("Idle window (%s): %d requests", st.IdleWindow, st.IdleRequests)
+	}
+	return []byte(b.String())
+}
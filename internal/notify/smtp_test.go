@@ -0,0 +1,162 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSMTPServer is an in-process SMTP stand-in that accepts every message and records the
+// envelope and data. It offers no extensions, so clients skip STARTTLS and AUTH.
+type fakeSMTPServer struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	from     string
+	rcpts    []string
+	messages []string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeSMTPServer{ln: ln}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+	reply("220 fake.smtp ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimSpace(line)
+		upper := strings.ToUpper(cmd)
+		switch {
+		case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+			reply("250 fake.smtp")
+		case strings.HasPrefix(upper, "MAIL FROM:"):
+			s.mu.Lock()
+			s.from = strings.Trim(cmd[len("MAIL FROM:"):], "<> ")
+			s.mu.Unlock()
+			reply("250 OK")
+		case strings.HasPrefix(upper, "RCPT TO:"):
+			s.mu.Lock()
+			s.rcpts = append(s.rcpts, strings.Trim(cmd[len("RCPT TO:"):], "<> "))
+			s.mu.Unlock()
+			reply("250 OK")
+		case upper == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, data.String())
+			s.mu.Unlock()
+			reply("250 OK queued")
+		case upper == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+// TestSMTPNotifier_SendsMessage verifies the envelope, headers and body against the fake server.
+func TestSMTPNotifier_SendsMessage(t *testing.T) {
+	// Arrange
+	srv := newFakeSMTPServer(t)
+	notifier, err := NewSMTPNotifier(SMTPConfig{
+		Addr: srv.ln.Addr().String(),
+		From: "service@example.com",
+		To:   []string{"oncall@example.com", "sre@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("NewSMTPNotifier() error = %v", err)
+	}
+	n := Notification{
+		Service:        "weather-alert-service",
+		PreviousStatus: "healthy",
+		Status:         "degraded",
+		Reason:         "error_rate_breach",
+		Since:          time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		Timestamp:      time.Date(2024, 1, 1, 12, 1, 0, 0, time.UTC),
+		Stats:          WindowStats{DegradedWindow: "1m0s", Errors: 6, Total: 10, ErrorRatePercent: 60},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Act
+	err = notifier.Notify(ctx, n)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.from != "service@example.com" || len(srv.rcpts) != 2 || len(srv.messages) != 1 {
+		t.Fatalf("envelope = %q %v, messages = %d", srv.from, srv.rcpts, len(srv.messages))
+	}
+	msg := srv.messages[0]
+	for _, want := range []string{
+		"Subject: [weather-alert-service] health healthy -> degraded\r\n",
+		"To: oncall@example.com, sre@example.com\r\n",
+		"Reason: error_rate_breach\r\n",
+		"Degraded window (1m0s): 6 errors of 10 requests (60.0%)\r\n",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+// TestSMTPNotifier_Errors verifies config validation and an unreachable server.
+func TestSMTPNotifier_Errors(t *testing.T) {
+	if _, err := NewSMTPNotifier(SMTPConfig{Addr: "no-port", From: "a@example.com", To: []string{"b@example.com"}}); err == nil {
+		t.Error("NewSMTPNotifier(no port) error = nil, want error")
+	}
+	if _, err := NewSMTPNotifier(SMTPConfig{Addr: "localhost:25"}); err == nil {
+		t.Error("NewSMTPNotifier(no from/to) error = nil, want error")
+	}
+
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	ln.Close()
+	notifier, _ := NewSMTPNotifier(SMTPConfig{Addr: addr, From: "a@example.com", To: []string{"b@example.com"}})
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := notifier.Notify(ctx, Notification{}); err == nil {
+		t.Error("Notify() to closed port: error = nil, want error")
+	}
+}
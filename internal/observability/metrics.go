@@ -100,6 +100,43 @@ var (
 	// RequestCoalescingWaitSeconds tracks time spent waiting for coalesced requests.
 	RequestCoalescingWaitSeconds prometheus.Histogram
 
+	// AlertTransitionsTotal counts alert rule status changes by rule and new status (firing/resolved).
+	AlertTransitionsTotal *prometheus.CounterVec
+	// AlertsFiring is the number of currently firing alert rules per severity.
+	AlertsFiring *prometheus.GaugeVec
+	// WebhookDeliveriesTotal counts subscription webhook delivery outcomes (success, retry, dead_letter).
+	WebhookDeliveriesTotal *prometheus.CounterVec
+	// StreamsActive is the number of open SSE weather streams.
+	StreamsActive prometheus.Gauge
+	// StreamsRejectedTotal counts stream connections refused by the concurrent stream cap.
+	StreamsRejectedTotal prometheus.Counter
+	// WeatherChangeEventsTotal counts material weather changes by reason (conditions, temperature, wind).
+	WeatherChangeEventsTotal *prometheus.CounterVec
+	// HistoryWritesTotal counts observation history writes by outcome (written, skipped, dropped, error).
+	HistoryWritesTotal *prometheus.CounterVec
+
+	// HealthNotificationsTotal counts health transition notifications by notifier (webhook, smtp) and outcome (success, error).
+	HealthNotificationsTotal *prometheus.CounterVec
+	// HealthFlapsSuppressedTotal counts health transitions that reverted within the debounce and were not notified.
+	HealthFlapsSuppressedTotal prometheus.Counter
+	// ReportRunsTotal counts scheduled digest report runs by job and outcome (success, retry, failure).
+	ReportRunsTotal *prometheus.CounterVec
+	// ReportLastSuccessTimestamp is the Unix time of each report job's last delivered digest.
+	ReportLastSuccessTimestamp *prometheus.GaugeVec
+
+	// Latest fresh readings per tracked location, set by RecordWeatherReading. Untracked locations
+	// are not exported; use these to alert on the weather itself.
+	WeatherTemperatureCelsius *prometheus.GaugeVec
+	WeatherHumidityPercent    *prometheus.GaugeVec
+	WeatherWindSpeed          *prometheus.GaugeVec
+
+	// readingsMu guards observedAt and the reading gauges against SetTrackedLocations removing a
+	// location mid-update. Lock order: readingsMu, then trackedLocationsMu.
+	readingsMu sync.Mutex
+	// observedAt is the timestamp of the latest reading per tracked location, exported as
+	// weatherObservationAgeSeconds at scrape time.
+	observedAt = make(map[string]time.Time)
+
 	// trackedLocations is built from config; used to resolve location for metrics.
 	trackedLocationsMu sync.RWMutex
 	trackedLocations   map[string]struct{}
@@ -330,6 +367,102 @@ func init() {
 			Buckets: []float64{.001, .005, .01, .05, .1, .5, 1, 5},
 		},
 	)
+	AlertTransitionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "alertTransitionsTotal",
+			Help: "Total alert rule status transitions by rule and new status",
+		},
+		[]string{"rule", "status"},
+	)
+	AlertsFiring = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "alertsFiring",
+			Help: "Number of alert rules currently firing per severity",
+		},
+		[]string{"severity"},
+	)
+	WebhookDeliveriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhookDeliveriesTotal",
+			Help: "Subscription webhook delivery attempts by outcome",
+		},
+		[]string{"outcome"},
+	)
+	StreamsActive = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "weatherStreamsActive",
+			Help: "Open SSE weather streams",
+		},
+	)
+	StreamsRejectedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "weatherStreamsRejectedTotal",
+			Help: "SSE stream connections refused by the concurrent stream cap",
+		},
+	)
+	WeatherChangeEventsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "weatherChangeEventsTotal",
+			Help: "Material weather changes recorded in the change-event log by reason",
+		},
+		[]string{"reason"},
+	)
+	HistoryWritesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "historyWritesTotal",
+			Help: "Observation history writes by outcome",
+		},
+		[]string{"outcome"},
+	)
+
+	HealthNotificationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "healthNotificationsTotal",
+			Help: "Health status transition notifications by notifier and outcome",
+		},
+		[]string{"notifier", "outcome"},
+	)
+	HealthFlapsSuppressedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "healthFlapsSuppressedTotal",
+			Help: "Health status transitions that reverted within the debounce period and were not notified",
+		},
+	)
+	ReportRunsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "reportRunsTotal",
+			Help: "Scheduled digest report runs by job and outcome",
+		},
+		[]string{"job", "outcome"},
+	)
+	ReportLastSuccessTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "reportLastSuccessTimestampSeconds",
+			Help: "Unix time of the last successfully delivered digest per report job",
+		},
+		[]string{"job"},
+	)
+	WeatherTemperatureCelsius = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "weatherTemperatureCelsius",
+			Help: "Temperature from the latest fresh reading per tracked location",
+		},
+		[]string{"location"},
+	)
+	WeatherHumidityPercent = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "weatherHumidityPercent",
+			Help: "Relative humidity from the latest fresh reading per tracked location",
+		},
+		[]string{"location"},
+	)
+	WeatherWindSpeed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "weatherWindSpeed",
+			Help: "Wind speed in m/s from the latest fresh reading per tracked location",
+		},
+		[]string{"location"},
+	)
 
 	registry.MustRegister(
 		HTTPRequestsTotal, HTTPRequestDuration, HTTPRequestsInFlight,
@@ -348,9 +481,79 @@ func init() {
 		UpstreamRateLimitHeadersParsedTotal, UpstreamRateLimitRetryAfterSeconds,
 		StaleCacheServesTotal, StaleCacheAgeSeconds,
 		RequestCoalescingHitsTotal, RequestCoalescingWaitSeconds,
+		AlertTransitionsTotal, AlertsFiring,
+		WebhookDeliveriesTotal,
+		StreamsActive, StreamsRejectedTotal,
+		WeatherChangeEventsTotal, HistoryWritesTotal,
+		HealthNotificationsTotal, HealthFlapsSuppressedTotal,
+		ReportRunsTotal, ReportLastSuccessTimestamp,
+		WeatherTemperatureCelsius, WeatherHumidityPercent, WeatherWindSpeed,
+		observationAgeCollector{desc: prometheus.NewDesc(
+			"weatherObservationAgeSeconds",
+			"Seconds since the latest fresh reading per tracked location",
+			[]string{"location"}, nil,
+		)},
 	)
 }
 
+// observationAgeCollector exports weatherObservationAgeSeconds. Age is computed at scrape time,
+// so it keeps growing when fresh fetches for a location stop.
+type observationAgeCollector struct {
+	desc *prometheus.Desc
+}
+
+func (c observationAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c observationAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	readingsMu.Lock()
+	defer readingsMu.Unlock()
+	now := time.Now()
+	for loc, at := range observedAt {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, now.Sub(at).Seconds(), loc)
+	}
+}
+
+// RecordWeatherReading updates the reading gauges for a tracked location from fresh upstream
+// data. Untracked locations are ignored to bound cardinality. A zero observed time means now.
+func RecordWeatherReading(location string, temperature float64, humidity int, windSpeed float64, observed time.Time) {
+	loc := normalizeLocationForMetrics(location)
+	if observed.IsZero() {
+		observed = time.Now()
+	}
+	readingsMu.Lock()
+	defer readingsMu.Unlock()
+	trackedLocationsMu.RLock()
+	_, ok := trackedLocations[loc]
+	trackedLocationsMu.RUnlock()
+	if !ok {
+		return
+	}
+	WeatherTemperatureCelsius.WithLabelValues(loc).Set(temperature)
+	WeatherHumidityPercent.WithLabelValues(loc).Set(float64(humidity))
+	WeatherWindSpeed.WithLabelValues(loc).Set(windSpeed)
+	observedAt[loc] = observed
+}
+
+// forgetUntrackedReadings removes reading gauges for locations no longer tracked, so removed
+// locations stop being exported instead of reporting their last value forever.
+func forgetUntrackedReadings() {
+	readingsMu.Lock()
+	defer readingsMu.Unlock()
+	trackedLocationsMu.RLock()
+	defer trackedLocationsMu.RUnlock()
+	for loc := range observedAt {
+		if _, ok := trackedLocations[loc]; ok {
+			continue
+		}
+		WeatherTemperatureCelsius.DeleteLabelValues(loc)
+		WeatherHumidityPercent.DeleteLabelValues(loc)
+		WeatherWindSpeed.DeleteLabelValues(loc)
+		delete(observedAt, loc)
+	}
+}
+
 // CircuitBreakerStateValue returns the gauge value for a state (0=closed, 1=open, 2=half-open).
 func CircuitBreakerStateValue(s int) float64 { return float64(s) }
 
@@ -393,13 +596,15 @@ func RegisterRateLimitGauges(window time.Duration) {
 }
 
 // SetTrackedLocations sets the allow-list for location metrics. Non-tracked locations increment "other".
+// Reading gauges for locations dropped from the list are removed.
 func SetTrackedLocations(locations []string) {
 	trackedLocationsMu.Lock()
-	defer trackedLocationsMu.Unlock()
 	trackedLocations = make(map[string]struct{}, len(locations))
 	for _, loc := range locations {
 		trackedLocations[normalizeLocationForMetrics(loc)] = struct{}{}
 	}
+	trackedLocationsMu.Unlock()
+	forgetUntrackedReadings()
 }
 
 // RecordWeatherQuery records a weather query for the given location.
@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestMetrics_Usable verifies that all Prometheus metrics can be used without
@@ -49,3 +50,41 @@ func TestMetricsHandler_ServesPrometheusFormat(t *testing.T) {
 		t.Error("MetricsHandler response should contain metric output")
 	}
 }
+
+// TestRecordWeatherReading verifies readings are exported for tracked locations only, the age
+// gauge is computed at scrape time, and untracking a location removes its gauges.
+func TestRecordWeatherReading(t *testing.T) {
+	// Arrange
+	SetTrackedLocations([]string{"Chicago"})
+	defer SetTrackedLocations(nil)
+	scrape := func() string {
+		w := httptest.NewRecorder()
+		MetricsHandler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+		return w.Body.String()
+	}
+
+	// Act
+	RecordWeatherReading(" chicago ", -3.5, 81, 7.2, time.Now().Add(-90*time.Second))
+	RecordWeatherReading("boston", 10, 50, 1, time.Now())
+	body := scrape()
+
+	// Assert
+	for _, want := range []string{
+		`weatherTemperatureCelsius{location="chicago"} -3.5`,
+		`weatherHumidityPercent{location="chicago"} 81`,
+		`weatherWindSpeed{location="chicago"} 7.2`,
+		`weatherObservationAgeSeconds{location="chicago"} 9`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q", want)
+		}
+	}
+	if strings.Contains(body, `location="boston"`) {
+		t.Error("metrics export untracked location boston")
+	}
+
+	SetTrackedLocations([]string{"seattle"})
+	if body := scrape(); strings.Contains(body, `location="chicago"`) {
+		t.Error("metrics still export chicago after it was untracked")
+	}
+}
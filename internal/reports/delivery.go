@@ -0,0 +1,95 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// Target delivers a rendered digest.
+type Target interface {
+	Deliver(ctx context.Context, job string, body []byte) error
+}
+
+// JobHeader names the report job on webhook deliveries.
+const JobHeader = "X-Report-Job"
+
+// WebhookTarget POSTs each digest as text/plain. Any 2xx response is success.
+type WebhookTarget struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookTarget validates rawURL and creates a WebhookTarget. Timeouts come from the
+// delivery context.
+func NewWebhookTarget(rawURL string) (*WebhookTarget, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook %q: must be an absolute http or https URL", rawURL)
+	}
+	return &WebhookTarget{url: rawURL, client: &http.Client{}}, nil
+}
+
+// Deliver implements Target.
+func (w *WebhookTarget) Deliver(ctx context.Context, job string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("User-Agent", "weather-alert-service")
+	req.Header.Set(JobHeader, job)
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post digest: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// FileTarget replaces a file with each digest. The write goes to a temp file that is renamed
+// over the target, so readers never see a partial digest.
+type FileTarget struct {
+	path string
+}
+
+// NewFileTarget creates a FileTarget. The parent directory is created on first delivery.
+func NewFileTarget(path string) *FileTarget {
+	return &FileTarget{path: path}
+}
+
+// Deliver implements Target.
+func (f *FileTarget) Deliver(ctx context.Context, job string, body []byte) error {
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create report dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".report-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write report: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	return nil
+}
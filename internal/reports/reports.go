@@ -0,0 +1,136 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// WeatherFetcher is implemented by the service layer. Digests go through it so report runs
+// share the cache and request coalescing with API traffic.
+type WeatherFetcher interface {
+	GetWeather(ctx context.Context, location string) (models.WeatherData, error)
+}
+
+// JobConfig is the raw report job definition loaded from config. NewJob validates it.
+type JobConfig struct {
+	Name         string
+	Schedule     string   // cron expression; see Schedule
+	Locations    []string // fetched in order
+	Template     string   // inline text/template source
+	TemplateFile string   // path to a template file; used when Template is empty
+	Webhook      string   // POST the rendered digest here, or
+	File         string   // write the rendered digest here
+}
+
+// Job is a validated report job.
+type Job struct {
+	Name      string
+	Schedule  Schedule
+	Locations []string
+	tmpl      *template.Template
+	target    Target
+}
+
+// DefaultTemplate renders one line per location. Used when a job sets neither template nor
+// template_file.
+const DefaultTemplate = `Weather digest: {{.Job}} ({{.GeneratedAt.Format "2006-01-02 15:04 MST"}})
+{{range .Locations}}{{if .Error}}- {{.Location}}: unavailable ({{.Error}})
+{{else}}- {{.Location}}: {{.Weather.Conditions}}, {{round .Weather.Temperature}}°C, humidity {{.Weather.Humidity}}%, wind {{round .Weather.WindSpeed}} m/s{{if .Weather.Stale}} (stale){{end}}
+{{end}}{{end}}`
+
+// templateFuncs are available to every report template.
+var templateFuncs = template.FuncMap{
+	"round": func(v float64) float64 { return math.Round(v*10) / 10 },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"title": func(s string) string {
+		if s == "" {
+			return s
+		}
+		return strings.ToUpper(s[:1]) + s[1:]
+	},
+}
+
+// NewJob validates cfg, parses its schedule and template, and builds its delivery target.
+func NewJob(cfg JobConfig) (*Job, error) {
+	name := strings.TrimSpace(cfg.Name)
+	if name == "" {
+		return nil, fmt.Errorf("report job: name is required")
+	}
+	sched, err := ParseSchedule(cfg.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("report job %q: %w", name, err)
+	}
+	locations := make([]string, 0, len(cfg.Locations))
+	for _, loc := range cfg.Locations {
+		if loc = strings.TrimSpace(loc); loc != "" {
+			locations = append(locations, loc)
+		}
+	}
+	if len(locations) == 0 {
+		return nil, fmt.Errorf("report job %q: at least one location is required", name)
+	}
+	src := cfg.Template
+	if src == "" && cfg.TemplateFile != "" {
+		data, err := os.ReadFile(cfg.TemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("report job %q: read template file: %w", name, err)
+		}
+		src = string(data)
+	}
+	if src == "" {
+		src = DefaultTemplate
+	}
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("report job %q: invalid template: %w", name, err)
+	}
+	var target Target
+	switch {
+	case cfg.Webhook != "" && cfg.File != "":
+		return nil, fmt.Errorf("report job %q: set only one of webhook or file", name)
+	case cfg.Webhook != "":
+		target, err = NewWebhookTarget(cfg.Webhook)
+		if err != nil {
+			return nil, fmt.Errorf("report job %q: %w", name, err)
+		}
+	case cfg.File != "":
+		target = NewFileTarget(cfg.File)
+	default:
+		return nil, fmt.Errorf("report job %q: a webhook or file target is required", name)
+	}
+	return &Job{Name: name, Schedule: sched, Locations: locations, tmpl: tmpl, target: target}, nil
+}
+
+// Digest is the data passed to a report template.
+type Digest struct {
+	Job         string
+	GeneratedAt time.Time
+	Locations   []LocationReport
+	Failed      int // locations that could not be fetched
+}
+
+// LocationReport is one location in a Digest. Error is set instead of Weather when the fetch
+// failed, so templates can report partial outages.
+type LocationReport struct {
+	Location string
+	Weather  models.WeatherData
+	Error    string
+}
+
+// Render executes the job template against d.
+func (j *Job) Render(d Digest) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := j.tmpl.Execute(&buf, d); err != nil {
+		return nil, fmt.Errorf("render report %q: %w", j.Name, err)
+	}
+	return buf.Bytes(), nil
+}
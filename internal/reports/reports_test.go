@@ -0,0 +1,213 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// mockFetcher returns canned weather per location after an optional delay; locations in failing
+// return an error. maxInFlight records the most concurrent calls seen.
+type mockFetcher struct {
+	mu          sync.Mutex
+	failing     map[string]bool
+	delay       time.Duration
+	calls       int
+	inFlight    int
+	maxInFlight int
+}
+
+func (m *mockFetcher) GetWeather(ctx context.Context, location string) (models.WeatherData, error) {
+	m.mu.Lock()
+	m.calls++
+	m.inFlight++
+	m.maxInFlight = max(m.maxInFlight, m.inFlight)
+	m.mu.Unlock()
+	time.Sleep(m.delay)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight--
+	if m.failing[location] {
+		return models.WeatherData{}, errors.New("upstream unavailable")
+	}
+	return models.WeatherData{Location: location, Temperature: 21.46, Conditions: "clear sky", Humidity: 40, WindSpeed: 3.04}, nil
+}
+
+// TestNewJob_Validation verifies that NewJob rejects malformed job configs.
+func TestNewJob_Validation(t *testing.T) {
+	valid := JobConfig{Name: "x", Schedule: "@daily", Locations: []string{"chicago"}, File: "out.txt"}
+	tests := []struct {
+		name    string
+		mutate  func(c *JobConfig)
+		wantErr string
+	}{
+		{name: "missing name", mutate: func(c *JobConfig) { c.Name = " " }, wantErr: "name is required"},
+		{name: "bad schedule", mutate: func(c *JobConfig) { c.Schedule = "daily" }, wantErr: "want 5 fields"},
+		{name: "no locations", mutate: func(c *JobConfig) { c.Locations = []string{""} }, wantErr: "location is required"},
+		{name: "bad template", mutate: func(c *JobConfig) { c.Template = "{{.Job" }, wantErr: "invalid template"},
+		{name: "missing template file", mutate: func(c *JobConfig) { c.TemplateFile = "does/not/exist.tmpl" }, wantErr: "read template file"},
+		{name: "no target", mutate: func(c *JobConfig) { c.File = "" }, wantErr: "webhook or file target is required"},
+		{name: "two targets", mutate: func(c *JobConfig) { c.Webhook = "https://example.com" }, wantErr: "only one of"},
+		{name: "relative webhook", mutate: func(c *JobConfig) { c.File, c.Webhook = "", "/hooks" }, wantErr: "absolute http"},
+		{name: "valid", mutate: func(c *JobConfig) {}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := valid
+			tc.mutate(&cfg)
+			_, err := NewJob(cfg)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("NewJob() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("NewJob() error = %v, want containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestRunJob_FileDefaultTemplate verifies a digest with a partial outage is rendered with the
+// default template and written to the file target.
+func TestRunJob_FileDefaultTemplate(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "reports", "digest.txt")
+	fetcher := &mockFetcher{failing: map[string]bool{"denver": true}}
+	s, err := NewScheduler([]JobConfig{{Name: "morning", Schedule: "@daily", Locations: []string{"chicago", "denver"}, File: path}}, fetcher, Config{}, nil)
+	if err != nil {
+		t.Fatalf("NewScheduler() error = %v", err)
+	}
+	s.now = func() time.Time { return time.Date(2024, 1, 10, 7, 0, 0, 0, time.UTC) }
+
+	// Act
+	err = s.RunJob(context.Background(), s.Jobs()[0])
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RunJob() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read digest: %v", err)
+	}
+	want := "Weather digest: morning (2024-01-10 07:00 UTC)\n" +
+		"- chicago: clear sky, 21.5°C, humidity 40%, wind 3 m/s\n" +
+		"- denver: unavailable (upstream unavailable)\n"
+	if string(data) != want {
+		t.Errorf("digest = %q, want %q", data, want)
+	}
+}
+
+// TestRunJob_BoundedConcurrency verifies a run fetches no more than Config.Concurrency
+// locations at a time.
+func TestRunJob_BoundedConcurrency(t *testing.T) {
+	// Arrange
+	locations := make([]string, 20)
+	for i := range locations {
+		locations[i] = fmt.Sprintf("city-%d", i)
+	}
+	fetcher := &mockFetcher{delay: 5 * time.Millisecond}
+	path := filepath.Join(t.TempDir(), "digest.txt")
+	s, err := NewScheduler([]JobConfig{{Name: "board", Schedule: "@hourly", Locations: locations, File: path}}, fetcher, Config{Concurrency: 3}, nil)
+	if err != nil {
+		t.Fatalf("NewScheduler() error = %v", err)
+	}
+
+	// Act
+	err = s.RunJob(context.Background(), s.Jobs()[0])
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RunJob() error = %v", err)
+	}
+	if fetcher.calls != len(locations) {
+		t.Errorf("calls = %d, want %d", fetcher.calls, len(locations))
+	}
+	if fetcher.maxInFlight > 3 || fetcher.maxInFlight < 2 {
+		t.Errorf("max concurrent fetches = %d, want 2..3", fetcher.maxInFlight)
+	}
+}
+
+// TestRunJob_WebhookRetries verifies failed deliveries are retried and a custom template is used.
+func TestRunJob_WebhookRetries(t *testing.T) {
+	var mu sync.Mutex
+	var attempts int
+	var body, jobHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		body, jobHeader = string(b), r.Header.Get(JobHeader)
+	}))
+	defer srv.Close()
+	s, err := NewScheduler([]JobConfig{{
+		Name:      "chat",
+		Schedule:  "@hourly",
+		Locations: []string{"chicago"},
+		Template:  `{{range .Locations}}{{title .Weather.Conditions}} in {{upper .Location}}{{end}}`,
+		Webhook:   srv.URL,
+	}}, &mockFetcher{}, Config{RetryDelay: time.Millisecond}, nil)
+	if err != nil {
+		t.Fatalf("NewScheduler() error = %v", err)
+	}
+
+	if err := s.RunJob(context.Background(), s.Jobs()[0]); err != nil {
+		t.Fatalf("RunJob() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 2 || body != "Clear sky in CHICAGO" || jobHeader != "chat" {
+		t.Errorf("attempts = %d, body = %q, job header = %q", attempts, body, jobHeader)
+	}
+}
+
+// TestRunJob_AllLocationsFail verifies a run with no weather fails after every attempt without
+// delivering.
+func TestRunJob_AllLocationsFail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "digest.txt")
+	fetcher := &mockFetcher{failing: map[string]bool{"chicago": true}}
+	s, _ := NewScheduler([]JobConfig{{Name: "x", Schedule: "@daily", Locations: []string{"chicago"}, File: path}}, fetcher, Config{MaxAttempts: 2, RetryDelay: time.Millisecond}, nil)
+
+	err := s.RunJob(context.Background(), s.Jobs()[0])
+
+	if !errors.Is(err, errAllLocationsFailed) {
+		t.Errorf("RunJob() error = %v, want errAllLocationsFailed", err)
+	}
+	if fetcher.calls != 2 {
+		t.Errorf("fetch calls = %d, want 2", fetcher.calls)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("digest file exists after failed run: %v", err)
+	}
+}
+
+// TestNewScheduler_DuplicateName verifies job names must be unique.
+func TestNewScheduler_DuplicateName(t *testing.T) {
+	job := JobConfig{Name: "x", Schedule: "@daily", Locations: []string{"chicago"}, File: "out.txt"}
+
+	_, err := NewScheduler([]JobConfig{job, job}, &mockFetcher{}, Config{}, nil)
+
+	if err == nil || !strings.Contains(err.Error(), "duplicate name") {
+		t.Errorf("NewScheduler() error = %v, want duplicate name", err)
+	}
+}
@@ -0,0 +1,78 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseSchedule_Errors verifies malformed cron expressions are rejected.
+func TestParseSchedule_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{name: "too few fields", expr: "0 7 * *", wantErr: "want 5 fields"},
+		{name: "minute out of range", expr: "60 * * * *", wantErr: "minute"},
+		{name: "inverted range", expr: "0 9-5 * * *", wantErr: "invalid range"},
+		{name: "zero step", expr: "*/0 * * * *", wantErr: "invalid step"},
+		{name: "non-numeric", expr: "0 7 * * mon", wantErr: "day of week"},
+		{name: "unknown descriptor", expr: "@yearly", wantErr: "want 5 fields"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseSchedule(tc.expr)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("ParseSchedule(%q) error = %v, want containing %q", tc.expr, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestSchedule_Next verifies the next fire time for common schedules.
+func TestSchedule_Next(t *testing.T) {
+	// Wednesday 2024-01-10 10:17:30 UTC
+	from := time.Date(2024, 1, 10, 10, 17, 30, 0, time.UTC)
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{name: "every minute", expr: "* * * * *", want: time.Date(2024, 1, 10, 10, 18, 0, 0, time.UTC)},
+		{name: "every 15 minutes", expr: "*/15 * * * *", want: time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC)},
+		{name: "hourly", expr: "@hourly", want: time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC)},
+		{name: "weekday mornings rolls to tomorrow", expr: "0 7 * * 1-5", want: time.Date(2024, 1, 11, 7, 0, 0, 0, time.UTC)},
+		{name: "weekly on Sunday as 7", expr: "30 8 * * 7", want: time.Date(2024, 1, 14, 8, 30, 0, 0, time.UTC)},
+		{name: "list of hours", expr: "0 6,18 * * *", want: time.Date(2024, 1, 10, 18, 0, 0, 0, time.UTC)},
+		{name: "monthly rolls to next month", expr: "@monthly", want: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month or weekday", expr: "0 0 15 * 5", want: time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{name: "leap day", expr: "0 0 29 2 *", want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "impossible date", expr: "0 0 31 2 *", want: time.Time{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := ParseSchedule(tc.expr)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) error = %v", tc.expr, err)
+			}
+			if got := s.Next(from); !got.Equal(tc.want) {
+				t.Errorf("Next() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestSchedule_NextInLocation verifies schedules fire at local wall-clock time.
+func TestSchedule_NextInLocation(t *testing.T) {
+	loc := time.FixedZone("UTC-6", -6*3600)
+	s, _ := ParseSchedule("0 7 * * *")
+
+	got := s.Next(time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC).In(loc))
+
+	if want := time.Date(2024, 1, 10, 13, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got.UTC(), want)
+	}
+}
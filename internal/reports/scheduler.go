@@ -0,0 +1,199 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+)
+
+// errAllLocationsFailed fails a run whose digest would contain no weather at all, so it is
+// retried instead of delivering a report of outages.
+var errAllLocationsFailed = errors.New("no location could be fetched")
+
+// Config configures a Scheduler.
+type Config struct {
+	Location    *time.Location // time zone schedules are evaluated in; default UTC
+	Timeout     time.Duration  // bounds one attempt (fetch, render, deliver); default 30s
+	MaxAttempts int            // total attempts per run including the first; default 3
+	RetryDelay  time.Duration  // backoff before the second attempt; doubles per attempt; default 30s
+	Concurrency int            // locations of one run fetched at a time; default 8
+}
+
+// Scheduler runs report jobs on their schedules.
+type Scheduler struct {
+	jobs    []*Job
+	fetcher WeatherFetcher
+	cfg     Config
+	logger  *zap.Logger
+	now     func() time.Time
+}
+
+// NewScheduler validates the job configs and returns a Scheduler. Job names must be unique.
+// logger may be nil.
+func NewScheduler(configs []JobConfig, fetcher WeatherFetcher, cfg Config, logger *zap.Logger) (*Scheduler, error) {
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = 30 * time.Second
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 8
+	}
+	jobs := make([]*Job, 0, len(configs))
+	seen := make(map[string]struct{}, len(configs))
+	for _, c := range configs {
+		job, err := NewJob(c)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := seen[job.Name]; dup {
+			return nil, fmt.Errorf("report job %q: duplicate name", job.Name)
+		}
+		seen[job.Name] = struct{}{}
+		jobs = append(jobs, job)
+	}
+	return &Scheduler{jobs: jobs, fetcher: fetcher, cfg: cfg, logger: logger, now: time.Now}, nil
+}
+
+// Jobs returns the validated jobs in config order.
+func (s *Scheduler) Jobs() []*Job {
+	return s.jobs
+}
+
+// Run runs every job on its schedule until ctx is done. Runs of one job never overlap; a run
+// that outlasts its next scheduled time skips it.
+func (s *Scheduler) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runSchedule(ctx, job)
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// runSchedule sleeps until each scheduled time of job and runs it.
+func (s *Scheduler) runSchedule(ctx context.Context, job *Job) {
+	for {
+		next := job.Schedule.Next(s.now().In(s.cfg.Location))
+		if next.IsZero() {
+			if s.logger != nil {
+				s.logger.Warn("report schedule never fires", zap.String("job", job.Name))
+			}
+			return
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		_ = s.RunJob(ctx, job)
+	}
+}
+
+// RunJob runs job once, retrying failed attempts with exponential backoff. Each attempt fetches
+// every location again, so a retry can recover from an upstream outage.
+func (s *Scheduler) RunJob(ctx context.Context, job *Job) error {
+	delay := s.cfg.RetryDelay
+	for attempt := 1; ; attempt++ {
+		err := s.attempt(ctx, job)
+		if err == nil {
+			observability.ReportRunsTotal.WithLabelValues(job.Name, "success").Inc()
+			observability.ReportLastSuccessTimestamp.WithLabelValues(job.Name).Set(float64(s.now().Unix()))
+			return nil
+		}
+		if attempt == s.cfg.MaxAttempts {
+			return s.fail(job, err)
+		}
+		observability.ReportRunsTotal.WithLabelValues(job.Name, "retry").Inc()
+		if s.logger != nil {
+			s.logger.Warn("report attempt failed; retrying", zap.String("job", job.Name), zap.Int("attempt", attempt), zap.Duration("delay", delay), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return s.fail(job, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// fail records a run that exhausted its attempts.
+func (s *Scheduler) fail(job *Job, err error) error {
+	observability.ReportRunsTotal.WithLabelValues(job.Name, "failure").Inc()
+	if s.logger != nil {
+		s.logger.Error("report failed", zap.String("job", job.Name), zap.Error(err))
+	}
+	return err
+}
+
+// attempt fetches, renders and delivers one digest within the configured timeout.
+func (s *Scheduler) attempt(ctx context.Context, job *Job) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	digest := s.collect(ctx, job)
+	if digest.Failed == len(digest.Locations) {
+		return fmt.Errorf("report %q: %w (first error: %s)", job.Name, errAllLocationsFailed, digest.Locations[0].Error)
+	}
+	body, err := job.Render(digest)
+	if err != nil {
+		return err
+	}
+	if err := job.target.Deliver(ctx, job.Name, body); err != nil {
+		return fmt.Errorf("deliver report %q: %w", job.Name, err)
+	}
+	return nil
+}
+
+// collect fetches the job locations, Config.Concurrency at a time, and assembles the digest in
+// config order.
+func (s *Scheduler) collect(ctx context.Context, job *Job) Digest {
+	d := Digest{
+		Job:         job.Name,
+		GeneratedAt: s.now().In(s.cfg.Location),
+		Locations:   make([]LocationReport, len(job.Locations)),
+	}
+	sem := make(chan struct{}, s.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i, loc := range job.Locations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			r := LocationReport{Location: loc}
+			data, err := s.fetcher.GetWeather(ctx, loc)
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				r.Weather = data
+			}
+			d.Locations[i] = r
+		}()
+	}
+	wg.Wait()
+	for _, r := range d.Locations {
+		if r.Error != "" {
+			d.Failed++
+		}
+	}
+	return d
+}
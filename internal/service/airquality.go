@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/client"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// defaultAirQualityTTL is the air quality cache TTL when SetAirQualityTTL is not called. The
+// upstream updates readings about hourly.
+const defaultAirQualityTTL = 15 * time.Minute
+
+// airQualityEntry is the cached air quality envelope under "airquality:<location>". Like
+// forecastEntry, it is stored past ExpiresAt for the stale cache TTL.
+type airQualityEntry struct {
+	AirQuality models.AirQuality `json:"airQuality"`
+	ExpiresAt  time.Time         `json:"expiresAt"`
+}
+
+// SetAirQualityTTL sets how long air quality is cached. Values <= 0 are ignored. Call during
+// startup before serving traffic.
+func (s *WeatherService) SetAirQualityTTL(ttl time.Duration) {
+	if ttl > 0 {
+		s.airQualityTTL = ttl
+	}
+}
+
+// GetAirQuality returns current air quality for the location, cache-aside with the air quality
+// TTL. An upstream failure falls back to a cached reading up to the stale cache TTL past expiry
+// (marked Stale). Returns client.ErrAirQualityUnsupported when the client does not implement
+// client.AirQualityClient.
+func (s *WeatherService) GetAirQuality(ctx context.Context, location string) (models.AirQuality, error) {
+	aqClient, ok := s.client.(client.AirQualityClient)
+	if !ok {
+		return models.AirQuality{}, client.ErrAirQualityUnsupported
+	}
+	key := validation.NormalizeLocation(location)
+	cacheKey := s.cacheKey("airquality:" + key)
+	logger := loggerFromContext(ctx)
+	now := time.Now()
+
+	entry, cached := s.readAirQuality(ctx, cacheKey)
+	if cached && now.Before(entry.ExpiresAt) {
+		observability.CacheHitsTotal.WithLabelValues("airquality").Inc()
+		return entry.AirQuality, nil
+	}
+
+	aq, err := aqClient.GetAirQuality(ctx, key)
+	if err != nil {
+		if cached && s.staleCacheTTL > 0 && now.Sub(entry.ExpiresAt) <= s.staleCacheTTL {
+			staleAge := now.Sub(entry.ExpiresAt)
+			observability.StaleCacheServesTotal.WithLabelValues(observability.MetricLocationLabel(key)).Inc()
+			observability.StaleCacheAgeSeconds.Observe(staleAge.Seconds())
+			if logger != nil {
+				logger.Info("serving stale air quality", zap.String("location", key), zap.Duration("age", staleAge))
+			}
+			stale := entry.AirQuality
+			stale.Stale = true
+			return stale, nil
+		}
+		return models.AirQuality{}, fmt.Errorf("fetch air quality for %s: %w", key, err)
+	}
+
+	raw, err := json.Marshal(airQualityEntry{AirQuality: aq, ExpiresAt: now.Add(s.airQualityTTL)})
+	if err == nil {
+		err = s.cache.SetBytes(ctx, cacheKey, raw, s.airQualityTTL+s.staleCacheTTL)
+	}
+	if err != nil {
+		observability.CacheErrorsTotal.WithLabelValues("set", categorizeCacheError(err)).Inc()
+		if logger != nil {
+			logger.Warn("cache set failed", zap.String("key", cacheKey), zap.Error(err))
+		}
+	}
+	return aq, nil
+}
+
+// readAirQuality returns the cached air quality envelope, fresh or not. Cache and decode errors
+// are treated as a miss.
+func (s *WeatherService) readAirQuality(ctx context.Context, cacheKey string) (airQualityEntry, bool) {
+	raw, ok, err := s.cache.GetBytes(ctx, cacheKey)
+	if err != nil {
+		observability.CacheErrorsTotal.WithLabelValues("get", categorizeCacheError(err)).Inc()
+		return airQualityEntry{}, false
+	}
+	if !ok {
+		return airQualityEntry{}, false
+	}
+	var entry airQualityEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return airQualityEntry{}, false
+	}
+	return entry, true
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// defaultAlertsTTL is the official alerts cache TTL when SetOfficialAlertsTTL is not called.
+const defaultAlertsTTL = 10 * time.Minute
+
+// SetOfficialAlertsTTL sets how long official alerts are cached. Values <= 0 are ignored.
+// Call during startup before serving traffic.
+func (s *WeatherService) SetOfficialAlertsTTL(ttl time.Duration) {
+	if ttl > 0 {
+		s.alertsTTL = ttl
+	}
+}
+
+// GetOfficialAlerts returns government-issued alerts for the location, cache-aside under the
+// key "alerts:<location>" with the alerts TTL. An empty result is cached too, so quiet
+// locations do not cost an upstream call per request. Cache errors fall through to upstream.
+func (s *WeatherService) GetOfficialAlerts(ctx context.Context, location string) ([]models.OfficialAlert, error) {
+	key := validation.NormalizeLocation(location)
+	cacheKey := s.cacheKey("alerts:" + key)
+	logger := loggerFromContext(ctx)
+
+	raw, ok, err := s.cache.GetBytes(ctx, cacheKey)
+	if err != nil {
+		observability.CacheErrorsTotal.WithLabelValues("get", categorizeCacheError(err)).Inc()
+	} else if ok {
+		var alerts []models.OfficialAlert
+		if jsonErr := json.Unmarshal(raw, &alerts); jsonErr == nil {
+			observability.CacheHitsTotal.WithLabelValues("alerts").Inc()
+			return alerts, nil
+		}
+	}
+
+	alerts, err := s.client.GetOfficialAlerts(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("fetch official alerts for %s: %w", key, err)
+	}
+	if alerts == nil {
+		alerts = []models.OfficialAlert{}
+	}
+
+	raw, err = json.Marshal(alerts)
+	if err == nil {
+		err = s.cache.SetBytes(ctx, cacheKey, raw, s.alertsTTL)
+	}
+	if err != nil {
+		observability.CacheErrorsTotal.WithLabelValues("set", categorizeCacheError(err)).Inc()
+		if logger != nil {
+			logger.Warn("cache set failed", zap.String("key", cacheKey), zap.Error(err))
+		}
+	}
+	return alerts, nil
+}
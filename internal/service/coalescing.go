@@ -4,30 +4,29 @@ import (
 	"context"
 	"sync"
 	"time"
-
-	"github.com/kjstillabower/weather-alert-service/internal/models"
 )
 
 // inFlightRequest tracks a single upstream request that multiple callers may wait for.
-type inFlightRequest struct {
+type inFlightRequest[T any] struct {
 	mu      sync.Mutex
-	result  models.WeatherData
+	result  T
 	err     error
 	done    bool
 	waiters []chan struct{} // Channels to notify waiters when result is ready
 }
 
 // requestCoalescer prevents cache stampede by coalescing concurrent requests for the same key.
-type requestCoalescer struct {
+// T is the result type (current weather, forecast).
+type requestCoalescer[T any] struct {
 	mu       sync.Mutex
-	inFlight map[string]*inFlightRequest
+	inFlight map[string]*inFlightRequest[T]
 	timeout  time.Duration
 }
 
 // newRequestCoalescer creates a new requestCoalescer with the specified timeout.
-func newRequestCoalescer(timeout time.Duration) *requestCoalescer {
-	return &requestCoalescer{
-		inFlight: make(map[string]*inFlightRequest),
+func newRequestCoalescer[T any](timeout time.Duration) *requestCoalescer[T] {
+	return &requestCoalescer[T]{
+		inFlight: make(map[string]*inFlightRequest[T]),
 		timeout:  timeout,
 	}
 }
@@ -35,7 +34,8 @@ func newRequestCoalescer(timeout time.Duration) *requestCoalescer {
 // GetOrDo checks if a request for key is already in-flight. If yes, waits for its result.
 // If no, executes fn and registers the request. Returns the result or error.
 // Respects context cancellation and timeout to prevent indefinite blocking.
-func (rc *requestCoalescer) GetOrDo(ctx context.Context, key string, fn func() (models.WeatherData, error)) (models.WeatherData, error) {
+func (rc *requestCoalescer[T]) GetOrDo(ctx context.Context, key string, fn func() (T, error)) (T, error) {
+	var zero T
 	rc.mu.Lock()
 	req, exists := rc.inFlight[key]
 	if exists {
@@ -49,7 +49,7 @@ func (rc *requestCoalescer) GetOrDo(ctx context.Context, key string, fn func() (
 			req.mu.Unlock()
 			rc.mu.Unlock()
 			if err != nil {
-				return models.WeatherData{}, err
+				return zero, err
 			}
 			return result, nil
 		}
@@ -67,16 +67,16 @@ func (rc *requestCoalescer) GetOrDo(ctx context.Context, key string, fn func() (
 			err := req.err
 			req.mu.Unlock()
 			if err != nil {
-				return models.WeatherData{}, err
+				return zero, err
 			}
 			return result, nil
 		case <-waitCtx.Done():
-			return models.WeatherData{}, waitCtx.Err()
+			return zero, waitCtx.Err()
 		}
 	}
 
 	// No existing request - create one
-	req = &inFlightRequest{
+	req = &inFlightRequest[T]{
 		waiters: make([]chan struct{}, 0),
 	}
 	rc.inFlight[key] = req
@@ -114,7 +114,7 @@ func (rc *requestCoalescer) GetOrDo(ctx context.Context, key string, fn func() (
 		req.mu.Unlock()
 		cancel()
 		if err != nil {
-			return models.WeatherData{}, err
+			return zero, err
 		}
 		return result, nil
 	}
@@ -128,16 +128,16 @@ func (rc *requestCoalescer) GetOrDo(ctx context.Context, key string, fn func() (
 		err := req.err
 		req.mu.Unlock()
 		if err != nil {
-			return models.WeatherData{}, err
+			return zero, err
 		}
 		return result, nil
 	case <-waitCtx.Done():
-		return models.WeatherData{}, waitCtx.Err()
+		return zero, waitCtx.Err()
 	}
 }
 
 // cleanup removes the in-flight request for key. Must be called after request completes.
-func (rc *requestCoalescer) cleanup(key string) {
+func (rc *requestCoalescer[T]) cleanup(key string) {
 	rc.mu.Lock()
 	defer rc.mu.Unlock()
 	delete(rc.inFlight, key)
@@ -11,7 +11,7 @@ import (
 )
 
 func TestRequestCoalescer_GetOrDo_ConcurrentRequests(t *testing.T) {
-	coalescer := newRequestCoalescer(5 * time.Second)
+	coalescer := newRequestCoalescer[models.WeatherData](5 * time.Second)
 	callCount := 0
 	var mu sync.Mutex
 
@@ -56,7 +56,7 @@ func TestRequestCoalescer_GetOrDo_ConcurrentRequests(t *testing.T) {
 }
 
 func TestRequestCoalescer_GetOrDo_ErrorPropagation(t *testing.T) {
-	coalescer := newRequestCoalescer(5 * time.Second)
+	coalescer := newRequestCoalescer[models.WeatherData](5 * time.Second)
 	wantErr := errors.New("api failure")
 
 	fn := func() (models.WeatherData, error) {
@@ -87,7 +87,7 @@ func TestRequestCoalescer_GetOrDo_ErrorPropagation(t *testing.T) {
 }
 
 func TestRequestCoalescer_GetOrDo_Timeout(t *testing.T) {
-	coalescer := newRequestCoalescer(100 * time.Millisecond)
+	coalescer := newRequestCoalescer[models.WeatherData](100 * time.Millisecond)
 
 	fn := func() (models.WeatherData, error) {
 		time.Sleep(200 * time.Millisecond) // Longer than timeout
@@ -107,7 +107,7 @@ func TestRequestCoalescer_GetOrDo_Timeout(t *testing.T) {
 }
 
 func TestRequestCoalescer_GetOrDo_DifferentKeys(t *testing.T) {
-	coalescer := newRequestCoalescer(5 * time.Second)
+	coalescer := newRequestCoalescer[models.WeatherData](5 * time.Second)
 	callCount := 0
 	var mu sync.Mutex
 
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"math"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// Thresholds outside which the heat index and wind chill are not defined, per the US National
+// Weather Service.
+const (
+	heatIndexMinTempF   = 80.0
+	windChillMaxTempC   = 10.0
+	windChillMinWindKMH = 4.8
+)
+
+// withComfortIndices returns data with DewPoint, HeatIndex, WindChill and ApparentTemperature
+// derived from its metric temperature, humidity and wind speed. Indices not defined for the
+// conditions are left nil; ApparentTemperature is the heat index or wind chill where one is
+// defined and the air temperature otherwise, as the NWS reports it. Computing them here rather
+// than in the client keeps them consistent across providers; models.WeatherData.InUnits
+// converts them with the other temperatures.
+func withComfortIndices(data models.WeatherData) models.WeatherData {
+	data.DewPoint, data.HeatIndex, data.WindChill, data.ApparentTemperature = nil, nil, nil, nil
+	if dp, ok := dewPoint(data.Temperature, data.Humidity); ok {
+		data.DewPoint = &dp
+	}
+	apparent := data.Temperature
+	if hi, ok := heatIndex(data.Temperature, data.Humidity); ok {
+		data.HeatIndex = &hi
+		apparent = hi
+	}
+	if wc, ok := windChill(data.Temperature, data.WindSpeed); ok {
+		data.WindChill = &wc
+		apparent = wc
+	}
+	data.ApparentTemperature = &apparent
+	return data
+}
+
+// dewPoint returns the dew point in °C for tempC and relative humidity (percent), using the
+// Magnus formula with the Alduchov and Eskridge (1996) coefficients. It is undefined for
+// humidity outside (0, 100].
+func dewPoint(tempC float64, humidity int) (float64, bool) {
+	if humidity <= 0 || humidity > 100 {
+		return 0, false
+	}
+	const a, b = 17.625, 243.04
+	gamma := math.Log(float64(humidity)/100) + a*tempC/(b+tempC)
+	return b * gamma / (a - gamma), true
+}
+
+// heatIndex returns the NWS heat index in °C for tempC and relative humidity (percent): the
+// Steadman approximation, or the Rothfusz regression with its low- and high-humidity
+// adjustments when that approximation reaches 80 °F. It is defined from 80 °F (26.7 °C).
+func heatIndex(tempC float64, humidity int) (float64, bool) {
+	t, rh := fahrenheit(tempC), float64(humidity)
+	if t < heatIndexMinTempF || humidity < 0 || humidity > 100 {
+		return 0, false
+	}
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (hi+t)/2 >= 80 {
+		hi = -42.379 + 2.04901523*t + 10.14333127*rh - 0.22475541*t*rh -
+			0.00683783*t*t - 0.05481717*rh*rh + 0.00122874*t*t*rh +
+			0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+		switch {
+		case rh < 13 && t <= 112:
+			hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+		case rh > 85 && t <= 87:
+			hi += (rh - 85) / 10 * (87 - t) / 5
+		}
+	}
+	return celsius(hi), true
+}
+
+// windChill returns the wind chill in °C for tempC and wind speed in m/s, using the metric
+// form of the 2001 NWS and Environment Canada index. It is defined at or below 10 °C (50 °F)
+// with wind above 4.8 km/h (3 mph).
+func windChill(tempC, windMS float64) (float64, bool) {
+	v := windMS * 3.6
+	if tempC > windChillMaxTempC || v <= windChillMinWindKMH {
+		return 0, false
+	}
+	v16 := math.Pow(v, 0.16)
+	return 13.12 + 0.6215*tempC - 11.37*v16 + 0.3965*tempC*v16, true
+}
+
+func fahrenheit(c float64) float64 { return c*9/5 + 32 }
+
+func celsius(f float64) float64 { return (f - 32) * 5 / 9 }
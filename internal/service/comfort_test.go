@@ -0,0 +1,117 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// TestHeatIndex verifies the heat index against the NWS heat index chart (°F, rounded to the
+// degree) and that it is undefined below 80 °F.
+func TestHeatIndex(t *testing.T) {
+	tests := []struct {
+		tempF    float64
+		humidity int
+		wantF    float64
+		wantOK   bool
+	}{
+		{tempF: 80, humidity: 40, wantF: 80, wantOK: true},
+		{tempF: 90, humidity: 50, wantF: 95, wantOK: true},
+		{tempF: 90, humidity: 70, wantF: 106, wantOK: true},
+		{tempF: 100, humidity: 40, wantF: 109, wantOK: true},
+		{tempF: 84, humidity: 90, wantF: 98, wantOK: true},
+		{tempF: 110, humidity: 40, wantF: 136, wantOK: true},
+		{tempF: 79, humidity: 90},
+	}
+
+	for _, tc := range tests {
+		got, ok := heatIndex(celsius(tc.tempF), tc.humidity)
+		if ok != tc.wantOK || (ok && math.Abs(fahrenheit(got)-tc.wantF) > 1) {
+			t.Errorf("heatIndex(%v°F, %d%%) = %.1f°F, %v; want %v°F, %v", tc.tempF, tc.humidity, fahrenheit(got), ok, tc.wantF, tc.wantOK)
+		}
+	}
+}
+
+// TestWindChill verifies the wind chill against the NWS wind chill chart (°F and mph, rounded to
+// the degree) and that it is undefined when warm or calm.
+func TestWindChill(t *testing.T) {
+	tests := []struct {
+		tempF   float64
+		windMPH float64
+		wantF   float64
+		wantOK  bool
+	}{
+		{tempF: 40, windMPH: 5, wantF: 36, wantOK: true},
+		{tempF: 30, windMPH: 10, wantF: 21, wantOK: true},
+		{tempF: 20, windMPH: 20, wantF: 4, wantOK: true},
+		{tempF: 0, windMPH: 15, wantF: -19, wantOK: true},
+		{tempF: -10, windMPH: 30, wantF: -39, wantOK: true},
+		{tempF: 55, windMPH: 20},
+		{tempF: 20, windMPH: 2},
+	}
+
+	for _, tc := range tests {
+		got, ok := windChill(celsius(tc.tempF), tc.windMPH/2.2369362920544)
+		if ok != tc.wantOK || (ok && math.Abs(fahrenheit(got)-tc.wantF) > 1) {
+			t.Errorf("windChill(%v°F, %v mph) = %.1f°F, %v; want %v°F, %v", tc.tempF, tc.windMPH, fahrenheit(got), ok, tc.wantF, tc.wantOK)
+		}
+	}
+}
+
+// TestDewPoint verifies the dew point against NWS calculator values (°C) and that it is
+// undefined for out-of-range humidity.
+func TestDewPoint(t *testing.T) {
+	tests := []struct {
+		tempC    float64
+		humidity int
+		want     float64
+		wantOK   bool
+	}{
+		{tempC: 20, humidity: 50, want: 9.3, wantOK: true},
+		{tempC: 30, humidity: 70, want: 23.9, wantOK: true},
+		{tempC: 0, humidity: 100, want: 0, wantOK: true},
+		{tempC: -10, humidity: 80, want: -12.9, wantOK: true},
+		{tempC: 20, humidity: 0},
+		{tempC: 20, humidity: 101},
+	}
+
+	for _, tc := range tests {
+		got, ok := dewPoint(tc.tempC, tc.humidity)
+		if ok != tc.wantOK || (ok && math.Abs(got-tc.want) > 0.2) {
+			t.Errorf("dewPoint(%v°C, %d%%) = %.2f, %v; want %v, %v", tc.tempC, tc.humidity, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+// TestWithComfortIndices verifies which indices are set and that the apparent temperature
+// follows the heat index, the wind chill, or the air temperature.
+func TestWithComfortIndices(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          models.WeatherData
+		wantHeatIndex bool
+		wantWindChill bool
+		wantApparent  float64
+	}{
+		{name: "hot", data: models.WeatherData{Temperature: celsius(90), Humidity: 50}, wantHeatIndex: true, wantApparent: celsius(95)},
+		{name: "cold and windy", data: models.WeatherData{Temperature: celsius(0), Humidity: 50, WindSpeed: 15 / 2.2369362920544}, wantWindChill: true, wantApparent: celsius(-19)},
+		{name: "mild", data: models.WeatherData{Temperature: 18, Humidity: 50, WindSpeed: 10}, wantApparent: 18},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := withComfortIndices(tc.data)
+
+			if (got.HeatIndex != nil) != tc.wantHeatIndex || (got.WindChill != nil) != tc.wantWindChill {
+				t.Errorf("heatIndex = %v, windChill = %v; want set %v, %v", got.HeatIndex, got.WindChill, tc.wantHeatIndex, tc.wantWindChill)
+			}
+			if got.DewPoint == nil {
+				t.Error("dewPoint not set")
+			}
+			if got.ApparentTemperature == nil || math.Abs(*got.ApparentTemperature-tc.wantApparent) > 0.6 {
+				t.Errorf("apparentTemperature = %v, want %.1f", got.ApparentTemperature, tc.wantApparent)
+			}
+		})
+	}
+}
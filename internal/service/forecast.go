@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+const (
+	// defaultForecastTTL is the forecast cache TTL when SetForecastTTL is not called. The upstream
+	// forecast is refreshed every few hours, so it tolerates a longer TTL than current weather.
+	defaultForecastTTL = 30 * time.Minute
+	// forecastStep is the length of one upstream forecast period.
+	forecastStep = 3 * time.Hour
+	// MaxForecastHours is the forecast horizon the upstream provides (5 days).
+	MaxForecastHours = 120
+)
+
+// forecastEntry is the cached forecast envelope under "forecast:<location>". It is stored for
+// the forecast TTL plus the stale cache TTL so the stale fallback can still read it after
+// ExpiresAt, which marks the end of freshness.
+type forecastEntry struct {
+	Forecast  models.Forecast `json:"forecast"`
+	ExpiresAt time.Time       `json:"expiresAt"`
+}
+
+// SetForecastTTL sets how long forecasts are cached. Values <= 0 are ignored. Call during
+// startup before serving traffic.
+func (s *WeatherService) SetForecastTTL(ttl time.Duration) {
+	if ttl > 0 {
+		s.forecastTTL = ttl
+	}
+}
+
+// GetForecast returns the forecast periods for the location that have not yet ended and start
+// within the next hours, using cache-aside with the forecast TTL. Concurrent misses for a
+// location share one upstream call when coalescing is enabled, and an upstream failure falls
+// back to a cached forecast up to the stale cache TTL past expiry (marked Stale).
+func (s *WeatherService) GetForecast(ctx context.Context, location string, hours int) (models.Forecast, error) {
+	key := validation.NormalizeLocation(location)
+	cacheKey := s.cacheKey("forecast:" + key)
+	logger := loggerFromContext(ctx)
+	now := time.Now()
+
+	entry, cached := s.readForecast(ctx, cacheKey)
+	if cached && now.Before(entry.ExpiresAt) {
+		observability.CacheHitsTotal.WithLabelValues("forecast").Inc()
+		return trimForecast(entry.Forecast, now, hours), nil
+	}
+
+	var forecast models.Forecast
+	var err error
+	if s.forecastCoalescer != nil {
+		forecast, err = s.forecastCoalescer.GetOrDo(ctx, key, func() (models.Forecast, error) {
+			return s.client.GetForecast(ctx, key)
+		})
+	} else {
+		forecast, err = s.client.GetForecast(ctx, key)
+	}
+	if err != nil {
+		if cached && s.staleCacheTTL > 0 && now.Sub(entry.ExpiresAt) <= s.staleCacheTTL {
+			staleAge := now.Sub(entry.ExpiresAt)
+			observability.StaleCacheServesTotal.WithLabelValues(observability.MetricLocationLabel(key)).Inc()
+			observability.StaleCacheAgeSeconds.Observe(staleAge.Seconds())
+			if logger != nil {
+				logger.Info("serving stale forecast", zap.String("location", key), zap.Duration("age", staleAge))
+			}
+			stale := trimForecast(entry.Forecast, now, hours)
+			stale.Stale = true
+			return stale, nil
+		}
+		return models.Forecast{}, fmt.Errorf("fetch forecast for %s: %w", key, err)
+	}
+
+	raw, err := json.Marshal(forecastEntry{Forecast: forecast, ExpiresAt: now.Add(s.forecastTTL)})
+	if err == nil {
+		err = s.cache.SetBytes(ctx, cacheKey, raw, s.forecastTTL+s.staleCacheTTL)
+	}
+	if err != nil {
+		observability.CacheErrorsTotal.WithLabelValues("set", categorizeCacheError(err)).Inc()
+		if logger != nil {
+			logger.Warn("cache set failed", zap.String("key", cacheKey), zap.Error(err))
+		}
+	}
+	return trimForecast(forecast, now, hours), nil
+}
+
+// readForecast returns the cached forecast envelope, fresh or not. Cache and decode errors are
+// treated as a miss.
+func (s *WeatherService) readForecast(ctx context.Context, cacheKey string) (forecastEntry, bool) {
+	raw, ok, err := s.cache.GetBytes(ctx, cacheKey)
+	if err != nil {
+		observability.CacheErrorsTotal.WithLabelValues("get", categorizeCacheError(err)).Inc()
+		return forecastEntry{}, false
+	}
+	if !ok {
+		return forecastEntry{}, false
+	}
+	var entry forecastEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return forecastEntry{}, false
+	}
+	return entry, true
+}
+
+// trimForecast returns f with only the periods that have not ended by now and start before
+// now+hours. The cached forecast is not modified.
+func trimForecast(f models.Forecast, now time.Time, hours int) models.Forecast {
+	cutoff := now.Add(time.Duration(hours) * time.Hour)
+	periods := make([]models.ForecastPeriod, 0, len(f.Periods))
+	for _, p := range f.Periods {
+		if !p.Time.Add(forecastStep).After(now) || !p.Time.Before(cutoff) {
+			continue
+		}
+		periods = append(periods, p)
+	}
+	f.Periods = periods
+	return f
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// defaultSearchTTL is the location search cache TTL when SetSearchTTL is not called. Geocoding
+// results change rarely.
+const defaultSearchTTL = 24 * time.Hour
+
+// SetSearchTTL sets how long location search results are cached. Values <= 0 are ignored.
+// Call during startup before serving traffic.
+func (s *WeatherService) SetSearchTTL(ttl time.Duration) {
+	if ttl > 0 {
+		s.searchTTL = ttl
+	}
+}
+
+// SearchLocations returns candidate places matching query, cache-aside under the key
+// "search:<query>" with the search TTL. An empty result is cached too. Cache errors fall
+// through to upstream.
+func (s *WeatherService) SearchLocations(ctx context.Context, query string) ([]models.Place, error) {
+	key := validation.NormalizeLocation(query)
+	cacheKey := s.cacheKey("search:" + key)
+	logger := loggerFromContext(ctx)
+
+	raw, ok, err := s.cache.GetBytes(ctx, cacheKey)
+	if err != nil {
+		observability.CacheErrorsTotal.WithLabelValues("get", categorizeCacheError(err)).Inc()
+	} else if ok {
+		var places []models.Place
+		if jsonErr := json.Unmarshal(raw, &places); jsonErr == nil {
+			observability.CacheHitsTotal.WithLabelValues("search").Inc()
+			return places, nil
+		}
+	}
+
+	places, err := s.client.SearchLocations(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("search locations for %s: %w", key, err)
+	}
+	if places == nil {
+		places = []models.Place{}
+	}
+
+	raw, err = json.Marshal(places)
+	if err == nil {
+		err = s.cache.SetBytes(ctx, cacheKey, raw, s.searchTTL)
+	}
+	if err != nil {
+		observability.CacheErrorsTotal.WithLabelValues("set", categorizeCacheError(err)).Inc()
+		if logger != nil {
+			logger.Warn("cache set failed", zap.String("key", cacheKey), zap.Error(err))
+		}
+	}
+	return places, nil
+}
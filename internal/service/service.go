@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -12,36 +13,79 @@ import (
 	"github.com/kjstillabower/weather-alert-service/internal/client"
 	"github.com/kjstillabower/weather-alert-service/internal/models"
 	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
 )
 
 // WeatherService orchestrates weather data retrieval using cache-aside pattern
 // with upstream API fallback. Implements the service layer business logic.
 type WeatherService struct {
-	client          client.WeatherClient
-	cache           cache.Cache
-	ttl             time.Duration
-	staleCacheTTL   time.Duration // Maximum age for stale cache fallback (0 = disabled)
-	stampedeTracker *stampedeTracker
-	coalescer       *requestCoalescer // Optional request coalescing (nil if disabled)
+	client            client.WeatherClient
+	cache             cache.Cache
+	ttl               time.Duration
+	staleCacheTTL     time.Duration // Maximum age for stale cache fallback (0 = disabled)
+	stampedeTracker   *stampedeTracker
+	coalescer         *requestCoalescer[models.WeatherData] // Optional request coalescing (nil if disabled)
+	fetchHooks        []FetchHook                           // Called after each fresh upstream fetch; registered at startup
+	alertsTTL         time.Duration                         // Cache TTL for official alerts
+	forecastTTL       time.Duration                         // Cache TTL for forecasts
+	forecastCoalescer *requestCoalescer[models.Forecast]    // Forecast request coalescing (nil if disabled)
+	searchTTL         time.Duration                         // Cache TTL for location search results
+	airQualityTTL     time.Duration                         // Cache TTL for air quality
+	cacheNamespace    string                                // Prefix of every cache key ("" for none)
 }
 
+// FetchHook is called after a fresh upstream fetch for a location has been written to cache.
+// key is the normalized location. Hooks run synchronously on the request path, so they must
+// be cheap and must not block; offload slow work (network I/O) to a goroutine.
+type FetchHook func(ctx context.Context, key string, data models.WeatherData)
+
 // NewWeatherService creates a new WeatherService with the provided dependencies.
 // TTL specifies the cache expiration duration for weather data.
 // staleCacheTTL specifies maximum age for stale cache fallback (0 = disabled).
 // coalesceEnabled and coalesceTimeout configure request coalescing (disabled if timeout 0).
 func NewWeatherService(client client.WeatherClient, cache cache.Cache, ttl time.Duration, staleCacheTTL time.Duration, coalesceEnabled bool, coalesceTimeout time.Duration) *WeatherService {
-	var coalescer *requestCoalescer
+	var coalescer *requestCoalescer[models.WeatherData]
+	var forecastCoalescer *requestCoalescer[models.Forecast]
 	if coalesceEnabled && coalesceTimeout > 0 {
-		coalescer = newRequestCoalescer(coalesceTimeout)
+		coalescer = newRequestCoalescer[models.WeatherData](coalesceTimeout)
+		forecastCoalescer = newRequestCoalescer[models.Forecast](coalesceTimeout)
 	}
 	return &WeatherService{
-		client:          client,
-		cache:           cache,
-		ttl:             ttl,
-		staleCacheTTL:   staleCacheTTL,
-		stampedeTracker: newStampedeTracker(),
-		coalescer:       coalescer,
+		client:            client,
+		cache:             cache,
+		ttl:               ttl,
+		staleCacheTTL:     staleCacheTTL,
+		stampedeTracker:   newStampedeTracker(),
+		coalescer:         coalescer,
+		alertsTTL:         defaultAlertsTTL,
+		forecastTTL:       defaultForecastTTL,
+		forecastCoalescer: forecastCoalescer,
+		searchTTL:         defaultSearchTTL,
+		airQualityTTL:     defaultAirQualityTTL,
+	}
+}
+
+// AddFetchHook registers a hook that observes fresh upstream data. Not safe for concurrent use
+// with GetWeather; call during startup before serving traffic.
+func (s *WeatherService) AddFetchHook(hook FetchHook) {
+	if hook != nil {
+		s.fetchHooks = append(s.fetchHooks, hook)
+	}
+}
+
+// SetCacheNamespace prefixes every cache key with namespace and a colon, e.g. the weather
+// provider name, so a shared cache never serves one provider's data after switching to
+// another. Call during startup before serving traffic.
+func (s *WeatherService) SetCacheNamespace(namespace string) {
+	s.cacheNamespace = namespace
+}
+
+// cacheKey returns key in the service's cache namespace.
+func (s *WeatherService) cacheKey(key string) string {
+	if s.cacheNamespace == "" {
+		return key
 	}
+	return s.cacheNamespace + ":" + key
 }
 
 // loggerFromContext extracts a zap.Logger from request context if present.
@@ -55,16 +99,40 @@ func loggerFromContext(ctx context.Context) *zap.Logger {
 	return nil
 }
 
+// defaultLanguage is the upstream's default response language. Requests for it share the
+// unlocalized cache entry.
+const defaultLanguage = "en"
+
 // GetWeather retrieves weather data for the specified location using cache-aside pattern.
 // Checks cache first, falls back to upstream API on cache miss, and populates cache on success.
 // Returns cached data if available, otherwise fetches from upstream and caches the result.
 func (s *WeatherService) GetWeather(ctx context.Context, location string) (models.WeatherData, error) {
-	key := normalizeLocation(location)
+	return s.getWeather(ctx, validation.NormalizeLocation(location), "")
+}
+
+// GetLocalizedWeather is GetWeather with condition descriptions in lang (an upstream language
+// code; "" or "en" is the default). Localized results are cached under "<location>@<lang>" and
+// do not feed fetch hooks or reading gauges, which track the unlocalized data.
+func (s *WeatherService) GetLocalizedWeather(ctx context.Context, location, lang string) (models.WeatherData, error) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == defaultLanguage {
+		lang = ""
+	}
+	return s.getWeather(ctx, validation.NormalizeLocation(location), lang)
+}
+
+// getWeather implements GetWeather and GetLocalizedWeather for a normalized location key.
+func (s *WeatherService) getWeather(ctx context.Context, key, lang string) (models.WeatherData, error) {
+	cacheKey := s.cacheKey(key)
+	if lang != "" {
+		cacheKey = s.cacheKey(key + "@" + lang)
+		ctx = client.WithLanguage(ctx, lang)
+	}
 	start := time.Now()
 	logger := loggerFromContext(ctx)
 
 	getStart := time.Now()
-	cached, ok, err := s.cache.Get(ctx, key)
+	cached, ok, err := s.cache.Get(ctx, cacheKey)
 	getDuration := time.Since(getStart).Seconds()
 	if err != nil {
 		observability.CacheErrorsTotal.WithLabelValues("get", categorizeCacheError(err)).Inc()
@@ -76,11 +144,12 @@ func (s *WeatherService) GetWeather(ctx context.Context, location string) (model
 			logger.Debug("cache hit", zap.String("location", key))
 			logger.Debug("weather served", zap.String("location", key), zap.Bool("cached", true), zap.Duration("duration", time.Since(start)))
 		}
-		return cached, nil
+		// Recomputed so entries cached before the indices were added carry them too.
+		return withComfortIndices(cached), nil
 	}
 
-	concurrentMisses := s.stampedeTracker.RecordMiss(key)
-	defer s.stampedeTracker.RecordHit(key)
+	concurrentMisses := s.stampedeTracker.RecordMiss(cacheKey)
+	defer s.stampedeTracker.RecordHit(cacheKey)
 	locLabel := observability.MetricLocationLabel(key)
 	if concurrentMisses > 1 {
 		observability.CacheStampedeDetectedTotal.WithLabelValues(locLabel).Inc()
@@ -92,11 +161,15 @@ func (s *WeatherService) GetWeather(ctx context.Context, location string) (model
 	}
 
 	// Use coalescer if enabled to prevent concurrent upstream calls for same key
+	// fetched is set only for the caller whose fn performed the upstream call, so fetch hooks
+	// fire once per upstream response rather than once per coalesced waiter.
 	var data models.WeatherData
 	var upstreamErr error
+	var fetched atomic.Bool
 	if s.coalescer != nil {
 		coalesceStart := time.Now()
-		data, upstreamErr = s.coalescer.GetOrDo(ctx, key, func() (models.WeatherData, error) {
+		data, upstreamErr = s.coalescer.GetOrDo(ctx, cacheKey, func() (models.WeatherData, error) {
+			fetched.Store(true)
 			return s.client.GetCurrentWeather(ctx, key)
 		})
 		coalesceWait := time.Since(coalesceStart)
@@ -109,29 +182,31 @@ func (s *WeatherService) GetWeather(ctx context.Context, location string) (model
 			observability.RequestCoalescingWaitSeconds.Observe(coalesceWait.Seconds())
 		}
 	} else {
+		fetched.Store(true)
 		data, upstreamErr = s.client.GetCurrentWeather(ctx, key)
 	}
 	if upstreamErr != nil {
 		// Upstream failed - try stale cache if enabled
 		if s.staleCacheTTL > 0 {
-			stale, ok, staleErr := s.cache.GetStale(ctx, key, s.staleCacheTTL)
+			stale, ok, staleErr := s.cache.GetStale(ctx, cacheKey, s.staleCacheTTL)
 			if staleErr == nil && ok {
-				// Calculate age from timestamp
-				staleAge := time.Since(stale.Timestamp)
+				// Calculate age from fetch time
+				staleAge := time.Since(stale.FetchedAt)
 				observability.StaleCacheServesTotal.WithLabelValues(observability.MetricLocationLabel(key)).Inc()
 				observability.StaleCacheAgeSeconds.Observe(staleAge.Seconds())
 				stale.Stale = true
 				if logger != nil {
 					logger.Info("serving stale cache", zap.String("location", key), zap.Duration("age", staleAge))
 				}
-				return stale, nil
+				return withComfortIndices(stale), nil
 			}
 		}
 		return models.WeatherData{}, fmt.Errorf("fetch weather for %s: %w", key, upstreamErr)
 	}
+	data = withComfortIndices(data)
 
 	setStart := time.Now()
-	if setErr := s.cache.Set(ctx, key, data, s.ttl); setErr != nil {
+	if setErr := s.cache.Set(ctx, cacheKey, data, s.ttl); setErr != nil {
 		observability.CacheErrorsTotal.WithLabelValues("set", categorizeCacheError(setErr)).Inc()
 		observability.CacheOperationDurationSeconds.WithLabelValues("set", "error").Observe(time.Since(setStart).Seconds())
 		if logger != nil {
@@ -140,12 +215,38 @@ func (s *WeatherService) GetWeather(ctx context.Context, location string) (model
 	} else {
 		observability.CacheOperationDurationSeconds.WithLabelValues("set", "success").Observe(time.Since(setStart).Seconds())
 	}
+	if lang == "" {
+		observability.RecordWeatherReading(key, data.Temperature, data.Humidity, data.WindSpeed, data.Timestamp)
+	}
+	if fetched.Load() && lang == "" {
+		for _, hook := range s.fetchHooks {
+			hook(ctx, key, data)
+		}
+	}
 	if logger != nil {
 		logger.Debug("weather served", zap.String("location", key), zap.Bool("cached", false), zap.Duration("duration", time.Since(start)))
 	}
 	return data, nil
 }
 
+// CacheMaxAge returns how much longer data, as returned by GetWeather, stays fresh in the cache:
+// the cache TTL less the time since it was fetched. Stale data returns 0.
+func (s *WeatherService) CacheMaxAge(data models.WeatherData) time.Duration {
+	if data.Stale || data.FetchedAt.IsZero() {
+		return 0
+	}
+	return max(s.ttl-time.Since(data.FetchedAt), 0)
+}
+
+// Source names the upstream provider weather comes from, or "" if the client does not
+// implement client.ProviderNamer.
+func (s *WeatherService) Source() string {
+	if namer, ok := s.client.(client.ProviderNamer); ok {
+		return namer.ProviderName()
+	}
+	return ""
+}
+
 // categorizeCacheError returns a stable label for cache error metrics (timeout, connection, unknown).
 func categorizeCacheError(err error) string {
 	if err == nil {
@@ -160,9 +261,3 @@ func categorizeCacheError(err error) string {
 	}
 	return "unknown"
 }
-
-// normalizeLocation normalizes location strings by trimming whitespace and converting to lowercase.
-// Used to ensure consistent cache keys and API requests regardless of input format.
-func normalizeLocation(location string) string {
-	return strings.ToLower(strings.TrimSpace(location))
-}
@@ -2,23 +2,49 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/kjstillabower/weather-alert-service/internal/client"
 	"github.com/kjstillabower/weather-alert-service/internal/models"
 )
 
 type mockWeatherClient struct {
-	weather     models.WeatherData
-	err         error
-	validateErr error
+	weather       models.WeatherData
+	err           error
+	validateErr   error
+	alerts        []models.OfficialAlert
+	alertCalls    int
+	forecast      models.Forecast
+	forecastErr   error
+	forecastCalls int
+	places        []models.Place
+	searchCalls   int
 }
 
 func (m *mockWeatherClient) GetCurrentWeather(ctx context.Context, location string) (models.WeatherData, error) {
 	return m.weather, m.err
 }
 
+func (m *mockWeatherClient) GetForecast(ctx context.Context, location string) (models.Forecast, error) {
+	m.forecastCalls++
+	return m.forecast, m.forecastErr
+}
+
+func (m *mockWeatherClient) SearchLocations(ctx context.Context, query string) ([]models.Place, error) {
+	m.searchCalls++
+	return m.places, m.err
+}
+
+func (m *mockWeatherClient) GetOfficialAlerts(ctx context.Context, location string) ([]models.OfficialAlert, error) {
+	m.alertCalls++
+	return m.alerts, m.err
+}
+
 func (m *mockWeatherClient) ValidateAPIKey(ctx context.Context) error {
 	return m.validateErr
 }
@@ -26,6 +52,7 @@ func (m *mockWeatherClient) ValidateAPIKey(ctx context.Context) error {
 type mockCache struct {
 	data      map[string]models.WeatherData
 	staleData map[string]models.WeatherData // Data that's expired but available for stale retrieval
+	bytes     map[string][]byte
 	err       error
 }
 
@@ -65,44 +92,23 @@ func (m *mockCache) Set(ctx context.Context, key string, value models.WeatherDat
 	return nil
 }
 
-// TestNormalizeLocation verifies that normalizeLocation trims whitespace, converts to lowercase,
-// and handles various input formats correctly.
-func TestNormalizeLocation(t *testing.T) {
-	tests := []struct {
-		name string
-		in   string
-		want string
-	}{
-		{
-			name: "trim and lower",
-			in:   " Seattle ",
-			want: "seattle",
-		},
-		{
-			name: "already normalized",
-			in:   "seattle",
-			want: "seattle",
-		},
-		{
-			name: "mixed case",
-			in:   "SeAtTlE",
-			want: "seattle",
-		},
-		{
-			name: "with spaces",
-			in:   "  New York  ",
-			want: "new york",
-		},
+func (m *mockCache) GetBytes(ctx context.Context, key string) ([]byte, bool, error) {
+	if m.err != nil {
+		return nil, false, m.err
 	}
+	val, ok := m.bytes[key]
+	return val, ok, nil
+}
 
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			got := normalizeLocation(tc.in)
-			if got != tc.want {
-				t.Fatalf("normalizeLocation(%q) = %q, want %q", tc.in, got, tc.want)
-			}
-		})
+func (m *mockCache) SetBytes(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if m.err != nil {
+		return m.err
 	}
+	if m.bytes == nil {
+		m.bytes = make(map[string][]byte)
+	}
+	m.bytes[key] = value
+	return nil
 }
 
 // TestWeatherService_GetWeather_CacheHit verifies that GetWeather returns cached data
@@ -299,3 +305,493 @@ func TestWeatherService_GetWeather_StaleCacheDisabled(t *testing.T) {
 		t.Fatal("GetWeather() error = nil, want error (stale cache disabled)")
 	}
 }
+
+// lockedCache is a goroutine-safe Cache fake for concurrent service tests.
+type lockedCache struct {
+	mu   sync.Mutex
+	data map[string]models.WeatherData
+}
+
+func (c *lockedCache) Get(ctx context.Context, key string) (models.WeatherData, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.data[key]
+	return v, ok, nil
+}
+
+func (c *lockedCache) GetStale(ctx context.Context, key string, maxStaleAge time.Duration) (models.WeatherData, bool, error) {
+	return c.Get(ctx, key)
+}
+
+func (c *lockedCache) Set(ctx context.Context, key string, value models.WeatherData, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.data == nil {
+		c.data = make(map[string]models.WeatherData)
+	}
+	c.data[key] = value
+	return nil
+}
+
+func (c *lockedCache) GetBytes(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (c *lockedCache) SetBytes(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return nil
+}
+
+// slowWeatherClient returns weather after a fixed delay so concurrent callers coalesce.
+type slowWeatherClient struct {
+	delay time.Duration
+	calls atomic.Int32
+}
+
+func (c *slowWeatherClient) GetCurrentWeather(ctx context.Context, location string) (models.WeatherData, error) {
+	c.calls.Add(1)
+	time.Sleep(c.delay)
+	return models.WeatherData{Location: location, Timestamp: time.Now()}, nil
+}
+
+func (c *slowWeatherClient) GetForecast(ctx context.Context, location string) (models.Forecast, error) {
+	return models.Forecast{}, nil
+}
+
+func (c *slowWeatherClient) SearchLocations(ctx context.Context, query string) ([]models.Place, error) {
+	return nil, nil
+}
+
+func (c *slowWeatherClient) GetOfficialAlerts(ctx context.Context, location string) ([]models.OfficialAlert, error) {
+	return nil, nil
+}
+
+func (c *slowWeatherClient) ValidateAPIKey(ctx context.Context) error { return nil }
+
+// TestWeatherService_FetchHooks_CalledOnFreshFetchOnly verifies that fetch hooks observe
+// upstream fetches with the normalized key, and are not called for cache hits or stale serves.
+func TestWeatherService_FetchHooks_CalledOnFreshFetchOnly(t *testing.T) {
+	// Arrange: empty cache, successful upstream, recording hook
+	mockClient := &mockWeatherClient{weather: models.WeatherData{Location: "denver", Temperature: 21}}
+	mockCache := &mockCache{data: make(map[string]models.WeatherData)}
+	svc := NewWeatherService(mockClient, mockCache, 5*time.Minute, time.Hour, false, 0)
+	var keys []string
+	svc.AddFetchHook(func(ctx context.Context, key string, data models.WeatherData) {
+		keys = append(keys, key)
+	})
+
+	// Act: miss (fetch), hit, then upstream failure served from stale cache
+	if _, err := svc.GetWeather(context.Background(), " Denver "); err != nil {
+		t.Fatalf("GetWeather() error = %v", err)
+	}
+	if _, err := svc.GetWeather(context.Background(), "denver"); err != nil {
+		t.Fatalf("GetWeather() cached error = %v", err)
+	}
+	mockClient.err = errors.New("upstream down")
+	delete(mockCache.data, "denver")
+	mockCache.staleData = map[string]models.WeatherData{"denver": {Location: "denver", Timestamp: time.Now()}}
+	if _, err := svc.GetWeather(context.Background(), "denver"); err != nil {
+		t.Fatalf("GetWeather() stale error = %v", err)
+	}
+
+	// Assert: only the upstream fetch was observed
+	if len(keys) != 1 || keys[0] != "denver" {
+		t.Errorf("hook keys = %v, want [denver]", keys)
+	}
+}
+
+// TestWeatherService_GetLocalizedWeather verifies localized weather is cached per language,
+// the default language shares the unlocalized entry, and only unlocalized fetches reach hooks.
+func TestWeatherService_GetLocalizedWeather(t *testing.T) {
+	// Arrange
+	mockClient := &mockWeatherClient{weather: models.WeatherData{Location: "paris", Conditions: "ciel dégagé"}}
+	mockCache := &mockCache{data: make(map[string]models.WeatherData)}
+	svc := NewWeatherService(mockClient, mockCache, 5*time.Minute, 0, false, 0)
+	var hookCalls int
+	svc.AddFetchHook(func(ctx context.Context, key string, data models.WeatherData) {
+		hookCalls++
+	})
+
+	// Act
+	if _, err := svc.GetLocalizedWeather(context.Background(), "Paris", "FR"); err != nil {
+		t.Fatalf("GetLocalizedWeather(fr) error = %v", err)
+	}
+	if _, err := svc.GetLocalizedWeather(context.Background(), "paris", "en"); err != nil {
+		t.Fatalf("GetLocalizedWeather(en) error = %v", err)
+	}
+
+	// Assert
+	if _, ok := mockCache.data["paris@fr"]; !ok {
+		t.Errorf("cache keys = %v, want paris@fr", mockCache.data)
+	}
+	if _, ok := mockCache.data["paris"]; !ok || len(mockCache.data) != 2 {
+		t.Errorf("cache keys = %v, want paris and paris@fr", mockCache.data)
+	}
+	if hookCalls != 1 {
+		t.Errorf("hook calls = %d, want 1 (unlocalized fetch only)", hookCalls)
+	}
+}
+
+// TestWeatherService_FetchHooks_OncePerCoalescedFetch verifies that concurrent callers sharing
+// one coalesced upstream request trigger the fetch hooks once, not once per waiter.
+func TestWeatherService_FetchHooks_OncePerCoalescedFetch(t *testing.T) {
+	// Arrange: slow upstream so callers overlap; coalescing enabled
+	client := &slowWeatherClient{delay: 50 * time.Millisecond}
+	svc := NewWeatherService(client, &lockedCache{}, 5*time.Minute, 0, true, time.Second)
+	var hookCalls atomic.Int32
+	svc.AddFetchHook(func(ctx context.Context, key string, data models.WeatherData) {
+		hookCalls.Add(1)
+	})
+
+	// Act: five concurrent requests for the same location
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = svc.GetWeather(context.Background(), "miami")
+		}()
+	}
+	wg.Wait()
+
+	// Assert: one hook call per upstream call
+	if got, want := hookCalls.Load(), client.calls.Load(); got != want {
+		t.Errorf("hook calls = %d, want %d (one per upstream call)", got, want)
+	}
+	if client.calls.Load() != 1 {
+		t.Errorf("upstream calls = %d, want 1 (coalesced)", client.calls.Load())
+	}
+}
+
+// TestWeatherService_GetOfficialAlerts_CachesResult verifies alerts (including an empty result)
+// are cached under the alerts key so repeat requests skip the upstream call.
+func TestWeatherService_GetOfficialAlerts_CachesResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		alerts []models.OfficialAlert
+		want   int
+	}{
+		{name: "alerts in effect", alerts: []models.OfficialAlert{{Sender: "NWS Chicago", Event: "Wind Advisory"}}, want: 1},
+		{name: "no alerts", alerts: nil, want: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockClient := &mockWeatherClient{alerts: tc.alerts}
+			mockCache := &mockCache{}
+			svc := NewWeatherService(mockClient, mockCache, 5*time.Minute, 0, false, 0)
+
+			// Act: miss then hit
+			first, err := svc.GetOfficialAlerts(context.Background(), " Chicago ")
+			if err != nil {
+				t.Fatalf("GetOfficialAlerts() error = %v", err)
+			}
+			second, err := svc.GetOfficialAlerts(context.Background(), "chicago")
+			if err != nil {
+				t.Fatalf("GetOfficialAlerts() cached error = %v", err)
+			}
+
+			// Assert
+			if first == nil || len(first) != tc.want || len(second) != tc.want {
+				t.Errorf("alerts = %v then %v, want %d non-nil", first, second, tc.want)
+			}
+			if mockClient.alertCalls != 1 {
+				t.Errorf("upstream calls = %d, want 1", mockClient.alertCalls)
+			}
+			if _, ok := mockCache.bytes["alerts:chicago"]; !ok {
+				t.Errorf("cache keys = %v, want alerts:chicago", mockCache.bytes)
+			}
+		})
+	}
+}
+
+// TestWeatherService_SetCacheNamespace verifies every cache key gets the namespace prefix, so
+// providers sharing a cache do not read each other's entries.
+func TestWeatherService_SetCacheNamespace(t *testing.T) {
+	// Arrange
+	mockCache := &mockCache{}
+	svc := NewWeatherService(&mockWeatherClient{weather: models.WeatherData{Location: "chicago"}}, mockCache, 5*time.Minute, 0, false, 0)
+	svc.SetCacheNamespace("nws")
+
+	// Act
+	_, weatherErr := svc.GetWeather(context.Background(), "Chicago")
+	_, localizedErr := svc.GetLocalizedWeather(context.Background(), "Chicago", "fr")
+	_, alertsErr := svc.GetOfficialAlerts(context.Background(), "Chicago")
+
+	// Assert
+	if weatherErr != nil || localizedErr != nil || alertsErr != nil {
+		t.Fatalf("errors = %v, %v, %v", weatherErr, localizedErr, alertsErr)
+	}
+	for _, key := range []string{"nws:chicago", "nws:chicago@fr"} {
+		if _, ok := mockCache.data[key]; !ok {
+			t.Errorf("weather cache keys = %v, want %s", mockCache.data, key)
+		}
+	}
+	if _, ok := mockCache.bytes["nws:alerts:chicago"]; !ok || len(mockCache.bytes) != 1 {
+		t.Errorf("byte cache keys = %v, want nws:alerts:chicago only", mockCache.bytes)
+	}
+}
+
+// TestWeatherService_GetOfficialAlerts_UpstreamError verifies upstream failures are returned and
+// not cached.
+func TestWeatherService_GetOfficialAlerts_UpstreamError(t *testing.T) {
+	mockCache := &mockCache{}
+	svc := NewWeatherService(&mockWeatherClient{err: errors.New("upstream down")}, mockCache, 5*time.Minute, 0, false, 0)
+
+	if _, err := svc.GetOfficialAlerts(context.Background(), "chicago"); err == nil {
+		t.Fatal("GetOfficialAlerts() error = nil, want error")
+	}
+	if len(mockCache.bytes) != 0 {
+		t.Errorf("cache = %v, want nothing cached on error", mockCache.bytes)
+	}
+}
+
+// TestWeatherService_GetForecast_CachesAndTrims verifies the full forecast is cached once and each
+// call returns only periods that have not ended and start within the requested hours.
+func TestWeatherService_GetForecast_CachesAndTrims(t *testing.T) {
+	// Arrange: one ended period, one in progress, two upcoming
+	now := time.Now().Truncate(time.Hour)
+	mockClient := &mockWeatherClient{forecast: models.Forecast{Location: "chicago", Periods: []models.ForecastPeriod{
+		{Time: now.Add(-4 * time.Hour)},
+		{Time: now.Add(-time.Hour)},
+		{Time: now.Add(5 * time.Hour)},
+		{Time: now.Add(20 * time.Hour)},
+	}}}
+	mockCache := &mockCache{}
+	svc := NewWeatherService(mockClient, mockCache, 5*time.Minute, 0, true, time.Second)
+
+	// Act
+	short, err := svc.GetForecast(context.Background(), " Chicago ", 12)
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+	long, err := svc.GetForecast(context.Background(), "chicago", 24)
+	if err != nil {
+		t.Fatalf("GetForecast() cached error = %v", err)
+	}
+
+	// Assert
+	if len(short.Periods) != 2 || len(long.Periods) != 3 {
+		t.Errorf("periods = %d (12h) and %d (24h), want 2 and 3", len(short.Periods), len(long.Periods))
+	}
+	if mockClient.forecastCalls != 1 {
+		t.Errorf("upstream calls = %d, want 1", mockClient.forecastCalls)
+	}
+	if _, ok := mockCache.bytes["forecast:chicago"]; !ok {
+		t.Errorf("cache keys = %v, want forecast:chicago", mockCache.bytes)
+	}
+}
+
+// TestWeatherService_GetForecast_StaleFallback verifies an expired cached forecast is served,
+// marked stale, when upstream fails within the stale cache TTL.
+func TestWeatherService_GetForecast_StaleFallback(t *testing.T) {
+	tests := []struct {
+		name      string
+		staleTTL  time.Duration
+		wantStale bool
+	}{
+		{name: "within stale TTL", staleTTL: time.Hour, wantStale: true},
+		{name: "stale cache disabled", staleTTL: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange: cached forecast expired 10 minutes ago, upstream down
+			raw, _ := json.Marshal(forecastEntry{
+				Forecast:  models.Forecast{Location: "chicago", Periods: []models.ForecastPeriod{{Time: time.Now()}}},
+				ExpiresAt: time.Now().Add(-10 * time.Minute),
+			})
+			mockCache := &mockCache{bytes: map[string][]byte{"forecast:chicago": raw}}
+			mockClient := &mockWeatherClient{forecastErr: errors.New("upstream down")}
+			svc := NewWeatherService(mockClient, mockCache, 5*time.Minute, tc.staleTTL, false, 0)
+
+			// Act
+			got, err := svc.GetForecast(context.Background(), "chicago", MaxForecastHours)
+
+			// Assert
+			if !tc.wantStale {
+				if err == nil {
+					t.Errorf("GetForecast() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetForecast() error = %v", err)
+			}
+			if !got.Stale || len(got.Periods) != 1 || mockClient.forecastCalls != 1 {
+				t.Errorf("GetForecast() = %+v after %d upstream calls, want stale forecast after 1", got, mockClient.forecastCalls)
+			}
+		})
+	}
+}
+
+// TestWeatherService_SearchLocations_CachesResult verifies search results, including empty ones,
+// are served from cache on repeat queries.
+func TestWeatherService_SearchLocations_CachesResult(t *testing.T) {
+	// Arrange
+	mockClient := &mockWeatherClient{places: []models.Place{{ID: "39.80,-89.64", Name: "Springfield", Country: "US"}}}
+	svc := NewWeatherService(mockClient, &mockCache{}, 5*time.Minute, 0, false, 0)
+
+	// Act
+	first, err := svc.SearchLocations(context.Background(), " Springfield ")
+	if err != nil {
+		t.Fatalf("SearchLocations() error = %v", err)
+	}
+	second, err := svc.SearchLocations(context.Background(), "springfield")
+	if err != nil {
+		t.Fatalf("SearchLocations() cached error = %v", err)
+	}
+	mockClient.places = nil
+	for i := 0; i < 2; i++ {
+		if places, err := svc.SearchLocations(context.Background(), "nowhere"); err != nil || places == nil || len(places) != 0 {
+			t.Fatalf("SearchLocations(nowhere) = %v, %v; want empty slice", places, err)
+		}
+	}
+
+	// Assert
+	if mockClient.searchCalls != 2 {
+		t.Errorf("upstream calls = %d, want 2", mockClient.searchCalls)
+	}
+	if len(first) != 1 || len(second) != 1 || second[0].ID != "39.80,-89.64" {
+		t.Errorf("results = %+v, %+v", first, second)
+	}
+}
+
+// TestWeatherService_CacheMaxAge verifies max-age is the TTL remaining since fetch, and zero for
+// stale, expired or undated data.
+func TestWeatherService_CacheMaxAge(t *testing.T) {
+	svc := NewWeatherService(&mockWeatherClient{}, &mockCache{}, 5*time.Minute, 0, false, 0)
+	tests := []struct {
+		name    string
+		data    models.WeatherData
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{name: "fetched 2m ago", data: models.WeatherData{FetchedAt: time.Now().Add(-2 * time.Minute)}, wantMin: 179 * time.Second, wantMax: 3 * time.Minute},
+		{name: "expired", data: models.WeatherData{FetchedAt: time.Now().Add(-time.Hour)}},
+		{name: "stale", data: models.WeatherData{FetchedAt: time.Now(), Stale: true}},
+		{name: "no fetch time", data: models.WeatherData{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := svc.CacheMaxAge(tc.data)
+			if got < tc.wantMin || got > tc.wantMax {
+				t.Errorf("CacheMaxAge() = %v, want between %v and %v", got, tc.wantMin, tc.wantMax)
+			}
+		})
+	}
+}
+
+// providerWeatherClient is a mockWeatherClient that names its provider.
+type providerWeatherClient struct {
+	mockWeatherClient
+}
+
+func (m *providerWeatherClient) ProviderName() string { return "test-provider" }
+
+// TestWeatherService_Source verifies the provider name comes from clients that report one.
+func TestWeatherService_Source(t *testing.T) {
+	if got := NewWeatherService(&providerWeatherClient{}, &mockCache{}, time.Minute, 0, false, 0).Source(); got != "test-provider" {
+		t.Errorf("Source() = %q, want test-provider", got)
+	}
+	if got := NewWeatherService(&mockWeatherClient{}, &mockCache{}, time.Minute, 0, false, 0).Source(); got != "" {
+		t.Errorf("Source() = %q, want empty for unnamed client", got)
+	}
+}
+
+// airQualityWeatherClient is a mockWeatherClient that reports air quality.
+type airQualityWeatherClient struct {
+	mockWeatherClient
+	airQuality models.AirQuality
+	aqErr      error
+	aqCalls    int
+}
+
+func (m *airQualityWeatherClient) GetAirQuality(ctx context.Context, location string) (models.AirQuality, error) {
+	m.aqCalls++
+	return m.airQuality, m.aqErr
+}
+
+// TestWeatherService_GetAirQuality_CachesResult verifies air quality is cached under its own key
+// and served from cache on repeat requests.
+func TestWeatherService_GetAirQuality_CachesResult(t *testing.T) {
+	// Arrange
+	mockClient := &airQualityWeatherClient{airQuality: models.AirQuality{Location: "beijing", AQI: 4, Category: "poor"}}
+	mockCache := &mockCache{}
+	svc := NewWeatherService(mockClient, mockCache, 5*time.Minute, 0, false, 0)
+	svc.SetAirQualityTTL(time.Hour)
+
+	// Act
+	first, err := svc.GetAirQuality(context.Background(), " Beijing ")
+	if err != nil {
+		t.Fatalf("GetAirQuality() error = %v", err)
+	}
+	second, err := svc.GetAirQuality(context.Background(), "beijing")
+	if err != nil {
+		t.Fatalf("GetAirQuality() cached error = %v", err)
+	}
+
+	// Assert
+	if mockClient.aqCalls != 1 {
+		t.Errorf("upstream calls = %d, want 1", mockClient.aqCalls)
+	}
+	if first.AQI != 4 || second.AQI != 4 || second.Stale {
+		t.Errorf("results = %+v, %+v", first, second)
+	}
+	if _, ok := mockCache.bytes["airquality:beijing"]; !ok {
+		t.Errorf("cache keys = %v, want airquality:beijing", mockCache.bytes)
+	}
+}
+
+// TestWeatherService_GetAirQuality_StaleFallback verifies an expired cached reading is served,
+// marked stale, when upstream fails within the stale cache TTL.
+func TestWeatherService_GetAirQuality_StaleFallback(t *testing.T) {
+	tests := []struct {
+		name      string
+		staleTTL  time.Duration
+		wantStale bool
+	}{
+		{name: "within stale TTL", staleTTL: time.Hour, wantStale: true},
+		{name: "stale cache disabled", staleTTL: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange: cached reading expired 10 minutes ago, upstream down
+			raw, _ := json.Marshal(airQualityEntry{
+				AirQuality: models.AirQuality{Location: "beijing", AQI: 3},
+				ExpiresAt:  time.Now().Add(-10 * time.Minute),
+			})
+			mockCache := &mockCache{bytes: map[string][]byte{"airquality:beijing": raw}}
+			mockClient := &airQualityWeatherClient{aqErr: errors.New("upstream down")}
+			svc := NewWeatherService(mockClient, mockCache, 5*time.Minute, tc.staleTTL, false, 0)
+
+			// Act
+			got, err := svc.GetAirQuality(context.Background(), "beijing")
+
+			// Assert
+			if !tc.wantStale {
+				if err == nil {
+					t.Errorf("GetAirQuality() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetAirQuality() error = %v", err)
+			}
+			if !got.Stale || got.AQI != 3 {
+				t.Errorf("GetAirQuality() = %+v, want stale AQI 3", got)
+			}
+		})
+	}
+}
+
+// TestWeatherService_GetAirQuality_Unsupported verifies clients without air quality return
+// client.ErrAirQualityUnsupported.
+func TestWeatherService_GetAirQuality_Unsupported(t *testing.T) {
+	svc := NewWeatherService(&mockWeatherClient{}, &mockCache{}, time.Minute, 0, false, 0)
+
+	_, err := svc.GetAirQuality(context.Background(), "beijing")
+
+	if !errors.Is(err, client.ErrAirQualityUnsupported) {
+		t.Errorf("GetAirQuality() error = %v, want ErrAirQualityUnsupported", err)
+	}
+}
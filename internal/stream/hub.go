@@ -0,0 +1,243 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+// ErrTooManyStreams is returned by Subscribe when the concurrent stream cap is reached.
+var ErrTooManyStreams = errors.New("too many concurrent streams")
+
+// ErrClosed is returned by Subscribe after Close.
+var ErrClosed = errors.New("stream hub closed")
+
+// Event is one weather update published to streams. IDs increase monotonically across all
+// locations for the life of the process and are used for Last-Event-ID resume.
+type Event struct {
+	ID       uint64
+	Location string
+	Data     models.WeatherData
+}
+
+// Config configures a Hub.
+type Config struct {
+	MaxStreams    int           // concurrent subscribers across all locations (0 = unlimited)
+	ReplayBuffer  int           // events retained per location for Last-Event-ID resume
+	SubscriberBuf int           // per-subscriber channel capacity; a full channel drops the subscriber
+	IdleRetention time.Duration // how long a location's replay buffer outlives its last subscriber
+}
+
+// Hub fans out fresh weather fetches to SSE subscribers by normalized location.
+// Only locations with (or recently with) subscribers are buffered, so arbitrary
+// request traffic does not grow memory.
+type Hub struct {
+	cfg Config
+
+	mu     sync.Mutex
+	topics map[string]*topic
+	seq    uint64
+	active int
+	closed bool
+}
+
+type topic struct {
+	buffer    []Event // oldest first, at most cfg.ReplayBuffer
+	evicted   uint64  // ID of the newest event dropped from buffer
+	subs      map[*Subscriber]struct{}
+	idleSince time.Time // zero while subscribers are attached
+}
+
+// Subscriber receives events for one location. C is closed when the subscriber falls too far
+// behind, the hub closes, or Close is called; clients should reconnect with Last-Event-ID.
+type Subscriber struct {
+	C   <-chan Event
+	c   chan Event
+	key string
+	hub *Hub
+}
+
+// NewHub creates a Hub, applying defaults for zero config values.
+func NewHub(cfg Config) *Hub {
+	if cfg.ReplayBuffer <= 0 {
+		cfg.ReplayBuffer = 50
+	}
+	if cfg.SubscriberBuf <= 0 {
+		cfg.SubscriberBuf = 16
+	}
+	if cfg.IdleRetention <= 0 {
+		cfg.IdleRetention = 10 * time.Minute
+	}
+	return &Hub{cfg: cfg, topics: make(map[string]*topic)}
+}
+
+// Publish records data for key and delivers it to the location's subscribers. It matches
+// service.FetchHook and is registered with WeatherService.AddFetchHook, so it runs once per
+// upstream response that repopulated the cache. Never blocks.
+func (h *Hub) Publish(ctx context.Context, key string, data models.WeatherData) {
+	key = validation.NormalizeLocation(key)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pruneLocked(time.Now())
+	t, ok := h.topics[key]
+	if !ok || h.closed {
+		return
+	}
+	h.seq++
+	ev := Event{ID: h.seq, Location: key, Data: data}
+	if len(t.buffer) >= h.cfg.ReplayBuffer {
+		t.evicted = t.buffer[0].ID
+		t.buffer = t.buffer[1:]
+	}
+	t.buffer = append(t.buffer, ev)
+	for sub := range t.subs {
+		select {
+		case sub.c <- ev:
+		default:
+			// Slow consumer: drop it rather than block the fetch path. The client resumes
+			// from the replay buffer on reconnect.
+			h.removeLocked(t, sub)
+		}
+	}
+}
+
+// Subscribe attaches a subscriber to location and returns buffered events with ID greater
+// than lastEventID. It returns none when lastEventID is 0 or the buffer no longer covers every
+// event after it (evicted, or lost with a restart or an idle location's topic); the caller
+// then starts over with a snapshot.
+func (h *Hub) Subscribe(location string, lastEventID uint64) (*Subscriber, []Event, error) {
+	key := validation.NormalizeLocation(location)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, nil, ErrClosed
+	}
+	if h.cfg.MaxStreams > 0 && h.active >= h.cfg.MaxStreams {
+		observability.StreamsRejectedTotal.Inc()
+		return nil, nil, ErrTooManyStreams
+	}
+	h.pruneLocked(time.Now())
+	t, ok := h.topics[key]
+	if !ok {
+		t = &topic{subs: make(map[*Subscriber]struct{})}
+		h.topics[key] = t
+	}
+	c := make(chan Event, h.cfg.SubscriberBuf)
+	sub := &Subscriber{C: c, c: c, key: key, hub: h}
+	t.subs[sub] = struct{}{}
+	t.idleSince = time.Time{}
+	h.active++
+	observability.StreamsActive.Set(float64(h.active))
+
+	var replay []Event
+	if lastEventID > 0 && lastEventID >= t.evicted {
+		for _, ev := range t.buffer {
+			if ev.ID > lastEventID {
+				replay = append(replay, ev)
+			}
+		}
+	}
+	return sub, replay, nil
+}
+
+// Close detaches the subscriber. Safe to call more than once and after the hub dropped it.
+func (s *Subscriber) Close() {
+	h := s.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if t, ok := h.topics[s.key]; ok {
+		h.removeLocked(t, s)
+	}
+}
+
+// Locations returns the normalized locations that currently have subscribers.
+func (h *Hub) Locations() []string {
+	h.mu.Lock()
+	out := make([]string, 0, len(h.topics))
+	for key, t := range h.topics {
+		if len(t.subs) > 0 {
+			out = append(out, key)
+		}
+	}
+	h.mu.Unlock()
+	sort.Strings(out)
+	return out
+}
+
+// Active returns the number of attached subscribers.
+func (h *Hub) Active() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.active
+}
+
+// Close detaches all subscribers (closing their channels) and rejects new ones.
+// Called at shutdown so open streams end before the server drains.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for _, t := range h.topics {
+		for sub := range t.subs {
+			h.removeLocked(t, sub)
+		}
+	}
+}
+
+// WeatherFetcher is implemented by the service layer.
+type WeatherFetcher interface {
+	GetWeather(ctx context.Context, location string) (models.WeatherData, error)
+}
+
+// RunRefresh calls fetcher.GetWeather for every streamed location at interval until ctx is
+// done. Cache hits are no-ops; once the cached entry expires the fetch repopulates it and
+// Publish fires, so streams keep receiving updates without any polling clients.
+func (h *Hub) RunRefresh(ctx context.Context, fetcher WeatherFetcher, interval, timeout time.Duration, logger *zap.Logger) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			for _, loc := range h.Locations() {
+				fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+				if _, err := fetcher.GetWeather(fetchCtx, loc); err != nil && logger != nil {
+					logger.Warn("stream refresh failed", zap.String("location", loc), zap.Error(err))
+				}
+				cancel()
+			}
+		}
+	}
+}
+
+// removeLocked detaches sub from t and closes its channel if it was still attached.
+func (h *Hub) removeLocked(t *topic, sub *Subscriber) {
+	if _, ok := t.subs[sub]; !ok {
+		return
+	}
+	delete(t.subs, sub)
+	close(sub.c)
+	h.active--
+	observability.StreamsActive.Set(float64(h.active))
+	if len(t.subs) == 0 {
+		t.idleSince = time.Now()
+	}
+}
+
+// pruneLocked drops topics whose last subscriber left more than IdleRetention ago.
+func (h *Hub) pruneLocked(now time.Time) {
+	for key, t := range h.topics {
+		if len(t.subs) == 0 && !t.idleSince.IsZero() && now.Sub(t.idleSince) > h.cfg.IdleRetention {
+			delete(h.topics, key)
+		}
+	}
+}
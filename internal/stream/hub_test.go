@@ -0,0 +1,170 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// TestHub_PublishDelivers verifies subscribers receive events for their normalized location only.
+func TestHub_PublishDelivers(t *testing.T) {
+	// Arrange
+	hub := NewHub(Config{})
+	sub, _, err := hub.Subscribe("Chicago", 0)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer sub.Close()
+
+	// Act
+	hub.Publish(context.Background(), "boston", models.WeatherData{Temperature: 1})
+	hub.Publish(context.Background(), "chicago", models.WeatherData{Temperature: 2})
+
+	// Assert
+	select {
+	case ev := <-sub.C:
+		if ev.Location != "chicago" || ev.Data.Temperature != 2 || ev.ID == 0 {
+			t.Errorf("event = %+v, want chicago temperature 2 with ID", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+	}
+	select {
+	case ev := <-sub.C:
+		t.Errorf("unexpected extra event %+v", ev)
+	default:
+	}
+}
+
+// TestHub_Subscribe_Replay verifies Last-Event-ID replay returns only newer buffered events,
+// that the buffer is bounded, and that nothing is replayed across an eviction gap.
+func TestHub_Subscribe_Replay(t *testing.T) {
+	hub := NewHub(Config{ReplayBuffer: 3})
+	first, _, _ := hub.Subscribe("paris", 0)
+	for i := 1; i <= 5; i++ {
+		hub.Publish(context.Background(), "paris", models.WeatherData{Temperature: float64(i)})
+	}
+	first.Close()
+
+	_, replay, err := hub.Subscribe("paris", 3)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if len(replay) != 2 || replay[0].ID != 4 || replay[1].ID != 5 {
+		t.Errorf("replay after 3 = %+v, want IDs 4 and 5", replay)
+	}
+	_, replay, _ = hub.Subscribe("paris", 2)
+	if len(replay) != 3 || replay[0].ID != 3 {
+		t.Errorf("replay after 2 = %+v, want the 3 buffered events starting at ID 3", replay)
+	}
+	// Event 2 was evicted, so a client that last saw 1 cannot resume
+	if _, replay, _ = hub.Subscribe("paris", 1); replay != nil {
+		t.Errorf("replay after 1 = %+v, want none (gap)", replay)
+	}
+	if _, replay, _ = hub.Subscribe("paris", 5); replay != nil {
+		t.Errorf("replay after 5 = %+v, want none (up to date)", replay)
+	}
+}
+
+// TestHub_Subscribe_Cap verifies the concurrent stream cap and that closing frees a slot.
+func TestHub_Subscribe_Cap(t *testing.T) {
+	hub := NewHub(Config{MaxStreams: 1})
+	sub, _, err := hub.Subscribe("a", 0)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if _, _, err := hub.Subscribe("b", 0); !errors.Is(err, ErrTooManyStreams) {
+		t.Fatalf("second Subscribe() error = %v, want ErrTooManyStreams", err)
+	}
+	sub.Close()
+	sub.Close() // idempotent
+	if _, _, err := hub.Subscribe("b", 0); err != nil {
+		t.Errorf("Subscribe() after Close error = %v", err)
+	}
+	if got := hub.Active(); got != 1 {
+		t.Errorf("Active() = %d, want 1", got)
+	}
+}
+
+// TestHub_Publish_DropsSlowSubscriber verifies a subscriber whose channel is full is closed
+// instead of blocking Publish.
+func TestHub_Publish_DropsSlowSubscriber(t *testing.T) {
+	hub := NewHub(Config{SubscriberBuf: 1})
+	sub, _, _ := hub.Subscribe("oslo", 0)
+
+	hub.Publish(context.Background(), "oslo", models.WeatherData{})
+	hub.Publish(context.Background(), "oslo", models.WeatherData{})
+
+	<-sub.C
+	if _, ok := <-sub.C; ok {
+		t.Fatal("channel still open, want closed after overflow")
+	}
+	if got := hub.Active(); got != 0 {
+		t.Errorf("Active() = %d, want 0 after drop", got)
+	}
+}
+
+// TestHub_Publish_IgnoresUnsubscribedLocations verifies no buffer is kept for locations
+// nobody streams.
+func TestHub_Publish_IgnoresUnsubscribedLocations(t *testing.T) {
+	hub := NewHub(Config{})
+	hub.Publish(context.Background(), "nowhere", models.WeatherData{})
+
+	_, replay, _ := hub.Subscribe("nowhere", 0)
+	if len(replay) != 0 || len(hub.topics["nowhere"].buffer) != 0 {
+		t.Errorf("buffer = %d events, want 0", len(hub.topics["nowhere"].buffer))
+	}
+}
+
+// TestHub_Close verifies Close ends all subscriptions and rejects new ones.
+func TestHub_Close(t *testing.T) {
+	hub := NewHub(Config{})
+	sub, _, _ := hub.Subscribe("rome", 0)
+
+	hub.Close()
+
+	if _, ok := <-sub.C; ok {
+		t.Error("subscriber channel open after Close")
+	}
+	if _, _, err := hub.Subscribe("rome", 0); !errors.Is(err, ErrClosed) {
+		t.Errorf("Subscribe() after Close error = %v, want ErrClosed", err)
+	}
+}
+
+// countingFetcher counts GetWeather calls per location.
+type countingFetcher struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (f *countingFetcher) GetWeather(ctx context.Context, location string) (models.WeatherData, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[location]++
+	return models.WeatherData{}, nil
+}
+
+// TestHub_RunRefresh verifies streamed locations are fetched once per tick regardless of
+// subscriber count.
+func TestHub_RunRefresh(t *testing.T) {
+	hub := NewHub(Config{})
+	a, _, _ := hub.Subscribe("tokyo", 0)
+	b, _, _ := hub.Subscribe("Tokyo", 0)
+	defer a.Close()
+	defer b.Close()
+	fetcher := &countingFetcher{calls: map[string]int{}}
+	ctx, cancel := context.WithTimeout(context.Background(), 35*time.Millisecond)
+	defer cancel()
+
+	_ = hub.RunRefresh(ctx, fetcher, 10*time.Millisecond, time.Second, nil)
+
+	fetcher.mu.Lock()
+	defer fetcher.mu.Unlock()
+	if n := fetcher.calls["tokyo"]; n < 2 || n > 4 || len(fetcher.calls) != 1 {
+		t.Errorf("calls = %v, want 2-4 calls for tokyo only", fetcher.calls)
+	}
+}
@@ -0,0 +1,248 @@
+package subscriptions
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/observability"
+)
+
+// Webhook request headers. Receivers verify SignatureHeader by computing
+// HMAC-SHA256(secret, TimestampHeader + "." + body) with their subscription's secret and
+// comparing hex digests.
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	EventHeader     = "X-Webhook-Event"
+)
+
+// DeliveryConfig configures webhook delivery.
+type DeliveryConfig struct {
+	Timeout        time.Duration  // per-attempt HTTP timeout
+	MaxAttempts    int            // total attempts including the first
+	BaseDelay      time.Duration  // backoff before the second attempt; doubles per attempt
+	MaxDelay       time.Duration  // backoff cap
+	Workers        int            // concurrent deliveries
+	QueueSize      int            // pending events; overflow goes to the dead-letter list
+	DeadLetterSize int            // retained dead letters; oldest dropped first
+	Policy         *AddressPolicy // outbound destination policy
+}
+
+// DeadLetter is an event that could not be delivered after all attempts.
+type DeadLetter struct {
+	Event    Event     `json:"event"`
+	URL      string    `json:"webhookUrl"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failedAt"`
+}
+
+// Dispatcher delivers events to subscriber webhooks from a bounded queue.
+type Dispatcher struct {
+	cfg    DeliveryConfig
+	client *http.Client
+	queue  chan Event
+	logger *zap.Logger
+
+	mu          sync.Mutex
+	deadLetters []DeadLetter
+}
+
+// NewDispatcher creates a Dispatcher. Outbound connections go through cfg.Policy, and
+// redirects are not followed so a receiver cannot bounce deliveries to another host.
+func NewDispatcher(cfg DeliveryConfig, logger *zap.Logger) *Dispatcher {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+	if cfg.QueueSize < 1 {
+		cfg.QueueSize = 100
+	}
+	if cfg.DeadLetterSize < 1 {
+		cfg.DeadLetterSize = 100
+	}
+	if cfg.Policy == nil {
+		cfg.Policy, _ = NewAddressPolicy(nil)
+	}
+	transport := &http.Transport{
+		DialContext:         cfg.Policy.DialContext(&net.Dialer{Timeout: cfg.Timeout}),
+		TLSHandshakeTimeout: cfg.Timeout,
+		MaxIdleConns:        cfg.Workers,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	return &Dispatcher{
+		cfg: cfg,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   cfg.Timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		queue:  make(chan Event, cfg.QueueSize),
+		logger: logger,
+	}
+}
+
+// Enqueue queues ev for delivery without blocking. When the queue is full the event is
+// dead-lettered immediately.
+func (d *Dispatcher) Enqueue(ev Event) {
+	select {
+	case d.queue <- ev:
+	default:
+		d.deadLetter(ev, 0, errors.New("delivery queue full"))
+	}
+}
+
+// Run starts the delivery workers and blocks until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < d.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case ev := <-d.queue:
+					d.deliver(ctx, ev)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// DeadLetters returns a copy of the retained dead letters, oldest first.
+func (d *Dispatcher) DeadLetters() []DeadLetter {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]DeadLetter(nil), d.deadLetters...)
+}
+
+// deliver posts ev until it succeeds, fails permanently, or attempts are exhausted.
+func (d *Dispatcher) deliver(ctx context.Context, ev Event) {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		d.deadLetter(ev, 0, fmt.Errorf("encode: %w", err))
+		return
+	}
+	var lastErr error
+	for attempt := 1; attempt <= d.cfg.MaxAttempts; attempt++ {
+		retryable, err := d.post(ctx, ev, body)
+		if err == nil {
+			observability.WebhookDeliveriesTotal.WithLabelValues("success").Inc()
+			return
+		}
+		lastErr = err
+		if !retryable || attempt == d.cfg.MaxAttempts {
+			d.deadLetter(ev, attempt, lastErr)
+			return
+		}
+		observability.WebhookDeliveriesTotal.WithLabelValues("retry").Inc()
+		select {
+		case <-ctx.Done():
+			d.deadLetter(ev, attempt, ctx.Err())
+			return
+		case <-time.After(d.backoff(attempt)):
+		}
+	}
+}
+
+// post performs a single delivery attempt, signed with the subscription's secret. Network errors, 429 and 5xx are retryable;
+// other non-2xx responses and refused destinations are not.
+func (d *Dispatcher) post(ctx context.Context, ev Event, body []byte) (retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ev.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "weather-alert-service")
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(ev.secret, ts, body))
+	req.Header.Set(DeliveryHeader, ev.DeliveryID)
+	req.Header.Set(EventHeader, string(ev.Type))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return !errors.Is(err, ErrInvalidWebhookURL), err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook returned %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+}
+
+// backoff returns the delay before the attempt after attempt: exponential from BaseDelay,
+// capped at MaxDelay, with jitter drawn from the upper half of the window so concurrent
+// retries to one receiver spread out.
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := float64(d.cfg.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if d.cfg.MaxDelay > 0 && delay > float64(d.cfg.MaxDelay) {
+		delay = float64(d.cfg.MaxDelay)
+	}
+	return time.Duration(delay/2 + delay/2*rand.Float64())
+}
+
+func (d *Dispatcher) deadLetter(ev Event, attempts int, err error) {
+	observability.WebhookDeliveriesTotal.WithLabelValues("dead_letter").Inc()
+	if d.logger != nil {
+		d.logger.Warn("webhook delivery failed",
+			zap.String("subscription_id", ev.SubscriptionID),
+			zap.String("delivery_id", ev.DeliveryID),
+			zap.Int("attempts", attempts),
+			zap.Error(err),
+		)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.deadLetters) >= d.cfg.DeadLetterSize {
+		d.deadLetters = d.deadLetters[1:]
+	}
+	d.deadLetters = append(d.deadLetters, DeadLetter{
+		Event:    ev,
+		URL:      ev.webhookURL,
+		Attempts: attempts,
+		Error:    err.Error(),
+		FailedAt: time.Now().UTC(),
+	})
+}
+
+// Sign returns the hex HMAC-SHA256 of timestamp + "." + body under secret.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,173 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// loopbackPolicy allows httptest servers, which listen on 127.0.0.1.
+func loopbackPolicy(t *testing.T) *AddressPolicy {
+	t.Helper()
+	p, err := NewAddressPolicy([]string{"127.0.0.1"})
+	if err != nil {
+		t.Fatalf("NewAddressPolicy() error = %v", err)
+	}
+	return p
+}
+
+func newTestDispatcher(t *testing.T, maxAttempts int, policy *AddressPolicy) *Dispatcher {
+	t.Helper()
+	return NewDispatcher(DeliveryConfig{
+		Timeout:     time.Second,
+		MaxAttempts: maxAttempts,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+		Policy:      policy,
+	}, nil)
+}
+
+// TestDispatcher_Deliver_SignedPayload verifies delivery headers, the HMAC signature under the
+// subscription's secret, and the body.
+func TestDispatcher_Deliver_SignedPayload(t *testing.T) {
+	// Arrange: receiver that records the request
+	var gotSig, gotTS, gotEvent string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSig = r.Header.Get(SignatureHeader)
+		gotTS = r.Header.Get(TimestampHeader)
+		gotEvent = r.Header.Get(EventHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	d := newTestDispatcher(t, 1, loopbackPolicy(t))
+	ev := Event{DeliveryID: "d-1", Type: EventCrossed, SubscriptionID: "s-1", Location: "chicago", webhookURL: srv.URL, secret: "s3cret"}
+
+	// Act
+	d.deliver(context.Background(), ev)
+
+	// Assert
+	if want := "sha256=" + Sign("s3cret", gotTS, gotBody); gotSig != want {
+		t.Errorf("signature = %q, want %q", gotSig, want)
+	}
+	if gotEvent != string(EventCrossed) {
+		t.Errorf("event header = %q, want %q", gotEvent, EventCrossed)
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(gotBody, &payload); err != nil || payload["subscriptionId"] != "s-1" || payload["event"] != "threshold.crossed" {
+		t.Errorf("payload = %s (err %v), want subscriptionId and event", gotBody, err)
+	}
+	if len(d.DeadLetters()) != 0 {
+		t.Errorf("DeadLetters() = %d, want 0", len(d.DeadLetters()))
+	}
+}
+
+// TestDispatcher_Deliver_RetriesThenSucceeds verifies 5xx responses are retried.
+func TestDispatcher_Deliver_RetriesThenSucceeds(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	d := newTestDispatcher(t, 4, loopbackPolicy(t))
+
+	d.deliver(context.Background(), Event{DeliveryID: "d-1", webhookURL: srv.URL})
+
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("calls = %d, want 3", got)
+	}
+	if len(d.DeadLetters()) != 0 {
+		t.Errorf("DeadLetters() = %d, want 0 after eventual success", len(d.DeadLetters()))
+	}
+}
+
+// TestDispatcher_Deliver_DeadLetters verifies exhausted retries and non-retryable responses
+// land in the dead-letter list with the attempt count.
+func TestDispatcher_Deliver_DeadLetters(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantAttempts int
+	}{
+		{name: "retries exhausted", status: http.StatusServiceUnavailable, wantAttempts: 3},
+		{name: "client error not retried", status: http.StatusGone, wantAttempts: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+			d := newTestDispatcher(t, 3, loopbackPolicy(t))
+
+			d.deliver(context.Background(), Event{DeliveryID: "d-1", webhookURL: srv.URL})
+
+			letters := d.DeadLetters()
+			if len(letters) != 1 || letters[0].Attempts != tc.wantAttempts || int(calls) != tc.wantAttempts {
+				t.Fatalf("DeadLetters() = %+v, calls = %d, want one letter after %d attempts", letters, calls, tc.wantAttempts)
+			}
+			if letters[0].URL != srv.URL {
+				t.Errorf("DeadLetter.URL = %q, want %q", letters[0].URL, srv.URL)
+			}
+		})
+	}
+}
+
+// TestDispatcher_Deliver_RefusedDestination verifies loopback receivers are refused at dial
+// time without the allow-list and are not retried.
+func TestDispatcher_Deliver_RefusedDestination(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer srv.Close()
+	d := newTestDispatcher(t, 3, nil)
+
+	d.deliver(context.Background(), Event{DeliveryID: "d-1", webhookURL: srv.URL})
+
+	letters := d.DeadLetters()
+	if calls != 0 || len(letters) != 1 || letters[0].Attempts != 1 {
+		t.Errorf("calls = %d, DeadLetters() = %+v, want refused on first attempt", calls, letters)
+	}
+}
+
+// TestDispatcher_DeadLetters_Bounded verifies the oldest dead letters are dropped at capacity.
+func TestDispatcher_DeadLetters_Bounded(t *testing.T) {
+	d := NewDispatcher(DeliveryConfig{QueueSize: 1, DeadLetterSize: 2}, nil)
+
+	for _, id := range []string{"a", "b", "c", "d"} {
+		d.Enqueue(Event{DeliveryID: id})
+	}
+
+	letters := d.DeadLetters()
+	if len(letters) != 2 || letters[0].Event.DeliveryID != "c" || letters[1].Event.DeliveryID != "d" {
+		t.Errorf("DeadLetters() = %+v, want the two most recent overflow events (c, d)", letters)
+	}
+}
+
+// TestDispatcher_Backoff_Bounds verifies backoff stays within the jitter window and cap.
+func TestDispatcher_Backoff_Bounds(t *testing.T) {
+	d := NewDispatcher(DeliveryConfig{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}, nil)
+
+	for i := 0; i < 50; i++ {
+		if got := d.backoff(1); got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("backoff(1) = %v, want within [50ms, 100ms]", got)
+		}
+		if got := d.backoff(5); got < 150*time.Millisecond || got > 300*time.Millisecond {
+			t.Fatalf("backoff(5) = %v, want within [150ms, 300ms] (capped)", got)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidWebhookURL is returned when a webhook URL is malformed or targets a refused address.
+var ErrInvalidWebhookURL = errors.New("invalid webhook url")
+
+// AddressPolicy decides which webhook destinations may be contacted. Private, loopback,
+// link-local and unspecified addresses are refused unless the host or address appears in
+// the allow-list. The check runs at dial time against the resolved IP, so DNS answers that
+// change after subscription creation cannot redirect deliveries to internal addresses.
+type AddressPolicy struct {
+	hosts    map[string]struct{}
+	prefixes []netip.Prefix
+}
+
+// NewAddressPolicy builds a policy from allow-list entries. Each entry is a hostname,
+// an IP address, or a CIDR prefix.
+func NewAddressPolicy(allowed []string) (*AddressPolicy, error) {
+	p := &AddressPolicy{hosts: make(map[string]struct{})}
+	for _, entry := range allowed {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			p.prefixes = append(p.prefixes, prefix.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			p.prefixes = append(p.prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		if strings.ContainsAny(entry, "/:") {
+			return nil, fmt.Errorf("allowed host %q: not a hostname, IP, or CIDR", entry)
+		}
+		p.hosts[entry] = struct{}{}
+	}
+	return p, nil
+}
+
+// ValidateURL checks that raw is an absolute http(s) URL whose host is not a refused
+// literal address or localhost name. Hostnames are resolved and checked again at dial time.
+func (p *AddressPolicy) ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhookURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme must be http or https", ErrInvalidWebhookURL)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return fmt.Errorf("%w: host is required", ErrInvalidWebhookURL)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: credentials in URL are not allowed", ErrInvalidWebhookURL)
+	}
+	if p.hostAllowed(host) {
+		return nil
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !p.addrAllowed(addr) {
+			return fmt.Errorf("%w: destination %s is not allowed", ErrInvalidWebhookURL, host)
+		}
+		return nil
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: destination %s is not allowed", ErrInvalidWebhookURL, host)
+	}
+	return nil
+}
+
+// DialContext resolves addr and connects to the first permitted IP. Allow-listed hostnames
+// skip the address check.
+func (p *AddressPolicy) DialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if p.hostAllowed(strings.ToLower(host)) {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return nil, err
+		}
+		var lastErr error
+		for _, ip := range ips {
+			if !p.addrAllowed(ip) {
+				lastErr = fmt.Errorf("%w: destination %s resolves to refused address %s", ErrInvalidWebhookURL, host, ip)
+				continue
+			}
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no addresses for %s", host)
+		}
+		return nil, lastErr
+	}
+}
+
+func (p *AddressPolicy) hostAllowed(host string) bool {
+	if p == nil {
+		return false
+	}
+	_, ok := p.hosts[host]
+	return ok
+}
+
+// addrAllowed reports whether ip is public or explicitly allow-listed.
+func (p *AddressPolicy) addrAllowed(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if p != nil {
+		for _, prefix := range p.prefixes {
+			if prefix.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast() || isCGNAT(ip))
+}
+
+// isCGNAT reports whether ip is in 100.64.0.0/10 (carrier-grade NAT), which netip does not
+// classify as private.
+func isCGNAT(ip netip.Addr) bool {
+	return cgnat.Contains(ip)
+}
+
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
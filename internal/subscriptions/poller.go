@@ -0,0 +1,76 @@
+package subscriptions
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// WeatherFetcher is implemented by the service layer. Polling goes through it so
+// subscription checks share the cache and request coalescing with API traffic.
+type WeatherFetcher interface {
+	GetWeather(ctx context.Context, location string) (models.WeatherData, error)
+}
+
+// Poller periodically fetches weather for subscribed locations and hands threshold
+// changes to the dispatcher.
+type Poller struct {
+	store      *Store
+	fetcher    WeatherFetcher
+	dispatcher *Dispatcher
+	timeout    time.Duration
+	logger     *zap.Logger
+}
+
+// NewPoller creates a Poller. timeout bounds each location fetch (0 = no per-fetch bound).
+func NewPoller(store *Store, fetcher WeatherFetcher, dispatcher *Dispatcher, timeout time.Duration, logger *zap.Logger) *Poller {
+	return &Poller{store: store, fetcher: fetcher, dispatcher: dispatcher, timeout: timeout, logger: logger}
+}
+
+// Poll fetches each subscribed location once, concurrently, and enqueues resulting events.
+// Locations that fail to fetch are skipped until the next poll; their state is unchanged.
+func (p *Poller) Poll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, loc := range p.store.Locations() {
+		loc := loc
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fetchCtx := ctx
+			if p.timeout > 0 {
+				var cancel context.CancelFunc
+				fetchCtx, cancel = context.WithTimeout(ctx, p.timeout)
+				defer cancel()
+			}
+			data, err := p.fetcher.GetWeather(fetchCtx, loc)
+			if err != nil {
+				if p.logger != nil {
+					p.logger.Warn("subscription poll failed", zap.String("location", loc), zap.Error(err))
+				}
+				return
+			}
+			for _, ev := range p.store.Evaluate(loc, data) {
+				p.dispatcher.Enqueue(ev)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Run polls at the given interval until ctx is done.
+func (p *Poller) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			p.Poll(ctx)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package subscriptions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// countingFetcher returns fixed weather and counts calls per location.
+type countingFetcher struct {
+	mu    sync.Mutex
+	calls map[string]int
+	data  models.WeatherData
+}
+
+func (f *countingFetcher) GetWeather(ctx context.Context, location string) (models.WeatherData, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[location]++
+	return f.data, nil
+}
+
+// TestPoller_Poll_DeduplicatesAndDelivers verifies that subscriptions sharing a location cause
+// one fetch, and that each subscription's crossing is delivered to its webhook.
+func TestPoller_Poll_DeduplicatesAndDelivers(t *testing.T) {
+	// Arrange: two subscriptions for chicago, one webhook receiver
+	var received int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&received, 1)
+	}))
+	defer srv.Close()
+	policy := loopbackPolicy(t)
+	store := NewStore(0, policy)
+	rules := []Rule{{Name: "wind", Type: "wind_above", Threshold: 10}}
+	for _, loc := range []string{"Chicago", "chicago"} {
+		if _, err := store.Create(loc, srv.URL, rules); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	fetcher := &countingFetcher{calls: map[string]int{}, data: models.WeatherData{WindSpeed: 20}}
+	dispatcher := newTestDispatcher(t, 1, policy)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dispatcher.Run(ctx)
+	poller := NewPoller(store, fetcher, dispatcher, time.Second, nil)
+
+	// Act
+	poller.Poll(ctx)
+
+	// Assert: one fetch, two deliveries
+	if fetcher.calls["chicago"] != 1 || len(fetcher.calls) != 1 {
+		t.Errorf("fetch calls = %v, want one call for chicago", fetcher.calls)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&received) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&received); got != 2 {
+		t.Errorf("deliveries = %d, want 2", got)
+	}
+}
@@ -0,0 +1,297 @@
+package subscriptions
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kjstillabower/weather-alert-service/internal/alerts"
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+var (
+	// ErrNotFound is returned when a subscription ID does not exist.
+	// Also returned when the management token does not match, so IDs cannot be probed.
+	ErrNotFound = errors.New("subscription not found")
+	// ErrLimitReached is returned when creating a subscription would exceed the configured maximum.
+	ErrLimitReached = errors.New("subscription limit reached")
+	// ErrNoThresholds is returned when a subscription has no threshold rules.
+	ErrNoThresholds = errors.New("at least one threshold is required")
+)
+
+// EventType distinguishes threshold crossings from clears in webhook payloads.
+type EventType string
+
+// Webhook event types.
+const (
+	EventCrossed EventType = "threshold.crossed"
+	EventCleared EventType = "threshold.cleared"
+)
+
+// Subscription is a webhook registration for threshold changes at one location.
+type Subscription struct {
+	ID         string    `json:"id"`
+	Location   string    `json:"location"`
+	WebhookURL string    `json:"webhookUrl"`
+	Thresholds []Rule    `json:"thresholds"`
+	CreatedAt  time.Time `json:"createdAt"`
+	// Token authorizes reading and deleting the subscription. It is only set on the value
+	// returned by Create; the store keeps its hash.
+	Token string `json:"token,omitempty"`
+	// Secret is the HMAC key that signs this subscription's webhook deliveries. Like Token it
+	// is only set on the value returned by Create.
+	Secret string `json:"secret,omitempty"`
+
+	secret    string
+	tokenHash [sha256.Size]byte
+	key       string          // normalized location; poll deduplication key
+	rules     []alerts.Rule   // compiled thresholds, same order as Thresholds
+	state     map[string]bool // rule name -> currently crossed
+}
+
+// Rule is the client-facing threshold definition. Fields mirror alerts.RuleConfig.
+type Rule struct {
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+	Severity  string  `json:"severity,omitempty"`
+	Threshold float64 `json:"threshold"`
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	Pattern   string  `json:"pattern,omitempty"`
+}
+
+// Event is a threshold crossing or clear for one subscription, ready for delivery.
+type Event struct {
+	DeliveryID     string             `json:"deliveryId"`
+	Type           EventType          `json:"event"`
+	SubscriptionID string             `json:"subscriptionId"`
+	Location       string             `json:"location"`
+	Rule           Rule               `json:"rule"`
+	Value          string             `json:"value"`
+	Message        string             `json:"message"`
+	Weather        models.WeatherData `json:"weather"`
+	Timestamp      time.Time          `json:"timestamp"`
+
+	webhookURL string
+	secret     string // signing secret of the subscription
+}
+
+// Store holds subscriptions in memory and tracks per-rule crossed state.
+// Subscriptions do not survive restarts.
+type Store struct {
+	mu     sync.RWMutex
+	subs   map[string]*Subscription
+	max    int
+	policy *AddressPolicy
+}
+
+// NewStore creates a Store that holds at most max subscriptions (0 = unlimited). policy is
+// used to reject private or loopback webhook URLs at creation; nil refuses all of them.
+func NewStore(max int, policy *AddressPolicy) *Store {
+	return &Store{subs: make(map[string]*Subscription), max: max, policy: policy}
+}
+
+// Create validates the thresholds and webhook URL and registers a subscription for location.
+// location must already be validated (validation.ValidateLocation). The returned subscription
+// carries the management token required by Get and Delete and the webhook signing secret;
+// neither can be retrieved again.
+func (s *Store) Create(location, webhookURL string, thresholds []Rule) (Subscription, error) {
+	if len(thresholds) == 0 {
+		return Subscription{}, ErrNoThresholds
+	}
+	if err := s.policy.ValidateURL(webhookURL); err != nil {
+		return Subscription{}, err
+	}
+	rules := make([]alerts.Rule, 0, len(thresholds))
+	seen := make(map[string]struct{}, len(thresholds))
+	for i, t := range thresholds {
+		r, err := alerts.NewRule(alerts.RuleConfig{
+			Name:      t.Name,
+			Type:      t.Type,
+			Severity:  t.Severity,
+			Threshold: t.Threshold,
+			Min:       t.Min,
+			Max:       t.Max,
+			Pattern:   t.Pattern,
+		})
+		if err != nil {
+			return Subscription{}, fmt.Errorf("threshold %d: %w", i, err)
+		}
+		if _, dup := seen[r.Name]; dup {
+			return Subscription{}, fmt.Errorf("threshold %d: duplicate name %q", i, r.Name)
+		}
+		seen[r.Name] = struct{}{}
+		thresholds[i].Name = r.Name
+		thresholds[i].Type = string(r.Type)
+		thresholds[i].Severity = string(r.Severity)
+		rules = append(rules, r)
+	}
+	token, err := newToken()
+	if err != nil {
+		return Subscription{}, err
+	}
+	secret, err := newToken()
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	sub := &Subscription{
+		ID:         uuid.New().String(),
+		Location:   location,
+		WebhookURL: webhookURL,
+		Thresholds: thresholds,
+		CreatedAt:  time.Now().UTC(),
+		secret:     secret,
+		tokenHash:  sha256.Sum256([]byte(token)),
+		key:        validation.NormalizeLocation(location),
+		rules:      rules,
+		state:      make(map[string]bool, len(rules)),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.max > 0 && len(s.subs) >= s.max {
+		return Subscription{}, ErrLimitReached
+	}
+	s.subs[sub.ID] = sub
+	created := sub.snapshot()
+	created.Token = token
+	created.Secret = secret
+	return created, nil
+}
+
+// Get returns the subscription with id, or ErrNotFound when it does not exist or token is not
+// its management token.
+func (s *Store) Get(id, token string) (Subscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sub, ok := s.subs[id]
+	if !ok || !sub.authorized(token) {
+		return Subscription{}, ErrNotFound
+	}
+	return sub.snapshot(), nil
+}
+
+// List returns all subscriptions ordered by creation time. It is for operators; subscribers
+// only see their own subscriptions through Get.
+func (s *Store) List() []Subscription {
+	s.mu.RLock()
+	out := make([]Subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		out = append(out, sub.snapshot())
+	}
+	s.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// Delete removes the subscription with id, or returns ErrNotFound when it does not exist or
+// token is not its management token.
+func (s *Store) Delete(id, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub, ok := s.subs[id]; !ok || !sub.authorized(token) {
+		return ErrNotFound
+	}
+	delete(s.subs, id)
+	return nil
+}
+
+// Locations returns the distinct normalized locations with at least one subscription.
+// The poller fetches each once per cycle regardless of how many subscriptions share it.
+func (s *Store) Locations() []string {
+	s.mu.RLock()
+	seen := make(map[string]struct{})
+	for _, sub := range s.subs {
+		seen[sub.key] = struct{}{}
+	}
+	s.mu.RUnlock()
+	out := make([]string, 0, len(seen))
+	for loc := range seen {
+		out = append(out, loc)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Evaluate runs every subscription for the normalized location key against data and returns
+// an Event for each threshold that crossed or cleared since the previous evaluation.
+// A threshold that is not crossed on its first evaluation produces no event.
+func (s *Store) Evaluate(key string, data models.WeatherData) []Event {
+	key = validation.NormalizeLocation(key)
+	now := time.Now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []Event
+	for _, sub := range s.subs {
+		if sub.key != key {
+			continue
+		}
+		for i, r := range sub.rules {
+			crossed, value, msg := r.Match(data)
+			if crossed == sub.state[r.Name] {
+				continue
+			}
+			sub.state[r.Name] = crossed
+			typ := EventCleared
+			if crossed {
+				typ = EventCrossed
+			}
+			events = append(events, Event{
+				DeliveryID:     uuid.New().String(),
+				Type:           typ,
+				SubscriptionID: sub.ID,
+				Location:       sub.Location,
+				Rule:           sub.Thresholds[i],
+				Value:          value,
+				Message:        msg,
+				Weather:        data,
+				Timestamp:      now,
+				webhookURL:     sub.WebhookURL,
+				secret:         sub.secret,
+			})
+		}
+	}
+	return events
+}
+
+// authorized reports whether token is the subscription's management token.
+func (sub *Subscription) authorized(token string) bool {
+	hash := sha256.Sum256([]byte(token))
+	return subtle.ConstantTimeCompare(hash[:], sub.tokenHash[:]) == 1
+}
+
+// newToken returns a random 256-bit hex string for tokens and signing secrets.
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// snapshot returns a copy safe to hand to callers outside the lock.
+func (sub *Subscription) snapshot() Subscription {
+	out := *sub
+	out.Thresholds = append([]Rule(nil), sub.Thresholds...)
+	out.secret = ""
+	out.tokenHash = [sha256.Size]byte{}
+	out.rules = nil
+	out.state = nil
+	return out
+}
@@ -0,0 +1,155 @@
+package subscriptions
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// TestStore_Create_Validation verifies threshold, URL and limit validation on Create.
+func TestStore_Create_Validation(t *testing.T) {
+	wind := []Rule{{Name: "wind", Type: "wind_above", Threshold: 10}}
+	tests := []struct {
+		name       string
+		url        string
+		thresholds []Rule
+		wantErr    string
+		wantIs     error
+	}{
+		{name: "no thresholds", url: "https://example.com/hook", wantIs: ErrNoThresholds},
+		{name: "bad threshold type", url: "https://example.com/hook", thresholds: []Rule{{Name: "x", Type: "pressure_below"}}, wantErr: "threshold 0"},
+		{name: "duplicate names", url: "https://example.com/hook", thresholds: []Rule{wind[0], wind[0]}, wantErr: "duplicate name"},
+		{name: "loopback url", url: "http://127.0.0.1/hook", thresholds: wind, wantIs: ErrInvalidWebhookURL},
+		{name: "valid", url: "https://example.com/hook", thresholds: wind},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewStore(0, nil)
+			sub, err := store.Create("chicago", tc.url, tc.thresholds)
+			switch {
+			case tc.wantIs != nil:
+				if !errors.Is(err, tc.wantIs) {
+					t.Fatalf("Create() error = %v, want %v", err, tc.wantIs)
+				}
+			case tc.wantErr != "":
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("Create() error = %v, want containing %q", err, tc.wantErr)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if sub.ID == "" || sub.Thresholds[0].Severity != "warning" {
+					t.Errorf("Create() = %+v, want ID and defaulted severity", sub)
+				}
+			}
+		})
+	}
+}
+
+// TestStore_Create_Limit verifies that the store refuses subscriptions beyond max.
+func TestStore_Create_Limit(t *testing.T) {
+	store := NewStore(1, nil)
+	rules := []Rule{{Name: "wind", Type: "wind_above", Threshold: 10}}
+	if _, err := store.Create("chicago", "https://example.com/a", rules); err != nil {
+		t.Fatalf("first Create() error = %v", err)
+	}
+	if _, err := store.Create("boston", "https://example.com/b", rules); !errors.Is(err, ErrLimitReached) {
+		t.Fatalf("second Create() error = %v, want ErrLimitReached", err)
+	}
+}
+
+// TestStore_Locations_Deduplicated verifies subscriptions sharing a normalized location
+// produce one poll location.
+func TestStore_Locations_Deduplicated(t *testing.T) {
+	store := NewStore(0, nil)
+	rules := []Rule{{Name: "wind", Type: "wind_above", Threshold: 10}}
+	_, _ = store.Create("Chicago", "https://example.com/a", rules)
+	_, _ = store.Create("chicago ", "https://example.com/b", rules)
+	_, _ = store.Create("Boston", "https://example.com/c", rules)
+
+	got := store.Locations()
+	if strings.Join(got, ",") != "boston,chicago" {
+		t.Errorf("Locations() = %v, want [boston chicago]", got)
+	}
+}
+
+// TestStore_Evaluate_CrossAndClear verifies events fire only on state changes and that a
+// threshold not crossed on first evaluation is silent.
+func TestStore_Evaluate_CrossAndClear(t *testing.T) {
+	// Arrange
+	store := NewStore(0, nil)
+	sub, err := store.Create("Chicago", "https://example.com/hook", []Rule{{Name: "wind", Type: "wind_above", Threshold: 10}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Act + Assert: calm first reading is silent
+	if got := store.Evaluate("chicago", models.WeatherData{WindSpeed: 3}); len(got) != 0 {
+		t.Fatalf("Evaluate(calm) = %d events, want 0", len(got))
+	}
+	got := store.Evaluate("chicago", models.WeatherData{WindSpeed: 14})
+	if len(got) != 1 || got[0].Type != EventCrossed || got[0].SubscriptionID != sub.ID || got[0].Value != "14" {
+		t.Fatalf("Evaluate(windy) = %+v, want one crossed event", got)
+	}
+	if sub.Secret == "" || got[0].secret != sub.Secret {
+		t.Errorf("event secret = %q, want the subscription's secret %q", got[0].secret, sub.Secret)
+	}
+	if got := store.Evaluate("chicago", models.WeatherData{WindSpeed: 15}); len(got) != 0 {
+		t.Fatalf("Evaluate(still windy) = %d events, want 0", len(got))
+	}
+	got = store.Evaluate("chicago", models.WeatherData{WindSpeed: 2})
+	if len(got) != 1 || got[0].Type != EventCleared {
+		t.Fatalf("Evaluate(calm again) = %+v, want one cleared event", got)
+	}
+	if got := store.Evaluate("boston", models.WeatherData{WindSpeed: 30}); len(got) != 0 {
+		t.Errorf("Evaluate(other location) = %d events, want 0", len(got))
+	}
+}
+
+// TestStore_Delete verifies deleted subscriptions are gone and unknown IDs return ErrNotFound.
+func TestStore_Delete(t *testing.T) {
+	store := NewStore(0, nil)
+	sub, _ := store.Create("chicago", "https://example.com/hook", []Rule{{Name: "wind", Type: "wind_above", Threshold: 10}})
+
+	if err := store.Delete(sub.ID, sub.Token); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(sub.ID, sub.Token); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(sub.ID, sub.Token); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() twice error = %v, want ErrNotFound", err)
+	}
+}
+
+// TestStore_Token verifies Get and Delete require the token returned by Create, and that the
+// token and signing secret are not exposed afterwards.
+func TestStore_Token(t *testing.T) {
+	store := NewStore(0, nil)
+	rules := []Rule{{Name: "wind", Type: "wind_above", Threshold: 10}}
+	sub, _ := store.Create("chicago", "https://example.com/a", rules)
+	other, _ := store.Create("chicago", "https://example.com/b", rules)
+	if sub.Token == "" || sub.Token == other.Token || sub.Secret == other.Secret || sub.Secret == sub.Token {
+		t.Fatalf("credentials = %+v, %+v, want distinct tokens and secrets", sub, other)
+	}
+
+	if _, err := store.Get(sub.ID, other.Token); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() with other token error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(sub.ID, ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() without token error = %v, want ErrNotFound", err)
+	}
+	got, err := store.Get(sub.ID, sub.Token)
+	if err != nil || got.Token != "" || got.Secret != "" {
+		t.Errorf("Get() = %+v, %v, want subscription without token or secret", got, err)
+	}
+	for _, listed := range store.List() {
+		if listed.Token != "" || listed.Secret != "" {
+			t.Errorf("List() exposes token for %s", listed.ID)
+		}
+	}
+}
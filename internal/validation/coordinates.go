@@ -0,0 +1,71 @@
+package validation
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CoordinatePrecision is the number of decimal places coordinates are quantized to for cache
+// keys and upstream requests (about 1.1 km of latitude), so nearby requests share entries.
+const CoordinatePrecision = 2
+
+// ErrCoordinatesRequired is returned when lat or lon is missing.
+var ErrCoordinatesRequired = errors.New("lat and lon are required")
+
+// ErrLatitudeInvalid is returned when lat is not a number between -90 and 90.
+var ErrLatitudeInvalid = errors.New("lat must be a number between -90 and 90")
+
+// ErrLongitudeInvalid is returned when lon is not a number between -180 and 180.
+var ErrLongitudeInvalid = errors.New("lon must be a number between -180 and 180")
+
+// ValidateCoordinates parses decimal-degree latitude and longitude strings and enforces their
+// ranges. Returns errors suitable for 400 INVALID_COORDINATES responses.
+func ValidateCoordinates(latInput, lonInput string) (lat, lon float64, err error) {
+	latInput, lonInput = strings.TrimSpace(latInput), strings.TrimSpace(lonInput)
+	if latInput == "" || lonInput == "" {
+		return 0, 0, ErrCoordinatesRequired
+	}
+	lat, err = strconv.ParseFloat(latInput, 64)
+	if err != nil || math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return 0, 0, ErrLatitudeInvalid
+	}
+	lon, err = strconv.ParseFloat(lonInput, 64)
+	if err != nil || math.IsNaN(lon) || lon < -180 || lon > 180 {
+		return 0, 0, ErrLongitudeInvalid
+	}
+	return lat, lon, nil
+}
+
+// CoordinateKey quantizes validated coordinates to CoordinatePrecision and formats them as
+// "lat,lon" (e.g. "41.88,-87.63"). The key stands in for a location name through the service
+// and client; ParseCoordinateKey recognizes it.
+func CoordinateKey(lat, lon float64) string {
+	return formatCoordinate(lat) + "," + formatCoordinate(lon)
+}
+
+// ParseCoordinateKey returns the coordinates of a key produced by CoordinateKey. Anything else,
+// including location names that happen to be two numbers ("41,87"), returns ok false.
+func ParseCoordinateKey(key string) (lat, lon float64, ok bool) {
+	latStr, lonStr, found := strings.Cut(key, ",")
+	if !found {
+		return 0, 0, false
+	}
+	lat, lon, err := ValidateCoordinates(latStr, lonStr)
+	if err != nil || formatCoordinate(lat) != latStr || formatCoordinate(lon) != lonStr {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
+
+// formatCoordinate rounds v to CoordinatePrecision places. Values that round to zero format
+// as "0.00", never "-0.00".
+func formatCoordinate(v float64) string {
+	scale := math.Pow10(CoordinatePrecision)
+	v = math.Round(v*scale) / scale
+	if v == 0 {
+		v = 0
+	}
+	return strconv.FormatFloat(v, 'f', CoordinatePrecision, 64)
+}
@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/kjstillabower/weather-alert-service/internal/models"
+)
+
+// ErrUnitsInvalid is returned when units is not a supported unit system.
+var ErrUnitsInvalid = errors.New("units must be one of metric, imperial, standard")
+
+// ErrLanguageInvalid is returned when lang is not a language code such as "fr" or "zh_cn".
+var ErrLanguageInvalid = errors.New("lang must be a language code such as fr or zh_cn")
+
+// ValidateUnits returns the lowercased unit system, defaulting to metric when input is empty.
+// Returns ErrUnitsInvalid for anything other than metric, imperial or standard.
+func ValidateUnits(input string) (string, error) {
+	units := strings.ToLower(strings.TrimSpace(input))
+	switch units {
+	case "":
+		return models.UnitsMetric, nil
+	case models.UnitsMetric, models.UnitsImperial, models.UnitsStandard:
+		return units, nil
+	default:
+		return "", ErrUnitsInvalid
+	}
+}
+
+// ValidateLanguage returns the lowercased language code, or "" when input is empty. Codes are two
+// letters with an optional "_" and two-letter region ("pt_br"), the form the upstream accepts;
+// whether the upstream supports a well-formed code is left to the upstream.
+func ValidateLanguage(input string) (string, error) {
+	lang := strings.ToLower(strings.TrimSpace(input))
+	if lang == "" {
+		return "", nil
+	}
+	base, region, hasRegion := strings.Cut(lang, "_")
+	if !isLowerLetters(base, 2) || (hasRegion && !isLowerLetters(region, 2)) {
+		return "", ErrLanguageInvalid
+	}
+	return lang, nil
+}
+
+// isLowerLetters reports whether s is exactly n ASCII lowercase letters.
+func isLowerLetters(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
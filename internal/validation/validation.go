@@ -20,6 +20,7 @@ var ErrLocationInvalidChars = errors.New("location contains invalid characters")
 
 // ValidateLocation trims the input, enforces length bounds (minLen, maxLen in runes),
 // and restricts to allowed characters: letters (Unicode), digits, space, comma, hyphen.
+// Coordinate keys (see CoordinateKey, used as location search IDs) are also accepted.
 // Returns the trimmed string or an error suitable for 400 INVALID_LOCATION responses.
 // Normalization (e.g. lowercase) is left to the service layer.
 func ValidateLocation(input string, minLen, maxLen int) (string, error) {
@@ -35,6 +36,9 @@ func ValidateLocation(input string, minLen, maxLen int) (string, error) {
 	if maxLen > 0 && n > maxLen {
 		return "", ErrLocationTooLong
 	}
+	if _, _, ok := ParseCoordinateKey(s); ok {
+		return s, nil
+	}
 	for _, c := range r {
 		if !isAllowedLocationRune(c) {
 			return "", ErrLocationInvalidChars
@@ -43,6 +47,12 @@ func ValidateLocation(input string, minLen, maxLen int) (string, error) {
 	return s, nil
 }
 
+// NormalizeLocation trims whitespace and lowercases location. It is the cache-key
+// normalization of the service layer; packages keyed by location use it so their keys match.
+func NormalizeLocation(location string) string {
+	return strings.ToLower(strings.TrimSpace(location))
+}
+
 // isAllowedLocationRune returns true for letters (Unicode), digits, space, comma, hyphen.
 func isAllowedLocationRune(r rune) bool {
 	if unicode.IsLetter(r) || unicode.IsNumber(r) {
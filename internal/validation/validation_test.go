@@ -132,3 +132,157 @@ func TestValidateLocation_LengthBoundaries(t *testing.T) {
 		t.Errorf("over max: err = %v, want ErrLocationTooLong", err)
 	}
 }
+
+func TestValidateCoordinates(t *testing.T) {
+	tests := []struct {
+		name    string
+		lat     string
+		lon     string
+		wantErr error
+	}{
+		{"valid", "41.8781", "-87.6298", nil},
+		{"bounds", "-90", "180", nil},
+		{"padded", " 41.88 ", " -87.63 ", nil},
+		{"missing lat", "", "-87.63", ErrCoordinatesRequired},
+		{"missing lon", "41.88", " ", ErrCoordinatesRequired},
+		{"lat out of range", "90.01", "0", ErrLatitudeInvalid},
+		{"lat not a number", "north", "0", ErrLatitudeInvalid},
+		{"lat NaN", "NaN", "0", ErrLatitudeInvalid},
+		{"lon out of range", "0", "-180.5", ErrLongitudeInvalid},
+		{"lon infinite", "0", "Inf", ErrLongitudeInvalid},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := ValidateCoordinates(tc.lat, tc.lon)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCoordinateKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     string
+	}{
+		{"rounds to two places", 41.8781, -87.6298, "41.88,-87.63"},
+		{"pads", 10, 20.5, "10.00,20.50"},
+		{"negative zero", -0.001, -0.004, "0.00,0.00"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CoordinateKey(tc.lat, tc.lon)
+			if got != tc.want {
+				t.Errorf("CoordinateKey() = %q, want %q", got, tc.want)
+			}
+			lat, lon, ok := ParseCoordinateKey(got)
+			if !ok || CoordinateKey(lat, lon) != got {
+				t.Errorf("ParseCoordinateKey(%q) = %v, %v, %v; want round trip", got, lat, lon, ok)
+			}
+		})
+	}
+}
+
+func TestParseCoordinateKey_RejectsNonKeys(t *testing.T) {
+	for _, input := range []string{"chicago", "41,87", "41.878,-87.63", "91.00,0.00", "41.88", "41.88,-87.63,1.00"} {
+		if _, _, ok := ParseCoordinateKey(input); ok {
+			t.Errorf("ParseCoordinateKey(%q) ok = true, want false", input)
+		}
+	}
+}
+
+func TestValidateUnits(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"", "metric", nil},
+		{"Imperial", "imperial", nil},
+		{" standard ", "standard", nil},
+		{"kelvin", "", ErrUnitsInvalid},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ValidateUnits(tc.input)
+			if got != tc.want || !errors.Is(err, tc.wantErr) {
+				t.Errorf("ValidateUnits(%q) = %q, %v; want %q, %v", tc.input, got, err, tc.want, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLanguage(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"", "", nil},
+		{"FR", "fr", nil},
+		{"zh_CN", "zh_cn", nil},
+		{"fra", "", ErrLanguageInvalid},
+		{"pt-br", "", ErrLanguageInvalid},
+		{"en_", "", ErrLanguageInvalid},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ValidateLanguage(tc.input)
+			if got != tc.want || !errors.Is(err, tc.wantErr) {
+				t.Errorf("ValidateLanguage(%q) = %q, %v; want %q, %v", tc.input, got, err, tc.want, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLocation_CoordinateKey(t *testing.T) {
+	got, err := ValidateLocation(" 39.80,-89.64 ", 1, 100)
+	if err != nil || got != "39.80,-89.64" {
+		t.Errorf("ValidateLocation(coordinate key) = %q, %v; want accepted", got, err)
+	}
+	if _, err := ValidateLocation("39.8,-89.64", 1, 100); !errors.Is(err, ErrLocationInvalidChars) {
+		t.Errorf("ValidateLocation(non-canonical) error = %v, want ErrLocationInvalidChars", err)
+	}
+}
+
+// TestNormalizeLocation verifies that NormalizeLocation trims whitespace, converts to lowercase,
+// and handles various input formats correctly.
+func TestNormalizeLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "trim and lower",
+			in:   " Seattle ",
+			want: "seattle",
+		},
+		{
+			name: "already normalized",
+			in:   "seattle",
+			want: "seattle",
+		},
+		{
+			name: "mixed case",
+			in:   "SeAtTlE",
+			want: "seattle",
+		},
+		{
+			name: "with spaces",
+			in:   "  New York  ",
+			want: "new york",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NormalizeLocation(tc.in)
+			if got != tc.want {
+				t.Fatalf("NormalizeLocation(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
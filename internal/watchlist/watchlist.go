@@ -0,0 +1,179 @@
+package watchlist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/kjstillabower/weather-alert-service/internal/validation"
+)
+
+var (
+	// ErrNotFound is returned when removing a location that is not on the watchlist.
+	ErrNotFound = errors.New("location not on watchlist")
+	// ErrFull is returned when adding would exceed the configured maximum.
+	ErrFull = errors.New("watchlist is full")
+)
+
+// Listener is called with the full list after every successful change. Listeners run
+// synchronously under the store's lock, so they must be quick and must not call back into
+// the store.
+type Listener func(locations []string)
+
+// Store is the runtime-editable set of watched locations. It drives the metrics location
+// allow-list and the cache warmer. When path is set, every change is written to disk before
+// it takes effect; a failed write leaves the list unchanged.
+type Store struct {
+	mu        sync.Mutex
+	path      string
+	max       int
+	locations map[string]struct{}
+	listeners []Listener
+}
+
+type fileFormat struct {
+	Locations []string `json:"locations"`
+}
+
+// Open loads the watchlist from path. If the file does not exist, the list is seeded from
+// seed (typically metrics.tracked_locations) and the file is created on the first change.
+// An empty path keeps the list in memory only. max caps the list size (0 = unlimited).
+func Open(path string, seed []string, max int) (*Store, error) {
+	s := &Store{path: path, max: max, locations: make(map[string]struct{})}
+	initial := seed
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			var f fileFormat
+			if err := json.Unmarshal(data, &f); err != nil {
+				return nil, fmt.Errorf("parse watchlist %s: %w", path, err)
+			}
+			initial = f.Locations
+		case !os.IsNotExist(err):
+			return nil, fmt.Errorf("read watchlist: %w", err)
+		}
+	}
+	for _, loc := range initial {
+		if key := validation.NormalizeLocation(loc); key != "" {
+			s.locations[key] = struct{}{}
+		}
+	}
+	return s, nil
+}
+
+// OnChange registers a listener and immediately calls it with the current list, so callers
+// need no separate initialization. Call during startup.
+func (s *Store) OnChange(l Listener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, l)
+	l(s.listLocked())
+}
+
+// List returns the watched locations, sorted.
+func (s *Store) List() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listLocked()
+}
+
+// Contains reports whether location is watched.
+func (s *Store) Contains(location string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.locations[validation.NormalizeLocation(location)]
+	return ok
+}
+
+// Add watches location. Returns false when it was already watched (no change, no write).
+func (s *Store) Add(location string) (bool, error) {
+	key := validation.NormalizeLocation(location)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.locations[key]; ok {
+		return false, nil
+	}
+	if s.max > 0 && len(s.locations) >= s.max {
+		return false, ErrFull
+	}
+	s.locations[key] = struct{}{}
+	if err := s.commitLocked(); err != nil {
+		delete(s.locations, key)
+		return false, err
+	}
+	return true, nil
+}
+
+// Remove stops watching location or returns ErrNotFound.
+func (s *Store) Remove(location string) error {
+	key := validation.NormalizeLocation(location)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.locations[key]; !ok {
+		return ErrNotFound
+	}
+	delete(s.locations, key)
+	if err := s.commitLocked(); err != nil {
+		s.locations[key] = struct{}{}
+		return err
+	}
+	return nil
+}
+
+// commitLocked persists the list and notifies listeners.
+func (s *Store) commitLocked() error {
+	list := s.listLocked()
+	if s.path != "" {
+		if err := writeFileAtomic(s.path, fileFormat{Locations: list}); err != nil {
+			return fmt.Errorf("persist watchlist: %w", err)
+		}
+	}
+	for _, l := range s.listeners {
+		l(list)
+	}
+	return nil
+}
+
+func (s *Store) listLocked() []string {
+	out := make([]string, 0, len(s.locations))
+	for loc := range s.locations {
+		out = append(out, loc)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// writeFileAtomic writes v as JSON to a temp file in the target directory and renames it
+// over path, so a crash mid-write never leaves a truncated watchlist.
+func writeFileAtomic(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".watchlist-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,116 @@
+package watchlist
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestOpen_SeedsWhenFileMissing verifies the seed list is used (normalized) when no file
+// exists, and that no file is written until the first change.
+func TestOpen_SeedsWhenFileMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watchlist.json")
+
+	s, err := Open(path, []string{" Seattle ", "boston", "BOSTON"}, 0)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	if got := strings.Join(s.List(), ","); got != "boston,seattle" {
+		t.Errorf("List() = %q, want boston,seattle", got)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("file exists before any change (stat err = %v)", err)
+	}
+}
+
+// TestStore_PersistsAcrossOpen verifies changes are written and the file wins over the seed
+// on the next Open.
+func TestStore_PersistsAcrossOpen(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "nested", "watchlist.json")
+	s, _ := Open(path, []string{"seattle"}, 0)
+
+	// Act
+	if added, err := s.Add("Paris"); err != nil || !added {
+		t.Fatalf("Add() = (%v, %v), want (true, nil)", added, err)
+	}
+	if err := s.Remove("seattle"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	// Assert
+	reopened, err := Open(path, []string{"seattle", "tokyo"}, 0)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if got := strings.Join(reopened.List(), ","); got != "paris" {
+		t.Errorf("reopened List() = %q, want paris (file overrides seed)", got)
+	}
+}
+
+// TestStore_AddRemoveErrors verifies duplicate adds, the size cap and unknown removes.
+func TestStore_AddRemoveErrors(t *testing.T) {
+	s, _ := Open("", nil, 1)
+
+	if added, _ := s.Add("oslo"); !added {
+		t.Fatal("first Add() added = false")
+	}
+	if added, err := s.Add("OSLO"); added || err != nil {
+		t.Errorf("duplicate Add() = (%v, %v), want (false, nil)", added, err)
+	}
+	if _, err := s.Add("rome"); !errors.Is(err, ErrFull) {
+		t.Errorf("Add() over cap error = %v, want ErrFull", err)
+	}
+	if err := s.Remove("rome"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Remove(unknown) error = %v, want ErrNotFound", err)
+	}
+}
+
+// TestStore_PersistFailureRollsBack verifies a failed write leaves the list and listeners
+// untouched.
+func TestStore_PersistFailureRollsBack(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(filepath.Join(dir, "sub", "watchlist.json"), []string{"seattle"}, 0)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	// A regular file where the parent directory should be makes every write fail.
+	if err := os.WriteFile(filepath.Join(dir, "sub"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var notified int
+	s.OnChange(func([]string) { notified++ })
+
+	if _, err := s.Add("paris"); err == nil {
+		t.Fatal("Add() error = nil, want persist error")
+	}
+	if err := s.Remove("seattle"); err == nil {
+		t.Fatal("Remove() error = nil, want persist error")
+	}
+
+	if got := strings.Join(s.List(), ","); got != "seattle" {
+		t.Errorf("List() = %q, want unchanged seattle", got)
+	}
+	if notified != 1 {
+		t.Errorf("listener calls = %d, want 1 (registration only)", notified)
+	}
+}
+
+// TestStore_OnChange verifies listeners get the current list on registration and after changes.
+func TestStore_OnChange(t *testing.T) {
+	s, _ := Open("", []string{"seattle"}, 0)
+	var got []string
+	s.OnChange(func(locations []string) { got = locations })
+	if strings.Join(got, ",") != "seattle" {
+		t.Fatalf("initial listener call = %v, want [seattle]", got)
+	}
+
+	_, _ = s.Add("boston")
+
+	if strings.Join(got, ",") != "boston,seattle" {
+		t.Errorf("listener after Add = %v, want [boston seattle]", got)
+	}
+}